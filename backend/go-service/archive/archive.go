@@ -0,0 +1,176 @@
+// Package archive keeps the raw HTML fetched from dictionary sources, so
+// entries can be re-parsed after a parser fix without fetching them again.
+//
+// Snapshots are stored under "<host>/<escaped path>/<time>-<hash>.html",
+// either in a directory or in an object store that accepts HTTP PUT.
+package archive
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"vocabulary-app/backend/go-service/config"
+)
+
+// queueSize bounds snapshots waiting to be written; beyond it they are dropped
+// rather than slowing down scrapes.
+const queueSize = 64
+
+// Archive writes snapshots in the background.
+type Archive struct {
+	store store
+	queue chan snapshot
+
+	mu   sync.Mutex
+	last map[string]string // URL -> hash of its last saved snapshot
+}
+
+type snapshot struct {
+	url       string
+	body      []byte
+	fetchedAt time.Time
+}
+
+type store interface {
+	put(key string, body []byte) error
+}
+
+// New creates an archive for the configured backend and starts its writer.
+func New(cfg config.ArchiveConfig) (*Archive, error) {
+	var s store
+	switch cfg.Backend {
+	case "filesystem":
+		if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+			return nil, fmt.Errorf("creating archive directory: %w", err)
+		}
+		s = dirStore{dir: cfg.Dir}
+	case "http":
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("ARCHIVE_URL is required for the http archive backend")
+		}
+		s = httpStore{
+			baseURL: strings.TrimSuffix(cfg.URL, "/"),
+			token:   cfg.Token,
+			client:  &http.Client{Timeout: cfg.Timeout},
+		}
+	default:
+		return nil, fmt.Errorf("unknown archive backend %q (want filesystem or http)", cfg.Backend)
+	}
+
+	a := &Archive{store: s, queue: make(chan snapshot, queueSize), last: make(map[string]string)}
+	go a.run()
+	return a, nil
+}
+
+// Save queues body, fetched from rawURL, for archiving. Unchanged pages are
+// only stored once.
+func (a *Archive) Save(rawURL string, body []byte, fetchedAt time.Time) {
+	select {
+	case a.queue <- snapshot{url: rawURL, body: body, fetchedAt: fetchedAt}:
+	default:
+		slog.Warn("archive queue full, dropping snapshot", "url", rawURL)
+	}
+}
+
+func (a *Archive) run() {
+	for s := range a.queue {
+		sum := sha256.Sum256(s.body)
+		hash := hex.EncodeToString(sum[:8])
+
+		a.mu.Lock()
+		unchanged := a.last[s.url] == hash
+		a.mu.Unlock()
+		if unchanged {
+			continue
+		}
+
+		key, err := Key(s.url, s.fetchedAt, hash)
+		if err != nil {
+			slog.Warn("not archiving snapshot", "url", s.url, "error", err)
+			continue
+		}
+		if err := a.store.put(key, s.body); err != nil {
+			slog.Warn("archiving snapshot failed", "url", s.url, "key", key, "error", err)
+			continue
+		}
+		slog.Debug("snapshot archived", "url", s.url, "key", key, "bytes", len(s.body))
+
+		a.mu.Lock()
+		a.last[s.url] = hash
+		a.mu.Unlock()
+	}
+}
+
+// Key returns the storage key for a snapshot of rawURL. The path, query and
+// fragment are escaped into one directory name, so all snapshots of a page
+// sit side by side in time order.
+func Key(rawURL string, fetchedAt time.Time, hash string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("URL has no host")
+	}
+	page := strings.TrimPrefix(u.Path, "/")
+	if u.RawQuery != "" {
+		page += "?" + u.RawQuery
+	}
+	if u.Fragment != "" {
+		page += "#" + u.Fragment
+	}
+	if page == "" {
+		page = "index"
+	}
+	return u.Host + "/" + url.PathEscape(page) + "/" + fetchedAt.UTC().Format("20060102T150405Z") + "-" + hash + ".html", nil
+}
+
+// dirStore writes snapshots below a local directory.
+type dirStore struct {
+	dir string
+}
+
+func (s dirStore) put(key string, body []byte) error {
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, body, 0o644)
+}
+
+// httpStore PUTs snapshots to an object store, e.g. a bucket URL.
+type httpStore struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+func (s httpStore) put(key string, body []byte) error {
+	req, err := http.NewRequest(http.MethodPut, s.baseURL+"/"+key, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/html; charset=utf-8")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("object store returned %s", resp.Status)
+	}
+	return nil
+}
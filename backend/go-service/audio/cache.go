@@ -0,0 +1,148 @@
+package audio
+
+import (
+	"container/list"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// diskCache keeps fetched recordings in a directory, each file next to a
+// .type file with its content type, and removes the least recently used
+// once they take up more than maxBytes. A file's modification time records
+// its last use, so the order survives restarts.
+type diskCache struct {
+	dir      string
+	maxBytes int64
+
+	mu    sync.Mutex
+	size  int64
+	order *list.List // of *cachedFile; front is the most recently used
+	files map[string]*list.Element
+}
+
+type cachedFile struct {
+	name string
+	size int64 // of the file and its .type file
+}
+
+// openDiskCache indexes the files already in dir, trimming them to
+// maxBytes.
+func openDiskCache(dir string, maxBytes int64) (*diskCache, error) {
+	c := &diskCache{dir: dir, maxBytes: maxBytes, order: list.New(), files: map[string]*list.Element{}}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	type found struct {
+		cachedFile
+		used time.Time
+	}
+	var cached []found
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || strings.HasPrefix(name, ".tmp-") || strings.HasSuffix(name, ".type") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		size := info.Size()
+		if typeInfo, err := os.Stat(filepath.Join(dir, name+".type")); err == nil {
+			size += typeInfo.Size()
+		}
+		cached = append(cached, found{cachedFile{name, size}, info.ModTime()})
+	}
+	sort.Slice(cached, func(i, j int) bool { return cached[i].used.Before(cached[j].used) })
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, f := range cached {
+		file := f.cachedFile
+		c.files[f.name] = c.order.PushFront(&file)
+		c.size += f.size
+	}
+	c.trim()
+	return c, nil
+}
+
+// get returns a cached file and its content type, and marks it used.
+func (c *diskCache) get(name string) ([]byte, string, bool) {
+	path := filepath.Join(c.dir, name)
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", false
+	}
+	contentType, _ := os.ReadFile(path + ".type")
+
+	c.mu.Lock()
+	if el, ok := c.files[name]; ok {
+		c.order.MoveToFront(el)
+	}
+	c.mu.Unlock()
+	now := time.Now()
+	os.Chtimes(path, now, now)
+	return body, string(contentType), true
+}
+
+// put stores a file, then removes the least recently used ones while the
+// cache is over its size.
+func (c *diskCache) put(name string, body []byte, contentType string) {
+	path := filepath.Join(c.dir, name)
+	// Write the type first: a body without one is still served, the reverse isn't
+	if err := writeFile(path+".type", []byte(contentType)); err != nil {
+		return
+	}
+	if err := writeFile(path, body); err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	size := int64(len(body) + len(contentType))
+	if el, ok := c.files[name]; ok {
+		file := el.Value.(*cachedFile)
+		c.size += size - file.size
+		file.size = size
+		c.order.MoveToFront(el)
+	} else {
+		c.files[name] = c.order.PushFront(&cachedFile{name, size})
+		c.size += size
+	}
+	c.trim()
+}
+
+// trim removes the least recently used files until the cache fits in
+// maxBytes. c.mu must be held.
+func (c *diskCache) trim() {
+	for c.size > c.maxBytes && c.order.Len() > 0 {
+		file := c.order.Remove(c.order.Back()).(*cachedFile)
+		delete(c.files, file.name)
+		c.size -= file.size
+		path := filepath.Join(c.dir, file.name)
+		os.Remove(path)
+		os.Remove(path + ".type")
+	}
+}
+
+// writeFile writes atomically, so concurrent readers never see a partial file.
+func writeFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
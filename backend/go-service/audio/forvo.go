@@ -0,0 +1,93 @@
+package audio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/langcode"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+)
+
+// forvoMaxClips caps how many recordings are kept per word.
+const forvoMaxClips = 3
+
+// forvoLanguages maps canonical language codes to Forvo's.
+var forvoLanguages = map[string]string{
+	langcode.Bokmal:  "no",
+	langcode.Nynorsk: "no",
+	langcode.English: "en",
+	langcode.Spanish: "es",
+	langcode.German:  "de",
+}
+
+// Forvo looks up native-speaker recordings through the Forvo API.
+type Forvo struct {
+	key     string
+	baseURL string
+	client  *http.Client
+}
+
+// NewForvo creates a Forvo client with the given API key.
+func NewForvo(key string, timeout time.Duration) *Forvo {
+	return &Forvo{
+		key:     key,
+		baseURL: "https://apifree.forvo.com",
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+// Lookup returns the best-rated recordings of word in a canonical language.
+// Languages Forvo doesn't cover return no clips.
+func (f *Forvo) Lookup(ctx context.Context, word, language string) ([]models.AudioClip, error) {
+	lang, ok := forvoLanguages[language]
+	if !ok {
+		return nil, nil
+	}
+	endpoint := fmt.Sprintf("%s/key/%s/format/json/action/word-pronunciations/word/%s/language/%s/order/rate-desc",
+		f.baseURL, url.PathEscape(f.key), url.PathEscape(word), lang)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		// The error would include the URL, and with it the API key
+		return nil, fmt.Errorf("forvo: request failed")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("forvo returned %s", resp.Status)
+	}
+
+	var body struct {
+		Items []struct {
+			PathMP3  string `json:"pathmp3"`
+			Country  string `json:"country"`
+			Username string `json:"username"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("forvo: decoding response: %w", err)
+	}
+
+	var clips []models.AudioClip
+	for _, item := range body.Items {
+		if item.PathMP3 == "" {
+			continue
+		}
+		clips = append(clips, models.AudioClip{
+			URL:     item.PathMP3,
+			Source:  "forvo",
+			Variant: item.Country,
+			Speaker: item.Username,
+		})
+		if len(clips) == forvoMaxClips {
+			break
+		}
+	}
+	return clips, nil
+}
@@ -0,0 +1,154 @@
+// Package audio serves pronunciation recordings through the Go service, so
+// the frontend can play them without running into CORS, and looks up
+// recordings from Forvo for words the dictionaries don't voice.
+//
+// Fetched files are cached on disk under the SHA-256 of their URL; a
+// recording's URL doesn't change, so cached files never expire, but the
+// least recently used are removed once the cache outgrows its size.
+package audio
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/sources"
+
+	"vocabulary-app/backend/go-service/config"
+)
+
+var (
+	// ErrNotAllowed is returned for URLs outside the allowed hosts.
+	ErrNotAllowed = errors.New("audio host not allowed")
+	// ErrNotFound is returned when the upstream has no such file.
+	ErrNotFound = errors.New("audio not found")
+	// ErrTooLarge is returned for files above the size limit.
+	ErrTooLarge = errors.New("audio file too large")
+)
+
+// maxRedirects is how many redirects a fetch follows, as http.Client does
+// by default.
+const maxRedirects = 10
+
+// Proxy fetches and caches audio files from allowed hosts.
+type Proxy struct {
+	cache   *diskCache
+	hosts   []string
+	maxSize int64
+	client  *http.Client
+}
+
+// NewProxy creates a proxy caching up to cfg.CacheMaxBytes into
+// cfg.CacheDir.
+func NewProxy(cfg config.AudioConfig) (*Proxy, error) {
+	if err := os.MkdirAll(cfg.CacheDir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating audio cache directory: %w", err)
+	}
+	cache, err := openDiskCache(cfg.CacheDir, cfg.CacheMaxBytes)
+	if err != nil {
+		return nil, fmt.Errorf("reading audio cache directory: %w", err)
+	}
+	p := &Proxy{
+		cache:   cache,
+		hosts:   cfg.AllowedHosts,
+		maxSize: cfg.MaxBytes,
+	}
+	p.client = &http.Client{
+		Transport:     sources.Default.Transport(nil),
+		Timeout:       cfg.Timeout,
+		CheckRedirect: p.checkRedirect,
+	}
+	return p, nil
+}
+
+// Get returns the audio at rawURL and its content type, from the cache when
+// possible.
+func (p *Proxy) Get(ctx context.Context, rawURL string) ([]byte, string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, "", fmt.Errorf("%w: invalid URL", ErrNotAllowed)
+	}
+	if !p.allowed(u.Hostname()) {
+		return nil, "", fmt.Errorf("%w: %s", ErrNotAllowed, u.Hostname())
+	}
+
+	sum := sha256.Sum256([]byte(u.String()))
+	name := hex.EncodeToString(sum[:])
+	if body, contentType, ok := p.cache.get(name); ok {
+		return body, contentType, nil
+	}
+
+	body, contentType, err := p.fetch(ctx, u.String())
+	if err != nil {
+		return nil, "", err
+	}
+	p.cache.put(name, body, contentType)
+	return body, contentType, nil
+}
+
+func (p *Proxy) fetch(ctx context.Context, rawURL string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return nil, "", ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		return nil, "", fmt.Errorf("audio upstream returned %s", resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, p.maxSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if int64(len(body)) > p.maxSize {
+		return nil, "", ErrTooLarge
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if !strings.HasPrefix(contentType, "audio/") {
+		// Some hosts serve recordings as octet-stream; sniff instead
+		contentType = http.DetectContentType(body)
+		if !strings.HasPrefix(contentType, "audio/") && !strings.HasPrefix(contentType, "application/ogg") {
+			return nil, "", fmt.Errorf("%w: upstream sent %s", ErrNotFound, contentType)
+		}
+	}
+	return body, contentType, nil
+}
+
+// checkRedirect applies the host allow-list to every redirect, so an
+// allowed host can't send the proxy elsewhere.
+func (p *Proxy) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	if (req.URL.Scheme != "http" && req.URL.Scheme != "https") || !p.allowed(req.URL.Hostname()) {
+		return fmt.Errorf("%w: redirected to %s", ErrNotAllowed, req.URL.Hostname())
+	}
+	return nil
+}
+
+// allowed reports whether host is one of the allowed hosts or a subdomain
+// of one.
+func (p *Proxy) allowed(host string) bool {
+	host = strings.ToLower(host)
+	for _, h := range p.hosts {
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+	return false
+}
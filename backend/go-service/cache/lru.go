@@ -0,0 +1,139 @@
+// Package cache holds scraped entries in memory, so words looked up again
+// and again (a review session going through a deck) are answered without
+// another scrape.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LRU is a size-bounded cache that evicts the least recently used value
+// when full, and drops values older than its TTL. It is safe for
+// concurrent use.
+type LRU[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	order    *list.List // front is the most recently used
+	items    map[K]*list.Element
+
+	hits, misses, evictions uint64
+}
+
+type item[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+// Stats are an LRU's size and hit/miss counters since it was created.
+type Stats struct {
+	Size      int    `json:"size"`
+	Capacity  int    `json:"capacity"`
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+	// HitRate is the share of lookups that were hits, 0 before any.
+	HitRate float64 `json:"hit_rate"`
+}
+
+// New creates an LRU holding up to capacity values, each for at most ttl;
+// a ttl of 0 keeps values until they are evicted.
+func New[K comparable, V any](capacity int, ttl time.Duration) *LRU[K, V] {
+	return &LRU[K, V]{
+		capacity: max(capacity, 1),
+		ttl:      ttl,
+		order:    list.New(),
+		items:    make(map[K]*list.Element),
+	}
+}
+
+// Get returns the value for key and marks it used, unless it is missing
+// or has expired.
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if ok && c.ttl > 0 && time.Now().After(el.Value.(*item[K, V]).expires) {
+		c.remove(el)
+		ok = false
+	}
+	if !ok {
+		c.misses++
+		var zero V
+		return zero, false
+	}
+	c.hits++
+	c.order.MoveToFront(el)
+	return el.Value.(*item[K, V]).value, true
+}
+
+// Contains reports whether key holds an unexpired value, without marking
+// it used or counting a hit or miss.
+func (c *LRU[K, V]) Contains(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	return ok && (c.ttl == 0 || !time.Now().After(el.Value.(*item[K, V]).expires))
+}
+
+// Add stores value for key, evicting the least recently used value if the
+// cache is full.
+func (c *LRU[K, V]) Add(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var expires time.Time
+	if c.ttl > 0 {
+		expires = time.Now().Add(c.ttl)
+	}
+	if el, ok := c.items[key]; ok {
+		it := el.Value.(*item[K, V])
+		it.value, it.expires = value, expires
+		c.order.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.order.PushFront(&item[K, V]{key: key, value: value, expires: expires})
+	if c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+		c.evictions++
+	}
+}
+
+// RemoveFunc drops the values whose keys match, and returns how many it
+// dropped.
+func (c *LRU[K, V]) RemoveFunc(match func(key K) bool) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	removed := 0
+	for key, el := range c.items {
+		if match(key) {
+			c.remove(el)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Stats reports the cache's size and counters.
+func (c *LRU[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := Stats{
+		Size:      c.order.Len(),
+		Capacity:  c.capacity,
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+	}
+	if lookups := c.hits + c.misses; lookups > 0 {
+		stats.HitRate = float64(c.hits) / float64(lookups)
+	}
+	return stats
+}
+
+func (c *LRU[K, V]) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.items, el.Value.(*item[K, V]).key)
+}
@@ -0,0 +1,158 @@
+// Package canary periodically scrapes a known set of words per language and
+// checks that the fields a healthy scrape always fills are non-empty, so
+// selector drift after an upstream redesign is noticed before users see
+// empty results.
+package canary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+)
+
+// Health of a language's canaries.
+const (
+	StatusOK    = "ok"
+	StatusDrift = "drift" // scrapes succeeded but expected fields came back empty
+	StatusError = "error" // scrapes failed outright
+)
+
+// ScrapeFunc scrapes a single word in a canonical language.
+type ScrapeFunc func(ctx context.Context, word, language string) (models.WordEntry, error)
+
+// WordResult is the outcome of checking one canary word.
+type WordResult struct {
+	Word    string   `json:"word"`
+	Missing []string `json:"missing,omitempty"` // fields that came back empty
+	Error   string   `json:"error,omitempty"`
+}
+
+// LanguageStatus is the result of the latest canary round for a language.
+type LanguageStatus struct {
+	Language  string       `json:"language"`
+	Status    string       `json:"status"`
+	CheckedAt time.Time    `json:"checked_at"`
+	Words     []WordResult `json:"words"`
+	// Failures counts consecutive rounds that were not ok.
+	Failures int `json:"consecutive_failures"`
+}
+
+// Monitor runs the canary checks.
+type Monitor struct {
+	scrape   ScrapeFunc
+	words    map[string][]string // language -> canary words
+	interval time.Duration
+
+	mu     sync.Mutex
+	status map[string]LanguageStatus
+}
+
+// NewMonitor creates a monitor for specs of the form "language:word", e.g.
+// "no-bm:hund", checked every interval.
+func NewMonitor(scrape ScrapeFunc, specs []string, interval time.Duration) (*Monitor, error) {
+	words := map[string][]string{}
+	for _, spec := range specs {
+		language, word, ok := strings.Cut(spec, ":")
+		if !ok || language == "" || word == "" {
+			return nil, fmt.Errorf("invalid canary %q, want language:word", spec)
+		}
+		words[language] = append(words[language], word)
+	}
+	return &Monitor{scrape: scrape, words: words, interval: interval, status: map[string]LanguageStatus{}}, nil
+}
+
+// Run checks the canaries right away and then every interval until ctx is
+// cancelled.
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		m.CheckAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckAll runs one round of checks for every language.
+func (m *Monitor) CheckAll(ctx context.Context) {
+	for language, words := range m.words {
+		m.check(ctx, language, words)
+	}
+}
+
+func (m *Monitor) check(ctx context.Context, language string, words []string) {
+	status := LanguageStatus{Language: language, Status: StatusOK, CheckedAt: time.Now()}
+	for _, word := range words {
+		result := WordResult{Word: word}
+		entry, err := m.scrape(ctx, word, language)
+		if errors.Is(err, models.ErrNotFound) {
+			// Nothing found for a word that is there is drift, not a failure
+			entry, err = models.WordEntry{}, nil
+		}
+		if err != nil {
+			result.Error = err.Error()
+			status.Status = StatusError
+		} else if result.Missing = missingFields(entry); len(result.Missing) > 0 && status.Status == StatusOK {
+			status.Status = StatusDrift
+		}
+		status.Words = append(status.Words, result)
+	}
+
+	m.mu.Lock()
+	if status.Status != StatusOK {
+		status.Failures = m.status[language].Failures + 1
+	}
+	m.status[language] = status
+	m.mu.Unlock()
+
+	if status.Status == StatusOK {
+		slog.Debug("canary check passed", "language", language, "words", len(words))
+	} else {
+		slog.Warn("canary check failed, scraper selectors may have drifted", "language", language, "status", status.Status, "consecutive_failures", status.Failures, "results", status.Words)
+	}
+}
+
+// missingFields lists the fields of a healthy entry that came back empty.
+// Inflections are only expected when the entry doesn't say they're partial.
+func missingFields(entry models.WordEntry) []string {
+	if len(entry.Senses) == 0 {
+		return []string{"senses"}
+	}
+	var missing []string
+	has := func(field string, ok func(models.SenseEntry) bool) {
+		for _, s := range entry.Senses {
+			if ok(s) {
+				return
+			}
+		}
+		missing = append(missing, field)
+	}
+	has("category", func(s models.SenseEntry) bool { return s.Category != "" })
+	has("meanings", func(s models.SenseEntry) bool { return len(s.Meanings) > 0 && s.Meanings[0].Description != "" })
+	if !entry.InflectionsPartial {
+		has("word_forms", func(s models.SenseEntry) bool { return len(s.WordForms) > 0 })
+	}
+	return missing
+}
+
+// Status returns the latest result per language, sorted by language.
+func (m *Monitor) Status() []LanguageStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	statuses := make([]LanguageStatus, 0, len(m.status))
+	for _, s := range m.status {
+		statuses = append(statuses, s)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Language < statuses[j].Language })
+	return statuses
+}
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+
+	"vocabulary-app/backend/go-service/requestid"
+)
+
+// AuditEvent describes an action to record in the shared audit log.
+type AuditEvent struct {
+	Action     string                 `json:"action"`
+	EntityType string                 `json:"entity_type"`
+	EntityID   string                 `json:"entity_id,omitempty"`
+	UserID     int                    `json:"user_id,omitempty"`
+	UserEmail  string                 `json:"user_email,omitempty"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+	RequestID  string                 `json:"request_id,omitempty"`
+	Service    string                 `json:"service"`
+}
+
+// RecordAudit reports an event to the audit log through c in the background.
+// Auditing is best-effort and never blocks or fails the caller.
+func RecordAudit(ctx context.Context, c PythonClient, event AuditEvent) {
+	event.Service = "go"
+	if event.RequestID == "" {
+		event.RequestID = requestid.FromContext(ctx)
+	}
+
+	// Detach from the request so the send isn't cancelled when the handler
+	// returns, but keep the request ID for correlation
+	sendCtx := requestid.NewContext(context.Background(), event.RequestID)
+	go func() {
+		if err := c.SendAudit(sendCtx, event); err != nil {
+			slog.WarnContext(sendCtx, "recording audit event failed", "action", event.Action, "entity_id", event.EntityID, "error", err)
+		}
+	}()
+}
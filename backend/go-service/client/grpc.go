@@ -0,0 +1,180 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"vocabulary-app/backend/go-service/config"
+	"vocabulary-app/backend/go-service/ingestpb"
+	"vocabulary-app/backend/go-service/requestid"
+	"vocabulary-app/backend/go-service/wordentrypb"
+)
+
+// GRPCPythonClient delivers entries through the Python service's WordIngest
+// gRPC service. Deadlines from ctx (and the per-attempt timeout) travel with
+// each call, so the Python side can abandon work the caller gave up on.
+// Audit events are not part of WordIngest and still go over HTTP; retries
+// and the circuit breaker are shared with the embedded HTTP client.
+type GRPCPythonClient struct {
+	*HTTPPythonClient
+	conn   *grpc.ClientConn
+	ingest ingestpb.WordIngestClient
+}
+
+// NewGRPCPythonClient connects to the WordIngest service at cfg.GRPCAddr.
+// The connection is established lazily on the first call.
+func NewGRPCPythonClient(cfg config.PythonServiceConfig, httpClient *http.Client) (*GRPCPythonClient, error) {
+	conn, err := grpc.NewClient(cfg.GRPCAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("connecting to WordIngest at %s: %w", cfg.GRPCAddr, err)
+	}
+	return &GRPCPythonClient{
+		HTTPPythonClient: NewPythonClient(cfg, httpClient),
+		conn:             conn,
+		ingest:           ingestpb.NewWordIngestClient(conn),
+	}, nil
+}
+
+// Close closes the gRPC connection.
+func (c *GRPCPythonClient) Close() error {
+	return c.conn.Close()
+}
+
+// SendWord stores a single entry with the Ingest RPC.
+func (c *GRPCPythonClient) SendWord(ctx context.Context, entry models.WordEntry) error {
+	err := c.call(ctx, func(ctx context.Context) error {
+		_, err := c.ingest.Ingest(c.outgoing(ctx), &ingestpb.IngestRequest{Entry: entryToProto(entry)})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("error sending data to Python: %w", err)
+	}
+	return nil
+}
+
+// SendWords streams entries with the IngestStream RPC; the Python service
+// stores the whole stream in one transaction.
+func (c *GRPCPythonClient) SendWords(ctx context.Context, entries []models.WordEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	err := c.call(ctx, func(ctx context.Context) error {
+		stream, err := c.ingest.IngestStream(c.outgoing(ctx))
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if err := stream.Send(&ingestpb.IngestRequest{Entry: entryToProto(entry)}); err != nil {
+				// The real error is reported by CloseAndRecv
+				break
+			}
+		}
+		summary, err := stream.CloseAndRecv()
+		if err != nil {
+			return err
+		}
+		if summary.Rejected > 0 {
+			slog.WarnContext(ctx, "Python service rejected entries", "rejected", summary.Rejected, "entries", len(entries))
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error sending %d entries to Python: %w", len(entries), err)
+	}
+	return nil
+}
+
+// outgoing attaches the service key and request ID as call metadata.
+func (c *GRPCPythonClient) outgoing(ctx context.Context) context.Context {
+	md := metadata.Pairs("x-service-key", c.serviceKey)
+	if id := requestid.FromContext(ctx); id != "" {
+		md.Append("x-request-id", id)
+	}
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+func entryToProto(entry models.WordEntry) *wordentrypb.WordEntry {
+	pb := &wordentrypb.WordEntry{
+		Word:               entry.Word,
+		Query:              entry.Query,
+		Language:           entry.Language,
+		LanguageDetected:   entry.LanguageDetected,
+		InflectionsPartial: entry.InflectionsPartial,
+		IdempotencyKey:     entry.IdempotencyKey,
+		Pronunciations:     pronunciationsToProto(entry.Pronunciations),
+		Source:             entry.Source,
+		SourceUrl:          entry.SourceURL,
+		License:            entry.License,
+	}
+	if entry.Frequency != nil {
+		pb.Frequency = &wordentrypb.Frequency{Rank: int32(entry.Frequency.Rank), Band: int32(entry.Frequency.Band)}
+	}
+	if entry.CEFR != nil {
+		pb.Cefr = &wordentrypb.CefrLevel{Level: entry.CEFR.Level, Basis: entry.CEFR.Basis}
+	}
+	for _, a := range entry.Audio {
+		pb.Audio = append(pb.Audio, &wordentrypb.AudioClip{Url: a.URL, Source: a.Source, Variant: a.Variant, Speaker: a.Speaker})
+	}
+	for _, w := range entry.Warnings {
+		pb.Warnings = append(pb.Warnings, &wordentrypb.ScrapeWarning{Code: w.Code, SenseId: w.SenseID, Message: w.Message})
+	}
+	if !entry.ScrapedAt.IsZero() {
+		pb.ScrapedAt = entry.ScrapedAt.Format(time.RFC3339)
+	}
+	for _, sense := range entry.Senses {
+		ps := &wordentrypb.Sense{
+			Id:       sense.ID,
+			Category: sense.Category,
+			Gender:   sense.Gender,
+			Article:  sense.Article,
+
+			Pronunciations: pronunciationsToProto(sense.Pronunciations),
+			Etymology:      sense.Etymology,
+			Synonyms:       sense.Synonyms,
+			Antonyms:       sense.Antonyms,
+			PartOfSpeech:   string(sense.PartOfSpeech),
+		}
+		for _, r := range sense.References {
+			ps.References = append(ps.References, &wordentrypb.Reference{Word: r.Word, Relation: r.Relation, ArticleId: r.ArticleID})
+		}
+		for _, m := range sense.Meanings {
+			ps.Meanings = append(ps.Meanings, &wordentrypb.Meaning{Description: m.Description, Examples: m.Examples})
+		}
+		for _, e := range sense.Expressions {
+			ps.Expressions = append(ps.Expressions, &wordentrypb.Expression{Phrase: e.Phrase, Explanation: e.Explanation})
+		}
+		for _, f := range sense.WordForms {
+			ps.WordForms = append(ps.WordForms, &wordentrypb.WordForm{
+				Label:        f.Label,
+				Forms:        f.Forms,
+				Number:       f.Number,
+				Definiteness: f.Definiteness,
+				Gender:       f.Gender,
+				Degree:       f.Degree,
+				Tense:        f.Tense,
+				Person:       f.Person,
+				Mood:         f.Mood,
+				Voice:        f.Voice,
+				Case:         f.Case,
+			})
+		}
+		pb.Senses = append(pb.Senses, ps)
+	}
+	return pb
+}
+
+func pronunciationsToProto(prons []models.Pronunciation) []*wordentrypb.Pronunciation {
+	var pb []*wordentrypb.Pronunciation
+	for _, p := range prons {
+		pb = append(pb, &wordentrypb.Pronunciation{Text: p.Text, Notation: p.Notation, Audio: p.Audio, Variant: p.Variant})
+	}
+	return pb
+}
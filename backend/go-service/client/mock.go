@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+)
+
+// MockPythonClient is an in-memory PythonClient that records what it is sent.
+// Set Err to make every call fail.
+type MockPythonClient struct {
+	mu     sync.Mutex
+	Err    error
+	Words  []models.WordEntry
+	Audits []AuditEvent
+}
+
+// SendWord records entry, or returns m.Err.
+func (m *MockPythonClient) SendWord(ctx context.Context, entry models.WordEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Err != nil {
+		return m.Err
+	}
+	m.Words = append(m.Words, entry)
+	return nil
+}
+
+// SendWords records entries, or returns m.Err.
+func (m *MockPythonClient) SendWords(ctx context.Context, entries []models.WordEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Err != nil {
+		return m.Err
+	}
+	m.Words = append(m.Words, entries...)
+	return nil
+}
+
+// SendAudit records event, or returns m.Err.
+func (m *MockPythonClient) SendAudit(ctx context.Context, event AuditEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Err != nil {
+		return m.Err
+	}
+	m.Audits = append(m.Audits, event)
+	return nil
+}
+
+// Ping returns m.Err.
+func (m *MockPythonClient) Ping(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.Err
+}
+
+// AuditEvents returns the audit events recorded so far. RecordAudit sends
+// in the background, so read them through this rather than m.Audits.
+func (m *MockPythonClient) AuditEvents() []AuditEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]AuditEvent(nil), m.Audits...)
+}
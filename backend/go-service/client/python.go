@@ -2,23 +2,237 @@ package client
 
 import (
     "bytes"
+    "context"
     "encoding/json"
+    "errors"
     "fmt"
     "net/http"
+    "strings"
+    "time"
 
-    "vocabulary-app/backend/go-service/models"
+    "github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+
+    "vocabulary-app/backend/go-service/config"
+    "vocabulary-app/backend/go-service/requestid"
 )
 
-func SendToPython(entry models.WordEntry) error {
-    jsonData, _ := json.Marshal(entry)
-    resp, err := http.Post("http://python-service:8000/api/words", "application/json", bytes.NewBuffer(jsonData))
+// PythonClient is everything the Go service needs from the Python service.
+// Handlers depend on this interface so the HTTP client can be swapped for
+// MockPythonClient.
+type PythonClient interface {
+    // SendWord stores a scraped entry.
+    SendWord(ctx context.Context, entry models.WordEntry) error
+    // SendWords stores several entries in one request.
+    SendWords(ctx context.Context, entries []models.WordEntry) error
+    // SendAudit writes an event to the shared audit log.
+    SendAudit(ctx context.Context, event AuditEvent) error
+    // Ping checks that the service and its database are reachable.
+    Ping(ctx context.Context) error
+}
+
+// HTTPPythonClient talks to the Python service over HTTP. Each attempt is
+// bounded by the configured timeout, and requests that fail with a network
+// error or a 5xx response are retried with exponential backoff. A circuit
+// breaker stops requests altogether while the service keeps failing; calls
+// then return ErrDownstreamUnavailable.
+type HTTPPythonClient struct {
+    baseURL    string
+    httpClient *http.Client
+    timeout    time.Duration
+    retries    int
+    backoff    time.Duration
+    serviceKey string
+    breaker    *Breaker
+}
+
+// NewPythonClient creates a client for the configured Python service.
+// httpClient may be nil to use http.DefaultClient.
+func NewPythonClient(cfg config.PythonServiceConfig, httpClient *http.Client) *HTTPPythonClient {
+    if httpClient == nil {
+        httpClient = http.DefaultClient
+    }
+    return &HTTPPythonClient{
+        baseURL:    strings.TrimRight(cfg.URL, "/"),
+        httpClient: httpClient,
+        timeout:    cfg.Timeout,
+        retries:    cfg.Retries,
+        backoff:    cfg.RetryBackoff,
+        serviceKey: cfg.ServiceKey,
+        breaker:    NewBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown),
+    }
+}
+
+// BreakerStatus reports the state of the client's circuit breaker.
+func (c *HTTPPythonClient) BreakerStatus() BreakerStatus {
+    return c.breaker.Status()
+}
+
+// SendWord forwards a scraped entry to the Python service. The request ID
+// in ctx, if any, is passed along so logs on both sides can be correlated.
+func (c *HTTPPythonClient) SendWord(ctx context.Context, entry models.WordEntry) error {
+    jsonData, err := json.Marshal(entry)
     if err != nil {
-        return fmt.Errorf("error sending data to Python: %v", err)
+        return fmt.Errorf("error encoding entry: %v", err)
+    }
+    if err := c.post(ctx, "/api/words", jsonData, c.serviceHeader()); err != nil {
+        return fmt.Errorf("error sending data to Python: %w", err)
+    }
+    return nil
+}
+
+// bulkRequest is the body of POST /api/words/bulk.
+type bulkRequest struct {
+    Entries []models.WordEntry `json:"entries"`
+}
+
+// SendWords forwards entries to the Python service's bulk endpoint in one
+// request. The service stores what it can and reports per-entry outcomes;
+// entries it rejects are not retried, since resending them won't help.
+func (c *HTTPPythonClient) SendWords(ctx context.Context, entries []models.WordEntry) error {
+    if len(entries) == 0 {
+        return nil
+    }
+    jsonData, err := json.Marshal(bulkRequest{Entries: entries})
+    if err != nil {
+        return fmt.Errorf("error encoding entries: %v", err)
+    }
+    if err := c.post(ctx, "/api/words/bulk", jsonData, c.serviceHeader()); err != nil {
+        return fmt.Errorf("error sending %d entries to Python: %w", len(entries), err)
+    }
+    return nil
+}
+
+// SendAudit writes an audit event. It is a no-op when no service key is
+// configured, since the Python service would reject the call anyway.
+func (c *HTTPPythonClient) SendAudit(ctx context.Context, event AuditEvent) error {
+    if c.serviceKey == "" {
+        return nil
+    }
+    body, err := json.Marshal(event)
+    if err != nil {
+        return err
+    }
+    return c.post(ctx, "/audit/events", body, c.serviceHeader())
+}
+
+// Ping calls the Python service's /healthz once, bypassing retries and the
+// circuit breaker so probes report the service's current state.
+func (c *HTTPPythonClient) Ping(ctx context.Context) error {
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/healthz", nil)
+    if err != nil {
+        return err
+    }
+    resp, err := c.httpClient.Do(req)
+    if err != nil {
+        return err
     }
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusOK {
-        return fmt.Errorf("Python service returned %s", resp.Status)
+        return &StatusError{StatusCode: resp.StatusCode, Status: resp.Status}
     }
     return nil
 }
+
+func (c *HTTPPythonClient) serviceHeader() http.Header {
+    return http.Header{"X-Service-Key": {c.serviceKey}}
+}
+
+// StatusError is returned when the Python service answers with a non-200 status.
+type StatusError struct {
+    StatusCode int
+    Status     string
+}
+
+func (e *StatusError) Error() string {
+    return "Python service returned " + e.Status
+}
+
+// post sends body to path through call.
+func (c *HTTPPythonClient) post(ctx context.Context, path string, body []byte, header http.Header) error {
+    return c.call(ctx, func(ctx context.Context) error {
+        return c.postOnce(ctx, path, body, header)
+    })
+}
+
+// call runs attempt under the circuit breaker, bounding each try by the
+// configured timeout and retrying retryable failures with exponential
+// backoff. The whole retry sequence counts as one call for the breaker.
+func (c *HTTPPythonClient) call(ctx context.Context, attempt func(ctx context.Context) error) error {
+    if !c.breaker.Allow() {
+        return ErrDownstreamUnavailable
+    }
+    err := c.retry(ctx, attempt)
+    c.breaker.Record(err == nil || !retryable(err))
+    return err
+}
+
+func (c *HTTPPythonClient) retry(ctx context.Context, attempt func(ctx context.Context) error) error {
+    backoff := c.backoff
+    for n := 0; ; n++ {
+        err := c.attemptOnce(ctx, attempt)
+        if err == nil || !retryable(err) || n >= c.retries {
+            return err
+        }
+
+        select {
+        case <-ctx.Done():
+            return err
+        case <-time.After(backoff):
+        }
+        backoff *= 2
+    }
+}
+
+func (c *HTTPPythonClient) attemptOnce(ctx context.Context, attempt func(ctx context.Context) error) error {
+    if c.timeout > 0 {
+        var cancel context.CancelFunc
+        ctx, cancel = context.WithTimeout(ctx, c.timeout)
+        defer cancel()
+    }
+    return attempt(ctx)
+}
+
+func (c *HTTPPythonClient) postOnce(ctx context.Context, path string, body []byte, header http.Header) error {
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(body))
+    if err != nil {
+        return err
+    }
+    for key, values := range header {
+        req.Header[key] = values
+    }
+    req.Header.Set("Content-Type", "application/json")
+    if id := requestid.FromContext(ctx); id != "" {
+        req.Header.Set(requestid.Header, id)
+    }
+
+    resp, err := c.httpClient.Do(req)
+    if err != nil {
+        return err
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        return &StatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+    }
+    return nil
+}
+
+// retryable reports whether a failed attempt is worth repeating: server errors
+// and transport failures are, client errors (4xx, invalid gRPC requests) are not.
+func retryable(err error) bool {
+    var statusErr *StatusError
+    if errors.As(err, &statusErr) {
+        return statusErr.StatusCode >= 500
+    }
+    if s, ok := status.FromError(err); ok && s.Code() != codes.Unknown {
+        switch s.Code() {
+        case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted, codes.Internal:
+            return true
+        }
+        return false
+    }
+    return !errors.Is(err, context.Canceled)
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+
+	"vocabulary-app/backend/go-service/config"
+)
+
+// testClient returns a client for srv that retries quickly.
+func testClient(srv *httptest.Server, retries int, timeout time.Duration) *HTTPPythonClient {
+	return NewPythonClient(config.PythonServiceConfig{
+		URL:          srv.URL,
+		Timeout:      timeout,
+		Retries:      retries,
+		RetryBackoff: time.Millisecond,
+	}, srv.Client())
+}
+
+// countingServer answers every request with status and counts them.
+func countingServer(t *testing.T, status int) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func TestSendWordRetriesServerErrors(t *testing.T) {
+	srv, calls := countingServer(t, http.StatusServiceUnavailable)
+	c := testClient(srv, 2, time.Second)
+
+	err := c.SendWord(context.Background(), models.WordEntry{Word: "hund"})
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("SendWord error = %v, want a 503 StatusError", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("server saw %d requests, want 3 (one attempt and two retries)", got)
+	}
+}
+
+func TestSendWordDoesNotRetryClientErrors(t *testing.T) {
+	srv, calls := countingServer(t, http.StatusUnprocessableEntity)
+	c := testClient(srv, 2, time.Second)
+
+	err := c.SendWord(context.Background(), models.WordEntry{Word: "hund"})
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("SendWord error = %v, want a 422 StatusError", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server saw %d requests, want 1", got)
+	}
+}
+
+func TestSendWordTimesOutEachAttempt(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Only the first attempt hangs. The body is read so the server
+		// notices when the client gives up and cancels r.Context()
+		io.Copy(io.Discard, r.Body)
+		if calls.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := testClient(srv, 1, 50*time.Millisecond)
+	start := time.Now()
+	if err := c.SendWord(context.Background(), models.WordEntry{Word: "hund"}); err != nil {
+		t.Fatalf("SendWord: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("SendWord took %s; the hanging attempt wasn't cut off", elapsed)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("server saw %d requests, want 2", got)
+	}
+
+	calls.Store(0)
+	c = testClient(srv, 0, 50*time.Millisecond)
+	if err := c.SendWord(context.Background(), models.WordEntry{Word: "hund"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("SendWord without retries error = %v, want a deadline exceeded", err)
+	}
+}
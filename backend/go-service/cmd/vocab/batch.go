@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/langcode"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"github.com/spf13/cobra"
+
+	"vocabulary-app/backend/go-service/routes"
+	"vocabulary-app/backend/go-service/wordlist"
+)
+
+func batchCommand(router func() (*routes.LanguageRouter, error)) *cobra.Command {
+	var file, language string
+	var workers int
+	var strict bool
+	cmd := &cobra.Command{
+		Use:   "batch [word...]",
+		Short: "Scrape a list of words and print their entries as NDJSON",
+		Long: "Scrape a list of words and print one entry per line (NDJSON), in list order.\n" +
+			"Words come from the arguments and --file, a word list like the import\n" +
+			"endpoint takes: one word per line with # comments, or a .csv file with a\n" +
+			"\"word\" column. Use --file - for standard input. Words that fail, or have no\n" +
+			"senses, are logged to standard error and make the command exit with an error\n" +
+			"after the rest are done. With --strict, so do words whose entries have warnings.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			words := args
+			if file != "" {
+				listed, err := readList(file)
+				if err != nil {
+					return err
+				}
+				words = append(words, listed...)
+			}
+			if len(words) == 0 {
+				return fmt.Errorf("no words given: pass them as arguments or with --file")
+			}
+			if language != "" {
+				canonical, ok := langcode.Normalize(language)
+				if !ok {
+					return fmt.Errorf("unsupported language: %s", language)
+				}
+				language = canonical
+			}
+			if workers < 1 {
+				return fmt.Errorf("--workers must be at least 1")
+			}
+			lr, err := router()
+			if err != nil {
+				return err
+			}
+
+			// Scrape concurrently (the sources' rate limits still apply) but
+			// print in list order
+			type result struct {
+				entry models.WordEntry
+				err   error
+				done  chan struct{}
+			}
+			results := make([]result, len(words))
+			for i := range results {
+				results[i].done = make(chan struct{})
+			}
+			jobs := make(chan int)
+			var wg sync.WaitGroup
+			for range min(workers, len(words)) {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := range jobs {
+						results[i].entry, results[i].err = scrape(cmd.Context(), lr, words[i], language, strict)
+						close(results[i].done)
+					}
+				}()
+			}
+			go func() {
+				for i := range words {
+					jobs <- i
+				}
+				close(jobs)
+			}()
+
+			encoder := json.NewEncoder(os.Stdout)
+			failed := 0
+			for i, word := range words {
+				<-results[i].done
+				entry, err := results[i].entry, results[i].err
+				// Printed entries aren't needed again; only those scraped
+				// ahead of the next word to print stay in memory
+				results[i].entry = models.WordEntry{}
+				if err == nil && len(entry.Senses) == 0 {
+					err = fmt.Errorf("no senses found")
+				}
+				if err != nil {
+					slog.Error("scrape failed", "word", word, "error", err)
+					failed++
+					continue
+				}
+				if err := encoder.Encode(entry); err != nil {
+					return err
+				}
+			}
+			wg.Wait()
+			if failed > 0 {
+				return fmt.Errorf("%d of %d words failed", failed, len(words))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&file, "file", "f", "", "word list to scrape, or - for standard input")
+	cmd.Flags().StringVarP(&language, "lang", "l", "", "language code, e.g. no-bm, no-nn, en, es or de (default: detect per word)")
+	cmd.Flags().IntVarP(&workers, "workers", "w", 2, "words scraped at once")
+	cmd.Flags().BoolVar(&strict, "strict", false, "fail words whose entries have warnings instead of printing them")
+	return cmd
+}
+
+// readList reads a word list file, or standard input for "-".
+func readList(file string) ([]string, error) {
+	var list io.Reader = os.Stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		list = f
+	}
+	words, err := wordlist.Read(list, strings.EqualFold(filepath.Ext(file), ".csv"))
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", file, err)
+	}
+	return words, nil
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+// exportColumns are the columns export can write, like the Python
+// service's /review/export and /api/decks/{id}/export.
+var exportColumns = map[string]func(models.WordEntry) string{
+	"word":     func(e models.WordEntry) string { return e.Word },
+	"language": func(e models.WordEntry) string { return e.Language },
+	"part_of_speech": func(e models.WordEntry) string {
+		return joinSenses(e, func(s models.SenseEntry) []string { return []string{string(s.PartOfSpeech)} }, ", ")
+	},
+	"gender": func(e models.WordEntry) string {
+		return joinSenses(e, func(s models.SenseEntry) []string { return []string{s.Gender} }, ", ")
+	},
+	"pronunciation": func(e models.WordEntry) string {
+		var texts []string
+		for _, p := range e.Pronunciations {
+			texts = append(texts, p.Text)
+		}
+		for _, s := range e.Senses {
+			for _, p := range s.Pronunciations {
+				texts = append(texts, p.Text)
+			}
+		}
+		return strings.Join(dedupe(texts), ", ")
+	},
+	"meanings": func(e models.WordEntry) string {
+		return joinSenses(e, func(s models.SenseEntry) []string {
+			var descriptions []string
+			for _, m := range s.Meanings {
+				descriptions = append(descriptions, m.Description)
+			}
+			return descriptions
+		}, "; ")
+	},
+	"examples": func(e models.WordEntry) string {
+		return joinSenses(e, func(s models.SenseEntry) []string {
+			var examples []string
+			for _, m := range s.Meanings {
+				examples = append(examples, m.Examples...)
+			}
+			return examples
+		}, "; ")
+	},
+	"frequency_rank": func(e models.WordEntry) string {
+		if e.Frequency == nil {
+			return ""
+		}
+		return strconv.Itoa(e.Frequency.Rank)
+	},
+	"cefr_level": func(e models.WordEntry) string {
+		if e.CEFR == nil {
+			return ""
+		}
+		return e.CEFR.Level
+	},
+	"source": func(e models.WordEntry) string { return e.Source },
+}
+
+func exportCommand() *cobra.Command {
+	var file, format, columns string
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Convert scraped entries to CSV, TSV or a Quizlet import",
+		Long: "Convert entries, as NDJSON from scrape --compact or batch, to CSV or TSV with a\n" +
+			"header row, or to the layout Quizlet imports: the first column as the term,\n" +
+			"the others joined with \" – \" as the definition, a tab between them and one\n" +
+			"card per line.\n\n" +
+			"Columns: word, language, part_of_speech, gender, pronunciation, meanings,\n" +
+			"examples, frequency_rank, cefr_level, source.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "csv" && format != "tsv" && format != "quizlet" {
+				return fmt.Errorf("unknown format %q: want csv, tsv or quizlet", format)
+			}
+			var names []string
+			for _, name := range strings.Split(columns, ",") {
+				if name = strings.TrimSpace(strings.ToLower(name)); name == "" {
+					continue
+				}
+				if _, ok := exportColumns[name]; !ok {
+					return fmt.Errorf("unknown column %q", name)
+				}
+				names = append(names, name)
+			}
+			if len(names) == 0 || format == "quizlet" && len(names) < 2 {
+				return fmt.Errorf("--columns needs a term column and at least one more")
+			}
+
+			var in io.Reader = os.Stdin
+			if file != "" && file != "-" {
+				f, err := os.Open(file)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				in = f
+			}
+			out := bufio.NewWriter(os.Stdout)
+			defer out.Flush()
+			return exportEntries(in, out, format, names)
+		},
+	}
+	cmd.Flags().StringVarP(&file, "file", "f", "", "NDJSON entries to convert (default: standard input)")
+	cmd.Flags().StringVar(&format, "format", "csv", "csv, tsv or quizlet")
+	cmd.Flags().StringVar(&columns, "columns", "word,part_of_speech,meanings", "comma-separated columns, in order")
+	return cmd
+}
+
+// exportEntries converts the NDJSON entries read from in.
+func exportEntries(in io.Reader, out io.Writer, format string, names []string) error {
+	var writer *csv.Writer
+	if format != "quizlet" {
+		writer = csv.NewWriter(out)
+		if format == "tsv" {
+			writer.Comma = '\t'
+		}
+		defer writer.Flush()
+		if err := writer.Write(names); err != nil {
+			return err
+		}
+	}
+
+	decoder := json.NewDecoder(in)
+	for line := 1; ; line++ {
+		var entry models.WordEntry
+		if err := decoder.Decode(&entry); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("reading entry %d: %w", line, err)
+		}
+		values := make([]string, len(names))
+		for i, name := range names {
+			values[i] = exportColumns[name](entry)
+		}
+		if writer != nil {
+			if err := writer.Write(values); err != nil {
+				return err
+			}
+			continue
+		}
+		// Tabs and line breaks would split the card
+		for i := range values {
+			values[i] = strings.Join(strings.Fields(values[i]), " ")
+		}
+		definition := slices.DeleteFunc(values[1:], func(v string) bool { return v == "" })
+		if _, err := fmt.Fprintf(out, "%s\t%s\n", values[0], strings.Join(definition, " – ")); err != nil {
+			return err
+		}
+	}
+}
+
+// joinSenses joins the distinct non-empty values field takes from each of
+// an entry's senses.
+func joinSenses(e models.WordEntry, field func(models.SenseEntry) []string, sep string) string {
+	var values []string
+	for _, s := range e.Senses {
+		values = append(values, field(s)...)
+	}
+	return strings.Join(dedupe(values), sep)
+}
+
+// dedupe drops empty and repeated values, keeping the order.
+func dedupe(values []string) []string {
+	var kept []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" && !slices.Contains(kept, v) {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,98 @@
+// Command vocab scrapes dictionary entries from the command line, with the
+// same scrapers and configuration as the HTTP service but without running
+// it:
+//
+//	vocab scrape hunder --lang no-bm
+//	vocab batch --file words.txt --lang no-bm > entries.ndjson
+//	vocab export --format quizlet < entries.ndjson > cards.txt
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/dictionary"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/scrapers/wordforms"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/sources"
+	"github.com/spf13/cobra"
+
+	"vocabulary-app/backend/go-service/config"
+	"vocabulary-app/backend/go-service/frequency"
+	"vocabulary-app/backend/go-service/logging"
+	"vocabulary-app/backend/go-service/routes"
+)
+
+func main() {
+	var configPath, logLevel string
+	root := &cobra.Command{
+		Use:           "vocab",
+		Short:         "Scrape dictionary entries without running the HTTP service",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	root.PersistentFlags().StringVar(&configPath, "config", os.Getenv("CONFIG_FILE"), "JSON config file, as for the service; keys are the environment variable names")
+	root.PersistentFlags().StringVar(&logLevel, "log-level", "", "minimum log level: debug, info, warn or error (default from the config)")
+
+	// Only commands that scrape need the scrapers set up
+	router := func() (*routes.LanguageRouter, error) {
+		return newRouter(configPath, logLevel)
+	}
+	root.AddCommand(scrapeCommand(router), batchCommand(router), exportCommand())
+
+	if err := root.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "vocab:", err)
+		os.Exit(1)
+	}
+}
+
+// newRouter loads the configuration like the service does and sets up the
+// scrapers with it: settings, proxies, switched-off sources and languages,
+// language detection and frequency lists. Offline dictionaries and audio
+// are left out, as the service holds the database they live in.
+func newRouter(configPath, logLevel string) (*routes.LanguageRouter, error) {
+	args := []string{}
+	if configPath != "" {
+		args = append(args, "-config", configPath)
+	}
+	if logLevel != "" {
+		args = append(args, "-log-level", logLevel)
+	}
+	cfg, err := config.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+	if err := logging.Setup(cfg.Log, os.Stderr); err != nil {
+		return nil, fmt.Errorf("invalid logging configuration: %w", err)
+	}
+
+	dictionary.Configure(cfg.Scraper.Settings())
+	sources.Default.SetBrowserLimit(cfg.Scraper.MaxBrowsers)
+	sources.Default.SetUserAgent(cfg.Scraper.UserAgent)
+	if err := sources.Default.SetProxies(cfg.Scraper.Proxies, cfg.Scraper.ProxyRotate, cfg.Scraper.ProxyCooldown); err != nil {
+		return nil, fmt.Errorf("invalid SCRAPER_PROXIES: %w", err)
+	}
+	for _, name := range cfg.Scraper.DisabledSources {
+		if err := sources.Default.SetSourceEnabled(name, false); err != nil {
+			return nil, fmt.Errorf("invalid SCRAPER_DISABLED_SOURCES: %w", err)
+		}
+	}
+	for _, language := range cfg.Scraper.DisabledLanguages {
+		if err := sources.Default.SetLanguageEnabled(language, false); err != nil {
+			return nil, fmt.Errorf("invalid SCRAPER_DISABLED_LANGUAGES: %w", err)
+		}
+	}
+	if err := wordforms.Configure(cfg.Scraper.LabelMappings); err != nil {
+		return nil, fmt.Errorf("invalid SCRAPER_LABEL_MAPPINGS: %w", err)
+	}
+
+	router := routes.NewLanguageRouter()
+	router.SetDetectLanguages(cfg.Scraper.DetectLanguages)
+	router.SetLimits(cfg.Scraper.Limits())
+	lists, err := frequency.Load(cfg.Frequency.Dir, cfg.Frequency.CEFRDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading frequency and CEFR lists: %w", err)
+	}
+	router.SetFrequencyLookup(lists.Lookup)
+	router.SetLevelLookup(lists.Level)
+	return router, nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"github.com/spf13/cobra"
+
+	"vocabulary-app/backend/go-service/format"
+	"vocabulary-app/backend/go-service/routes"
+)
+
+func scrapeCommand(router func() (*routes.LanguageRouter, error)) *cobra.Command {
+	var language, output string
+	var compact, strict bool
+	cmd := &cobra.Command{
+		Use:   "scrape <word>",
+		Short: "Scrape one word and print its entry",
+		Long: "Scrape one word and print its entry as JSON, YAML or a table of its senses\n" +
+			"and forms (--output). Without --lang the language is detected, as when the\n" +
+			"service is called without one. A word with no senses exits with an error, as\n" +
+			"does, with --strict, an entry missing parts whose scrape failed.",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := format.Parse(output)
+			if err != nil {
+				return err
+			}
+			lr, err := router()
+			if err != nil {
+				return err
+			}
+			// Phrases may be given unquoted
+			word := strings.Join(args, " ")
+			entry, err := scrape(cmd.Context(), lr, word, language, strict)
+			if err != nil {
+				return err
+			}
+			if f == format.JSON && compact {
+				err = json.NewEncoder(os.Stdout).Encode(entry)
+			} else {
+				err = format.Write(os.Stdout, f, entry)
+			}
+			if err != nil {
+				return err
+			}
+			if len(entry.Senses) == 0 {
+				return fmt.Errorf("no senses found for %q", word)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&language, "lang", "l", "", "language code, e.g. no-bm, no-nn, en, es or de (default: detect)")
+	cmd.Flags().StringVarP(&output, "output", "o", "json", "output format: json, yaml or table")
+	cmd.Flags().BoolVar(&compact, "compact", false, "print JSON output on one line")
+	cmd.Flags().BoolVar(&strict, "strict", false, "fail instead of printing an entry with warnings")
+	return cmd
+}
+
+// scrape scrapes a word in a language, or detecting it when language is
+// empty. In strict mode an entry with warnings is returned as an error.
+func scrape(ctx context.Context, lr *routes.LanguageRouter, word, language string, strict bool) (models.WordEntry, error) {
+	var entry models.WordEntry
+	var err error
+	if language == "" {
+		entry, err = lr.ScrapeWordDetectingLanguage(ctx, word, nil)
+	} else {
+		entry, err = lr.ScrapeWordByLanguage(ctx, word, language)
+	}
+	if strict && err == nil {
+		err = entry.CheckComplete()
+	}
+	return entry, err
+}
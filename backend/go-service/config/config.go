@@ -0,0 +1,761 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/langcode"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/scrapers"
+)
+
+// Config holds the service settings. Each setting is named by an
+// environment variable; its value comes from, in increasing precedence, the
+// built-in default (suitable for local development), the JSON config file
+// named by CONFIG_FILE or -config, the environment, and command-line flags.
+type Config struct {
+	Server        ServerConfig
+	Jobs          JobsConfig
+	Scraper       ScraperConfig
+	Webhooks      WebhooksConfig
+	RateLimit     RateLimitConfig
+	PythonService PythonServiceConfig
+	Delivery      DeliveryConfig
+	Queue         QueueConfig
+	Events        EventsConfig
+	Log           LogConfig
+	Archive       ArchiveConfig
+	Canary        CanaryConfig
+	Audio         AudioConfig
+	Frequency     FrequencyConfig
+	Suggest       SuggestConfig
+	EntryCache    EntryCacheConfig
+	Dictionaries  DictionariesConfig
+	Analyze       AnalyzeConfig
+	OneShot       OneShotConfig
+	// DataPath is the bolt database for local state (outbox, webhooks, feature
+	// flags, jobs, dead letters).
+	DataPath string
+}
+
+// ServerConfig controls the HTTP server.
+type ServerConfig struct {
+	// Addr is the host:port the server listens on; an empty host binds
+	// all interfaces.
+	Addr string
+	// TLSCertFile and TLSKeyFile enable HTTPS when both are set.
+	TLSCertFile string
+	TLSKeyFile  string
+	// ReadHeaderTimeout bounds reading a request's headers.
+	ReadHeaderTimeout time.Duration
+	// ReadTimeout bounds reading a whole request, including the body.
+	ReadTimeout time.Duration
+	// WriteTimeout bounds handling a request and writing its response; 0
+	// disables it. The ?wait= long-poll and streamed jobs extend it as
+	// they go.
+	WriteTimeout time.Duration
+	// IdleTimeout is how long keep-alive connections are kept open.
+	IdleTimeout time.Duration
+	// KeepAlives reuses connections between requests; turning it off
+	// closes each connection after its response.
+	KeepAlives bool
+	// TCPKeepAlive is the interval of TCP keep-alive probes, which notice
+	// clients that vanished without closing; negative disables them.
+	TCPKeepAlive time.Duration
+	// MaxHeaderBytes caps the size of a request's headers.
+	MaxHeaderBytes int
+	// MaxJobWait caps the ?wait= long-poll on job creation.
+	MaxJobWait time.Duration
+}
+
+// JobsConfig controls how batch and import jobs are run.
+type JobsConfig struct {
+	// Concurrency is how many words of a job are scraped at once. Jobs of
+	// different languages run side by side, one job per language at a time.
+	Concurrency int
+	// LanguageConcurrency overrides Concurrency for some languages, as
+	// "language:n", e.g. "no-bm:4".
+	LanguageConcurrency []string
+	// MaxWords caps the words of one job; 0 means no cap.
+	MaxWords int
+	// Retention is how long finished jobs can still be polled.
+	Retention time.Duration
+}
+
+// TLS reports whether the server should serve HTTPS.
+func (c ServerConfig) TLS() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
+
+// ScraperConfig controls how dictionaries are scraped.
+type ScraperConfig struct {
+	// OrdbokeneURL is the base URL of the Norwegian dictionary site.
+	OrdbokeneURL string
+	// ArticleAPIURL is the base URL of the ordbokene article JSON API.
+	ArticleAPIURL string
+	// RequestTimeout bounds each page and article API request.
+	RequestTimeout time.Duration
+	// BrowserEnabled turns on Chrome for inflection tables; when off, the
+	// static fallback is used directly.
+	BrowserEnabled bool
+	// BrowserTimeout bounds the Chrome inflection scrape of one sense; a
+	// word's senses share a session bounded by their sum.
+	BrowserTimeout time.Duration
+	// BrowserURL attaches to a running Chrome at this DevTools URL (e.g.
+	// ws://chrome:9222) instead of starting one in the service, so the
+	// service image needs no Chrome and Chrome can be scaled on its own.
+	BrowserURL string
+	// MaxBrowsers caps concurrent Chrome sessions: local Chrome instances,
+	// or tabs in the Chrome at BrowserURL.
+	MaxBrowsers int
+	// SenseDelay is a pause between the requests for each sense of a word.
+	SenseDelay time.Duration
+	// ResolveLemmas looks inflected input ("hunder") up as its lemma
+	// ("hund") before scraping, at the cost of one suggest API request.
+	ResolveLemmas bool
+	// DetectLanguages are tried in order for words scraped without a
+	// language, after languages the word's letters point to.
+	DetectLanguages []string
+	// UserAgent identifies the scrapers to dictionary sites, with a contact
+	// URL. Sources can override it with SOURCE_<NAME>_HEADERS.
+	UserAgent string
+	// Proxies are outbound http, https or socks5 proxy URLs for scraping;
+	// empty means connect directly. ProxyRotate spreads requests across
+	// them instead of using the first healthy one, and a proxy that keeps
+	// failing is skipped for ProxyCooldown.
+	Proxies       []string
+	ProxyRotate   bool
+	ProxyCooldown time.Duration
+	// DisabledSources and DisabledLanguages switch scrapers off by source
+	// name or canonical language code. Admins can change this at runtime.
+	DisabledSources   []string
+	DisabledLanguages []string
+	// MaxSenses, MaxExamplesPerMeaning and MaxForms (inflection rows per
+	// sense) cap the size of entries; 0 means no limit. Requests can
+	// lower them with ?max_senses= and so on.
+	MaxSenses             int
+	MaxExamplesPerMeaning int
+	MaxForms              int
+	// LabelMappings add words to the vocabularies inflection row labels
+	// are read with, as "language:word=field=value", e.g.
+	// "no-bm:infinitiv=mood=infinitive".
+	LabelMappings []string
+}
+
+// Settings returns the parts of c the ordbokene.no scrapers use.
+func (c ScraperConfig) Settings() scrapers.Settings {
+	return scrapers.Settings{
+		OrdbokeneURL:   c.OrdbokeneURL,
+		ArticleAPIURL:  c.ArticleAPIURL,
+		RequestTimeout: c.RequestTimeout,
+		BrowserEnabled: c.BrowserEnabled,
+		BrowserTimeout: c.BrowserTimeout,
+		BrowserURL:     c.BrowserURL,
+		SenseDelay:     c.SenseDelay,
+		ResolveLemmas:  c.ResolveLemmas,
+		MaxSenses:      c.MaxSenses,
+	}
+}
+
+// Limits returns the entry size limits.
+func (c ScraperConfig) Limits() models.Limits {
+	return models.Limits{
+		MaxSenses:             c.MaxSenses,
+		MaxExamplesPerMeaning: c.MaxExamplesPerMeaning,
+		MaxForms:              c.MaxForms,
+	}
+}
+
+// WebhooksConfig controls notifying registered webhooks about finished jobs.
+type WebhooksConfig struct {
+	Enabled bool
+	// Timeout bounds each delivery attempt.
+	Timeout time.Duration
+	// Attempts is how many times a notification is tried before giving up.
+	Attempts int
+	// AllowPrivateNetworks lets webhooks point at loopback and private
+	// addresses, e.g. for local development.
+	AllowPrivateNetworks bool
+}
+
+// CanaryConfig controls the periodic scrapes that detect selector drift.
+type CanaryConfig struct {
+	// Enabled turns on the canary checks.
+	Enabled bool
+	// Interval is the time between rounds of checks.
+	Interval time.Duration
+	// Words lists the canaries as "language:word", e.g. "no-bm:hund".
+	Words []string
+}
+
+// AudioConfig controls the pronunciation audio proxy and Forvo lookups.
+type AudioConfig struct {
+	// ProxyEnabled serves recordings through /api/audio.
+	ProxyEnabled bool
+	// CacheDir is where proxied recordings are kept.
+	CacheDir string
+	// CacheMaxBytes caps the size of CacheDir; the least recently used
+	// recordings are removed beyond it.
+	CacheMaxBytes int64
+	// AllowedHosts lists the hosts (and their subdomains) the proxy may fetch from.
+	AllowedHosts []string
+	// MaxBytes caps the size of a proxied recording.
+	MaxBytes int64
+	// Timeout bounds each upstream request.
+	Timeout time.Duration
+	// ForvoAPIKey enables Forvo lookups for every scraped word; empty disables them.
+	ForvoAPIKey string
+}
+
+// FrequencyConfig controls annotating entries with frequency ranks and
+// CEFR levels.
+type FrequencyConfig struct {
+	// Dir holds one frequency list per language, named <language>.txt; a
+	// missing or empty directory leaves entries unranked.
+	Dir string
+	// CEFRDir holds curated CEFR word lists named the same way. Words not
+	// on them get a level estimated from their frequency rank.
+	CEFRDir string
+}
+
+// SuggestConfig controls the autocomplete endpoint.
+type SuggestConfig struct {
+	// Limit is the most suggestions returned per query.
+	Limit int
+	// CacheTTL is how long suggestions for a prefix are reused.
+	CacheTTL time.Duration
+	// CacheSize caps the number of cached prefixes.
+	CacheSize int
+}
+
+// EntryCacheConfig controls the in-memory cache of scraped entries.
+type EntryCacheConfig struct {
+	// Size caps the number of cached entries; 0 turns the cache off.
+	Size int
+	// TTL is how long an entry is served from the cache before it is
+	// scraped again.
+	TTL time.Duration
+	// Warm pre-scrapes the most frequent words of each enabled language
+	// with a frequency list while no one is looking words up.
+	Warm bool
+	// WarmWords is how many words per language are kept warm.
+	WarmWords int
+	// WarmInterval is the time between rounds of warming; shorter than TTL
+	// so warmed words don't expire between rounds.
+	WarmInterval time.Duration
+	// WarmDelay is the least time between two warming scrapes.
+	WarmDelay time.Duration
+	// WarmIdle is how long no word must have been looked up before warming
+	// scrapes run.
+	WarmIdle time.Duration
+}
+
+// DictionariesConfig controls offline dictionaries (StarDict, FreeDict)
+// imported by admins.
+type DictionariesConfig struct {
+	// Prefer lists languages looked up offline before scraping, for sources
+	// that are unreliable or stubs. Other languages only fall back to
+	// offline dictionaries when a scrape fails or finds nothing.
+	Prefer []string
+	// MaxUploadBytes caps an uploaded dictionary file.
+	MaxUploadBytes int64
+}
+
+// AnalyzeConfig bounds text analysis, which looks up the lemma of every
+// distinct word in a text.
+type AnalyzeConfig struct {
+	// MaxWords caps how many distinct words of one text are lemmatized.
+	MaxWords int
+	// Timeout caps how long one analysis spends lemmatizing.
+	Timeout time.Duration
+	// MaxUploadBytes caps an uploaded e-book or subtitle file.
+	MaxUploadBytes int64
+}
+
+// OneShotConfig runs the binary as a one-shot scraper instead of a server,
+// for cron jobs and debugging. It is set only by command-line flags.
+type OneShotConfig struct {
+	// Word, when set, is scraped and printed as JSON, and the binary exits.
+	Word string
+	// Language is the word's language code; empty detects it.
+	Language string
+}
+
+// ArchiveConfig controls keeping raw HTML snapshots of scraped pages.
+type ArchiveConfig struct {
+	// Backend is "filesystem", "http" (an object store accepting PUT), or
+	// empty to disable archiving.
+	Backend string
+	// Dir is where the filesystem backend writes snapshots.
+	Dir string
+	// URL is the base URL snapshots are PUT below, e.g. a bucket URL.
+	URL string
+	// Token is sent as a bearer token to the object store, if set.
+	Token string
+	// Timeout bounds each upload to the object store.
+	Timeout time.Duration
+}
+
+// LogConfig controls the service's structured logs.
+type LogConfig struct {
+	// Level is the minimum level logged: debug, info, warn or error.
+	Level string
+	// Format is "text" for local development or "json" for log collectors.
+	Format string
+}
+
+// EventsConfig controls the Kafka stream of dictionary events.
+type EventsConfig struct {
+	// KafkaBrokers lists the bootstrap brokers; empty disables events.
+	KafkaBrokers []string
+	// TopicPrefix is prepended to each event type to form its topic.
+	TopicPrefix string
+	// BatchTimeout is the longest an event waits for its batch to fill.
+	BatchTimeout time.Duration
+}
+
+// QueueConfig controls publishing scraped entries to NATS JetStream.
+type QueueConfig struct {
+	// NATSURL is the NATS server to publish to; empty disables publishing.
+	NATSURL string
+	// Retention is how long the stream keeps entries for consumers to catch up.
+	Retention time.Duration
+	// PublishTimeout bounds waiting for JetStream to acknowledge a publish.
+	PublishTimeout time.Duration
+}
+
+// DeliveryConfig controls forwarding scraped entries to the Python service.
+type DeliveryConfig struct {
+	// Enabled turns on delivery of every successfully scraped entry.
+	Enabled bool
+	// BatchSize is the maximum number of entries per bulk request.
+	BatchSize int
+	// FlushInterval is the longest an entry waits for its batch to fill.
+	FlushInterval time.Duration
+	// RetryInterval is how often the outbox is checked for due retries.
+	RetryInterval time.Duration
+	// InitialBackoff is the delay before the first retry; it doubles per attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries of one entry.
+	MaxBackoff time.Duration
+	// MaxAttempts is how many times an entry is tried before it moves to
+	// the dead letters; 0 retries forever.
+	MaxAttempts int
+}
+
+// PythonServiceConfig locates the Python service that stores scraped entries.
+type PythonServiceConfig struct {
+	// Transport selects how entries are delivered: "http" or "grpc".
+	Transport string
+	// URL is the base URL, without a trailing slash.
+	URL string
+	// GRPCAddr is the host:port of the WordIngest gRPC service.
+	GRPCAddr string
+	// Timeout bounds each attempt of a request to the service.
+	Timeout time.Duration
+	// Retries is how many times a request is retried after a 5xx or network error.
+	Retries int
+	// RetryBackoff is the delay before the first retry; it doubles on each retry.
+	RetryBackoff time.Duration
+	// BreakerThreshold is how many consecutive failed requests open the
+	// circuit breaker; 0 disables it.
+	BreakerThreshold int
+	// BreakerCooldown is how long the breaker stays open before a trial request.
+	BreakerCooldown time.Duration
+	// ServiceKey authenticates service-to-service calls (X-Service-Key).
+	ServiceKey string
+}
+
+// RateLimitConfig controls the per-user/per-key token bucket on scrape endpoints.
+type RateLimitConfig struct {
+	Enabled bool
+	// RequestsPerSecond is the refill rate of each bucket.
+	RequestsPerSecond float64
+	// Burst is the bucket capacity.
+	Burst int
+	// IdleTTL is how long an untouched bucket is kept before being pruned.
+	IdleTTL time.Duration
+	// APIKeys are the X-API-Key values that get a bucket of their own;
+	// requests with any other key are limited by user or IP.
+	APIKeys []string
+}
+
+// Load reads the configuration from the environment and the config file
+// named by CONFIG_FILE, if any. A config file that can't be read is logged
+// and ignored; use Parse to treat it as an error. Settings whose values
+// can't be parsed are reported in the error, rather than quietly replaced
+// by their defaults.
+func Load() (*Config, error) {
+	src, err := newSource(os.Getenv("CONFIG_FILE"))
+	if err != nil {
+		slog.Warn("ignoring config file", "error", err)
+	}
+	cfg := src.load()
+	src.check(cfg)
+	if err := src.err(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Parse reads the configuration like Load, then applies command-line flags
+// from args. The config file may also be named with -config, and unlike
+// Load, one that can't be read is an error.
+func Parse(args []string) (*Config, error) {
+	path := os.Getenv("CONFIG_FILE")
+	if p, ok := configFlag(args); ok {
+		path = p
+	}
+	src, err := newSource(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := src.load()
+	src.check(cfg)
+	if err := src.err(); err != nil {
+		return nil, err
+	}
+
+	fs := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	fs.String("config", path, "JSON config file; keys are the environment variable names")
+	fs.StringVar(&cfg.Server.Addr, "addr", cfg.Server.Addr, "address to listen on")
+	fs.StringVar(&cfg.Server.TLSCertFile, "tls-cert", cfg.Server.TLSCertFile, "TLS certificate file; serves HTTPS together with -tls-key")
+	fs.StringVar(&cfg.Server.TLSKeyFile, "tls-key", cfg.Server.TLSKeyFile, "TLS private key file")
+	fs.StringVar(&cfg.Log.Level, "log-level", cfg.Log.Level, "minimum log level: debug, info, warn or error")
+	fs.StringVar(&cfg.PythonService.URL, "python-url", cfg.PythonService.URL, "base URL of the Python service")
+	fs.DurationVar(&cfg.PythonService.Timeout, "python-timeout", cfg.PythonService.Timeout, "timeout for each request attempt to the Python service")
+	fs.StringVar(&cfg.PythonService.Transport, "python-transport", cfg.PythonService.Transport, "how to deliver entries to the Python service: http or grpc")
+	fs.IntVar(&cfg.PythonService.Retries, "python-retries", cfg.PythonService.Retries, "retries after 5xx or network errors from the Python service")
+	fs.BoolVar(&cfg.Scraper.BrowserEnabled, "browser", cfg.Scraper.BrowserEnabled, "use Chrome for inflection tables")
+	fs.StringVar(&cfg.OneShot.Word, "word", "", "scrape this word, print its entry as JSON and exit instead of serving")
+	fs.StringVar(&cfg.OneShot.Language, "lang", "", "language of -word, e.g. no-bm, no-nn, en, es or de (default: detect)")
+	fs.Parse(args)
+
+	if (cfg.Server.TLSCertFile == "") != (cfg.Server.TLSKeyFile == "") {
+		return nil, fmt.Errorf("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+	if _, _, err := net.SplitHostPort(cfg.Server.Addr); err != nil {
+		return nil, fmt.Errorf("invalid LISTEN_ADDR %q: %w", cfg.Server.Addr, err)
+	}
+	return cfg, nil
+}
+
+// configFlag finds -config (or --config) in args ahead of flag parsing, so
+// the file can be loaded before flags override it.
+func configFlag(args []string) (string, bool) {
+	for i, arg := range args {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "config" {
+			continue
+		}
+		if hasValue {
+			return value, true
+		}
+		if i+1 < len(args) {
+			return args[i+1], true
+		}
+	}
+	return "", false
+}
+
+func (src *source) load() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Addr:              src.getString("LISTEN_ADDR", ":8080"),
+			TLSCertFile:       src.getString("TLS_CERT_FILE", ""),
+			TLSKeyFile:        src.getString("TLS_KEY_FILE", ""),
+			ReadHeaderTimeout: src.getDuration("HTTP_READ_HEADER_TIMEOUT", 10*time.Second),
+			ReadTimeout:       src.getDuration("HTTP_READ_TIMEOUT", time.Minute),
+			WriteTimeout:      src.getDuration("HTTP_WRITE_TIMEOUT", 2*time.Minute),
+			IdleTimeout:       src.getDuration("HTTP_IDLE_TIMEOUT", 2*time.Minute),
+			KeepAlives:        src.getBool("HTTP_KEEP_ALIVES", true),
+			TCPKeepAlive:      src.getDuration("HTTP_TCP_KEEP_ALIVE", 30*time.Second),
+			MaxHeaderBytes:    src.getInt("HTTP_MAX_HEADER_BYTES", 64<<10),
+			MaxJobWait:        src.getDuration("JOB_MAX_WAIT", 30*time.Second),
+		},
+		Jobs: JobsConfig{
+			Concurrency:         src.getInt("JOB_CONCURRENCY", 2),
+			LanguageConcurrency: src.getList("JOB_LANGUAGE_CONCURRENCY"),
+			MaxWords:            src.getInt("JOB_MAX_WORDS", 5000),
+			Retention:           src.getDuration("JOB_RETENTION", 24*time.Hour),
+		},
+		Scraper: ScraperConfig{
+			OrdbokeneURL:          src.getString("SCRAPER_ORDBOKENE_URL", "https://ordbokene.no"),
+			ArticleAPIURL:         src.getString("SCRAPER_ARTICLE_API_URL", "https://ord.uib.no"),
+			RequestTimeout:        src.getDuration("SCRAPER_REQUEST_TIMEOUT", 10*time.Second),
+			BrowserEnabled:        src.getBool("SCRAPER_BROWSER_ENABLED", true),
+			BrowserTimeout:        src.getDuration("SCRAPER_BROWSER_TIMEOUT", 40*time.Second),
+			BrowserURL:            src.getString("CHROME_WS_URL", ""),
+			SenseDelay:            src.getDuration("SCRAPER_SENSE_DELAY", 0),
+			ResolveLemmas:         src.getBool("SCRAPER_RESOLVE_LEMMAS", true),
+			DetectLanguages:       src.getListOr("SCRAPER_DETECT_LANGUAGES", []string{langcode.Bokmal, langcode.Nynorsk}),
+			UserAgent:             src.getString("SCRAPER_USER_AGENT", "vocabulary-app/1.0 (+https://github.com/klaraeelise/vocabulary-app)"),
+			MaxBrowsers:           src.getInt("SCRAPER_MAX_BROWSERS", 2),
+			DisabledSources:       src.getList("SCRAPER_DISABLED_SOURCES"),
+			DisabledLanguages:     src.getList("SCRAPER_DISABLED_LANGUAGES"),
+			Proxies:               src.getList("SCRAPER_PROXIES"),
+			ProxyRotate:           src.getBool("SCRAPER_PROXY_ROTATE", false),
+			ProxyCooldown:         src.getDuration("SCRAPER_PROXY_COOLDOWN", 5*time.Minute),
+			LabelMappings:         src.getList("SCRAPER_LABEL_MAPPINGS"),
+			MaxSenses:             src.getInt("SCRAPER_MAX_SENSES", 50),
+			MaxExamplesPerMeaning: src.getInt("SCRAPER_MAX_EXAMPLES_PER_MEANING", 25),
+			MaxForms:              src.getInt("SCRAPER_MAX_FORMS", 100),
+		},
+		Webhooks: WebhooksConfig{
+			Enabled:              src.getBool("WEBHOOKS_ENABLED", true),
+			Timeout:              src.getDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+			Attempts:             src.getInt("WEBHOOK_ATTEMPTS", 3),
+			AllowPrivateNetworks: src.getBool("WEBHOOK_ALLOW_PRIVATE_NETWORKS", false),
+		},
+		DataPath: src.getString("DATA_PATH", "data/go-service.db"),
+		RateLimit: RateLimitConfig{
+			Enabled:           src.getBool("RATE_LIMIT_ENABLED", true),
+			RequestsPerSecond: src.getFloat("RATE_LIMIT_RPS", 0.5),
+			Burst:             src.getInt("RATE_LIMIT_BURST", 10),
+			IdleTTL:           src.getDuration("RATE_LIMIT_IDLE_TTL", 10*time.Minute),
+			APIKeys:           src.getList("RATE_LIMIT_API_KEYS"),
+		},
+		PythonService: PythonServiceConfig{
+			Transport:        src.getString("PYTHON_SERVICE_TRANSPORT", "http"),
+			URL:              src.getString("PYTHON_SERVICE_URL", "http://python-service:8000"),
+			GRPCAddr:         src.getString("PYTHON_SERVICE_GRPC_ADDR", "python-service:50051"),
+			Timeout:          src.getDuration("PYTHON_SERVICE_TIMEOUT", 10*time.Second),
+			Retries:          src.getInt("PYTHON_SERVICE_RETRIES", 2),
+			RetryBackoff:     src.getDuration("PYTHON_SERVICE_RETRY_BACKOFF", 250*time.Millisecond),
+			BreakerThreshold: src.getInt("PYTHON_SERVICE_BREAKER_THRESHOLD", 5),
+			BreakerCooldown:  src.getDuration("PYTHON_SERVICE_BREAKER_COOLDOWN", 30*time.Second),
+			ServiceKey:       src.getString("SERVICE_API_KEY", ""),
+		},
+		Delivery: DeliveryConfig{
+			Enabled:        src.getBool("DELIVERY_ENABLED", false),
+			BatchSize:      src.getInt("DELIVERY_BATCH_SIZE", 50),
+			FlushInterval:  src.getDuration("DELIVERY_FLUSH_INTERVAL", 2*time.Second),
+			RetryInterval:  src.getDuration("OUTBOX_RETRY_INTERVAL", 5*time.Second),
+			InitialBackoff: src.getDuration("OUTBOX_INITIAL_BACKOFF", 10*time.Second),
+			MaxBackoff:     src.getDuration("OUTBOX_MAX_BACKOFF", 10*time.Minute),
+			MaxAttempts:    src.getInt("OUTBOX_MAX_ATTEMPTS", 30),
+		},
+		Queue: QueueConfig{
+			NATSURL:        src.getString("NATS_URL", ""),
+			Retention:      src.getDuration("NATS_STREAM_RETENTION", 7*24*time.Hour),
+			PublishTimeout: src.getDuration("NATS_PUBLISH_TIMEOUT", 5*time.Second),
+		},
+		Events: EventsConfig{
+			KafkaBrokers: src.getList("KAFKA_BROKERS"),
+			TopicPrefix:  src.getString("KAFKA_TOPIC_PREFIX", "dictionary"),
+			BatchTimeout: src.getDuration("KAFKA_BATCH_TIMEOUT", time.Second),
+		},
+		Log: LogConfig{
+			Level:  src.getString("LOG_LEVEL", "info"),
+			Format: src.getString("LOG_FORMAT", "text"),
+		},
+		Canary: CanaryConfig{
+			Enabled:  src.getBool("CANARY_ENABLED", false),
+			Interval: src.getDuration("CANARY_INTERVAL", 6*time.Hour),
+			Words:    src.getListOr("CANARY_WORDS", []string{"no-bm:hund", "no-bm:spise", "no-nn:hund", "no-nn:eta"}),
+		},
+		Audio: AudioConfig{
+			ProxyEnabled:  src.getBool("AUDIO_PROXY_ENABLED", true),
+			CacheDir:      src.getString("AUDIO_CACHE_DIR", "data/audio"),
+			CacheMaxBytes: int64(src.getInt("AUDIO_CACHE_MAX_BYTES", 256<<20)),
+			AllowedHosts:  src.getListOr("AUDIO_ALLOWED_HOSTS", []string{"ordbokene.no", "ord.uib.no", "forvo.com", "api.dictionaryapi.dev", "upload.wikimedia.org"}),
+			MaxBytes:      int64(src.getInt("AUDIO_MAX_BYTES", 5<<20)),
+			Timeout:       src.getDuration("AUDIO_TIMEOUT", 10*time.Second),
+			ForvoAPIKey:   src.getString("FORVO_API_KEY", ""),
+		},
+		Suggest: SuggestConfig{
+			Limit:     src.getInt("SUGGEST_LIMIT", 10),
+			CacheTTL:  src.getDuration("SUGGEST_CACHE_TTL", time.Hour),
+			CacheSize: src.getInt("SUGGEST_CACHE_SIZE", 10000),
+		},
+		EntryCache: EntryCacheConfig{
+			Size: src.getInt("ENTRY_CACHE_SIZE", 1000),
+			TTL:  src.getDuration("ENTRY_CACHE_TTL", 24*time.Hour),
+
+			Warm:         src.getBool("ENTRY_CACHE_WARM", false),
+			WarmWords:    src.getInt("ENTRY_CACHE_WARM_WORDS", 300),
+			WarmInterval: src.getDuration("ENTRY_CACHE_WARM_INTERVAL", 12*time.Hour),
+			WarmDelay:    src.getDuration("ENTRY_CACHE_WARM_DELAY", 2*time.Second),
+			WarmIdle:     src.getDuration("ENTRY_CACHE_WARM_IDLE", time.Minute),
+		},
+		Dictionaries: DictionariesConfig{
+			Prefer:         src.getListOr("DICTIONARIES_PREFER", []string{langcode.English, langcode.Spanish, langcode.German}),
+			MaxUploadBytes: int64(src.getInt("DICTIONARIES_MAX_UPLOAD_BYTES", 256<<20)),
+		},
+		Analyze: AnalyzeConfig{
+			MaxWords:       src.getInt("ANALYZE_MAX_WORDS", 300),
+			Timeout:        src.getDuration("ANALYZE_TIMEOUT", 20*time.Second),
+			MaxUploadBytes: int64(src.getInt("ANALYZE_MAX_UPLOAD_BYTES", 20<<20)),
+		},
+		Frequency: FrequencyConfig{
+			Dir:     src.getString("FREQUENCY_DIR", "data/frequency"),
+			CEFRDir: src.getString("CEFR_DIR", "data/cefr"),
+		},
+		Archive: ArchiveConfig{
+			Backend: src.getString("ARCHIVE_BACKEND", ""),
+			Dir:     src.getString("ARCHIVE_DIR", "data/snapshots"),
+			URL:     src.getString("ARCHIVE_URL", ""),
+			Token:   src.getString("ARCHIVE_TOKEN", ""),
+			Timeout: src.getDuration("ARCHIVE_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// source looks settings up by environment variable name: the environment
+// first, then the config file. Values that can't be parsed are collected in
+// errs, and the setting keeps its default until err reports them.
+type source struct {
+	file map[string]string
+	errs []error
+}
+
+// newSource reads the JSON config file at path; an empty path means none.
+// File values may be strings, numbers, booleans or (for lists) arrays.
+func newSource(path string) (source, error) {
+	src := source{file: map[string]string{}}
+	if path == "" {
+		return src, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return src, fmt.Errorf("reading config file: %w", err)
+	}
+	// Numbers are kept as written, as large ones would otherwise come out
+	// in exponent form
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var values map[string]interface{}
+	if err := dec.Decode(&values); err != nil {
+		return src, fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+	for key, v := range values {
+		switch v := v.(type) {
+		case []interface{}:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+			src.file[key] = strings.Join(items, ",")
+		default:
+			src.file[key] = fmt.Sprint(v)
+		}
+	}
+	return src, nil
+}
+
+func (src *source) lookup(key string) string {
+	if v, ok := os.LookupEnv(key); ok && v != "" {
+		return v
+	}
+	return src.file[key]
+}
+
+// invalid records that the value of key isn't the kind of value wanted.
+func (src *source) invalid(key, value, want string) {
+	src.errs = append(src.errs, fmt.Errorf("invalid %s %q: want %s", key, value, want))
+}
+
+// check records settings that parse but can't be used.
+func (src *source) check(cfg *Config) {
+	// A rate of zero (or NaN) would refill no bucket and a burst below one
+	// would let no request through
+	if rps := cfg.RateLimit.RequestsPerSecond; !(rps > 0) || math.IsInf(rps, 1) {
+		src.invalid("RATE_LIMIT_RPS", src.lookup("RATE_LIMIT_RPS"), "a number above 0")
+	}
+	if cfg.RateLimit.Burst < 1 {
+		src.invalid("RATE_LIMIT_BURST", src.lookup("RATE_LIMIT_BURST"), "an integer of at least 1")
+	}
+}
+
+// err reports every value that couldn't be parsed or used, or nil.
+func (src *source) err() error {
+	return errors.Join(src.errs...)
+}
+
+func (src *source) getString(key, def string) string {
+	if v := src.lookup(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// getList reads a comma-separated list, skipping empty items.
+func (src *source) getList(key string) []string {
+	var items []string
+	for _, item := range strings.Split(src.lookup(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getListOr is getList with a default for when the setting is unset or empty.
+func (src *source) getListOr(key string, def []string) []string {
+	if items := src.getList(key); len(items) > 0 {
+		return items
+	}
+	return def
+}
+
+func (src *source) getInt(key string, def int) int {
+	raw := src.lookup(key)
+	if raw == "" {
+		return def
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		src.invalid(key, raw, "an integer")
+		return def
+	}
+	return v
+}
+
+func (src *source) getFloat(key string, def float64) float64 {
+	raw := src.lookup(key)
+	if raw == "" {
+		return def
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		src.invalid(key, raw, "a number")
+		return def
+	}
+	return v
+}
+
+func (src *source) getBool(key string, def bool) bool {
+	raw := src.lookup(key)
+	if raw == "" {
+		return def
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		src.invalid(key, raw, "true or false")
+		return def
+	}
+	return v
+}
+
+func (src *source) getDuration(key string, def time.Duration) time.Duration {
+	raw := src.lookup(key)
+	if raw == "" {
+		return def
+	}
+	v, err := time.ParseDuration(raw)
+	if err != nil {
+		src.invalid(key, raw, "a duration with a unit, e.g. 30s")
+		return def
+	}
+	return v
+}
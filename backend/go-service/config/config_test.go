@@ -0,0 +1,31 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseRejectsUnusableRateLimit(t *testing.T) {
+	for _, tc := range []struct{ key, value string }{
+		{"RATE_LIMIT_RPS", "0"},
+		{"RATE_LIMIT_RPS", "-1"},
+		{"RATE_LIMIT_RPS", "NaN"},
+		{"RATE_LIMIT_BURST", "0"},
+	} {
+		t.Run(tc.key+"="+tc.value, func(t *testing.T) {
+			t.Setenv("CONFIG_FILE", "")
+			t.Setenv(tc.key, tc.value)
+			_, err := Parse(nil)
+			if err == nil || !strings.Contains(err.Error(), tc.key) {
+				t.Errorf("Parse error = %v, want one about %s", err, tc.key)
+			}
+		})
+	}
+}
+
+func TestParseDefaults(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+	if _, err := Parse(nil); err != nil {
+		t.Fatalf("Parse with defaults: %v", err)
+	}
+}
@@ -0,0 +1,211 @@
+// Package deadletter keeps work that failed for good: deliveries that ran
+// out of retries, webhook notifications that never got through and jobs
+// that failed. Each letter carries what is needed to try again, and the
+// feature that produced it registers how to, so admins can inspect letters
+// and redrive them once the cause is fixed.
+package deadletter
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var lettersBucket = []byte("dead_letters")
+
+// Kinds of dead letters.
+const (
+	KindDelivery = "delivery" // an entry the Python service never accepted
+	KindWebhook  = "webhook"  // a job notification a webhook never accepted
+	KindJob      = "job"      // the failed words of a job
+)
+
+var (
+	// ErrNotFound is returned for unknown letters.
+	ErrNotFound = errors.New("dead letter not found")
+	// ErrNoRedrive is returned for letters whose feature is switched off,
+	// so nothing has registered how to redrive them.
+	ErrNoRedrive = errors.New("dead letter can't be redriven")
+)
+
+// Letter is a piece of failed work.
+type Letter struct {
+	ID   uint64 `json:"id"`
+	Kind string `json:"kind"`
+	// Payload is the work itself, in the form its kind's redrive takes.
+	Payload json.RawMessage `json:"payload"`
+	// Context identifies the work for people, e.g. the word or job ID.
+	Context map[string]string `json:"context,omitempty"`
+	// Error is the last failure, and Attempts how many times it was tried.
+	Error    string    `json:"error"`
+	Attempts int       `json:"attempts"`
+	FirstTry time.Time `json:"first_try"`
+	FailedAt time.Time `json:"failed_at"`
+	// Redrives counts failed redrives; each updates Error and FailedAt.
+	Redrives int `json:"redrives,omitempty"`
+}
+
+// RedriveFunc tries a letter's work again.
+type RedriveFunc func(ctx context.Context, letter Letter) error
+
+// Store keeps dead letters in the service's bolt database.
+type Store struct {
+	db *bolt.DB
+
+	mu       sync.Mutex
+	redrives map[string]RedriveFunc
+}
+
+// NewStore keeps dead letters in their own bucket of db.
+func NewStore(db *bolt.DB) (*Store, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(lettersBucket)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("initialising dead letters: %w", err)
+	}
+	return &Store{db: db, redrives: map[string]RedriveFunc{}}, nil
+}
+
+// Handle registers how letters of a kind are redriven.
+func (s *Store) Handle(kind string, redrive RedriveFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.redrives[kind] = redrive
+}
+
+// Add stores a letter, giving it an ID. FailedAt defaults to now and
+// FirstTry to FailedAt.
+func (s *Store) Add(letter Letter) (Letter, error) {
+	if letter.FailedAt.IsZero() {
+		letter.FailedAt = time.Now()
+	}
+	if letter.FirstTry.IsZero() {
+		letter.FirstTry = letter.FailedAt
+	}
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(lettersBucket)
+		id, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		letter.ID = id
+		return putLetter(b, letter)
+	})
+	return letter, err
+}
+
+// List returns up to limit letters of kind (all kinds when empty), oldest
+// first.
+func (s *Store) List(kind string, limit int) ([]Letter, error) {
+	letters := []Letter{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(lettersBucket).Cursor()
+		for k, v := c.First(); k != nil && len(letters) < limit; k, v = c.Next() {
+			var letter Letter
+			if err := json.Unmarshal(v, &letter); err != nil {
+				return fmt.Errorf("decoding dead letter %d: %w", binary.BigEndian.Uint64(k), err)
+			}
+			if kind == "" || letter.Kind == kind {
+				letters = append(letters, letter)
+			}
+		}
+		return nil
+	})
+	return letters, err
+}
+
+// Get returns the letter with the given ID.
+func (s *Store) Get(id uint64) (Letter, error) {
+	var letter Letter
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(lettersBucket).Get(letterKey(id))
+		if data == nil {
+			return ErrNotFound
+		}
+		return json.Unmarshal(data, &letter)
+	})
+	return letter, err
+}
+
+// Remove deletes a letter without redriving it.
+func (s *Store) Remove(id uint64) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(lettersBucket)
+		if b.Get(letterKey(id)) == nil {
+			return ErrNotFound
+		}
+		return b.Delete(letterKey(id))
+	})
+}
+
+// Redrive tries a letter's work again and removes the letter when it
+// succeeds. When it fails the letter stays, with the new error.
+func (s *Store) Redrive(ctx context.Context, id uint64) error {
+	letter, err := s.Get(id)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	redrive, ok := s.redrives[letter.Kind]
+	s.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: %s is disabled", ErrNoRedrive, letter.Kind)
+	}
+
+	if cause := redrive(ctx, letter); cause != nil {
+		letter.Error = cause.Error()
+		letter.FailedAt = time.Now()
+		letter.Redrives++
+		err := s.db.Update(func(tx *bolt.Tx) error {
+			return putLetter(tx.Bucket(lettersBucket), letter)
+		})
+		return errors.Join(cause, err)
+	}
+	err = s.Remove(id)
+	if errors.Is(err, ErrNotFound) {
+		// Removed by someone else while it was redriven
+		return nil
+	}
+	return err
+}
+
+// Counts returns how many letters there are of each kind, for the admin
+// stats.
+func (s *Store) Counts() (map[string]int, error) {
+	counts := map[string]int{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(lettersBucket).ForEach(func(_, v []byte) error {
+			var letter struct {
+				Kind string `json:"kind"`
+			}
+			if err := json.Unmarshal(v, &letter); err != nil {
+				return err
+			}
+			counts[letter.Kind]++
+			return nil
+		})
+	})
+	return counts, err
+}
+
+func putLetter(b *bolt.Bucket, letter Letter) error {
+	data, err := json.Marshal(letter)
+	if err != nil {
+		return err
+	}
+	return b.Put(letterKey(letter.ID), data)
+}
+
+func letterKey(id uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, id)
+	return key
+}
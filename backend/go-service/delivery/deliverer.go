@@ -0,0 +1,204 @@
+// Package delivery hands scraped entries to the Python service without
+// losing them when it is unreachable. Entries are batched and posted to the
+// bulk endpoint; failed batches are kept in a persistent outbox and retried
+// in the background with backoff, until they have been tried as often as
+// configured and move to the dead letters.
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+
+	"vocabulary-app/backend/go-service/client"
+	"vocabulary-app/backend/go-service/config"
+	"vocabulary-app/backend/go-service/deadletter"
+)
+
+// Deliverer sends entries to the Python service, falling back to the outbox.
+type Deliverer struct {
+	client      client.PythonClient
+	outbox      *Outbox
+	deadLetters *deadletter.Store
+	cfg         config.DeliveryConfig
+
+	mu      sync.Mutex
+	pending []models.WordEntry
+	full    chan struct{}
+}
+
+// NewDeliverer creates a deliverer sending through c, parking failures in
+// outbox and moving the entries that run out of retries to deadLetters.
+func NewDeliverer(c client.PythonClient, outbox *Outbox, deadLetters *deadletter.Store, cfg config.DeliveryConfig) *Deliverer {
+	return &Deliverer{
+		client:      c,
+		outbox:      outbox,
+		deadLetters: deadLetters,
+		cfg:         cfg,
+		full:        make(chan struct{}, 1),
+	}
+}
+
+// Deliver queues entry for the next batch. A batch is sent once it reaches
+// the configured size or the flush interval passes, whichever comes first,
+// so the caller never waits on the Python service.
+func (d *Deliverer) Deliver(ctx context.Context, entry models.WordEntry) {
+	d.mu.Lock()
+	d.pending = append(d.pending, entry)
+	full := len(d.pending) >= d.cfg.BatchSize
+	d.mu.Unlock()
+
+	if full {
+		select {
+		case d.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Run sends batches and retries outbox items until ctx is cancelled, then
+// flushes what is still pending.
+func (d *Deliverer) Run(ctx context.Context) {
+	flush := time.NewTicker(d.cfg.FlushInterval)
+	defer flush.Stop()
+	retry := time.NewTicker(d.cfg.RetryInterval)
+	defer retry.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.flush(context.Background())
+			return
+		case <-d.full:
+			d.flush(ctx)
+		case <-flush.C:
+			d.flush(ctx)
+		case <-retry.C:
+			d.retryDue(ctx)
+		}
+	}
+}
+
+// Stats reports the outbox backlog.
+func (d *Deliverer) Stats() (OutboxStats, error) {
+	return d.outbox.Stats()
+}
+
+// flush sends everything pending in batches, moving failed batches to the outbox.
+func (d *Deliverer) flush(ctx context.Context) {
+	d.mu.Lock()
+	pending := d.pending
+	d.pending = nil
+	d.mu.Unlock()
+
+	for len(pending) > 0 {
+		batch := pending[:min(len(pending), d.cfg.BatchSize)]
+		pending = pending[len(batch):]
+
+		err := d.client.SendWords(ctx, batch)
+		if err == nil {
+			continue
+		}
+		if err := d.outbox.Add(batch, err, time.Now().Add(d.backoff(1))); err != nil {
+			slog.Error("entries lost: delivery failed and outbox write failed", "entries", len(batch), "error", err)
+			continue
+		}
+		slog.Warn("delivery failed, entries queued in outbox", "entries", len(batch), "error", err)
+	}
+}
+
+// retryDue resends one batch of due outbox items.
+func (d *Deliverer) retryDue(ctx context.Context) {
+	items, err := d.outbox.Due(time.Now(), d.cfg.BatchSize)
+	if err != nil {
+		slog.Error("reading outbox failed", "error", err)
+		return
+	}
+	if len(items) == 0 {
+		return
+	}
+
+	entries := make([]models.WordEntry, len(items))
+	for i, item := range items {
+		entries[i] = item.Entry
+	}
+
+	if err := d.client.SendWords(ctx, entries); err != nil {
+		var retry, exhausted []Item
+		for _, item := range items {
+			if d.cfg.MaxAttempts > 0 && item.Attempts+1 >= d.cfg.MaxAttempts {
+				exhausted = append(exhausted, item)
+			} else {
+				retry = append(retry, item)
+			}
+		}
+		next := func(attempts int) time.Time { return time.Now().Add(d.backoff(attempts)) }
+		if err := d.outbox.Reschedule(retry, err, next); err != nil {
+			slog.Error("rescheduling outbox items failed", "items", len(retry), "error", err)
+		}
+		d.deadLetter(exhausted, err)
+		return
+	}
+	if err := d.outbox.Remove(items); err != nil {
+		slog.Error("removing delivered outbox items failed", "items", len(items), "error", err)
+		return
+	}
+	slog.Info("delivered entries from the outbox", "entries", len(items))
+}
+
+// deadLetter moves items that failed their last attempt with cause from
+// the outbox to the dead letters.
+func (d *Deliverer) deadLetter(items []Item, cause error) {
+	var moved []Item
+	for _, item := range items {
+		payload, err := json.Marshal(item.Entry)
+		if err == nil {
+			_, err = d.deadLetters.Add(deadletter.Letter{
+				Kind:     deadletter.KindDelivery,
+				Payload:  payload,
+				Context:  map[string]string{"word": item.Entry.Word, "language": item.Entry.Language},
+				Error:    cause.Error(),
+				Attempts: item.Attempts + 1,
+				FirstTry: item.CreatedAt,
+			})
+		}
+		if err != nil {
+			// Left in the outbox, to be tried again
+			slog.Error("moving outbox item to the dead letters failed", "word", item.Entry.Word, "error", err)
+			continue
+		}
+		moved = append(moved, item)
+	}
+	if len(moved) == 0 {
+		return
+	}
+	if err := d.outbox.Remove(moved); err != nil {
+		slog.Error("removing dead-lettered outbox items failed", "items", len(moved), "error", err)
+		return
+	}
+	slog.Warn("entries moved to the dead letters after their last delivery attempt", "entries", len(moved), "attempts", d.cfg.MaxAttempts, "error", cause)
+}
+
+// Redrive sends the entry of a delivery dead letter to the Python service
+// again. It is registered with the dead letters for KindDelivery.
+func (d *Deliverer) Redrive(ctx context.Context, letter deadletter.Letter) error {
+	var entry models.WordEntry
+	if err := json.Unmarshal(letter.Payload, &entry); err != nil {
+		return err
+	}
+	return d.client.SendWords(ctx, []models.WordEntry{entry})
+}
+
+// backoff is the delay after the given number of failed attempts: the
+// initial backoff doubled per attempt, capped at the maximum.
+func (d *Deliverer) backoff(attempts int) time.Duration {
+	delay := d.cfg.InitialBackoff
+	for i := 1; i < attempts && delay < d.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, d.cfg.MaxBackoff)
+}
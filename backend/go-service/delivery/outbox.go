@@ -0,0 +1,155 @@
+package delivery
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	bolt "go.etcd.io/bbolt"
+)
+
+var outboxBucket = []byte("outbox")
+
+// Item is an entry waiting to be delivered to the Python service.
+type Item struct {
+	ID          uint64           `json:"id"`
+	Entry       models.WordEntry `json:"entry"`
+	Attempts    int              `json:"attempts"`
+	LastError   string           `json:"last_error,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+	NextAttempt time.Time        `json:"next_attempt"`
+}
+
+// OutboxStats summarises the outbox for the admin stats.
+type OutboxStats struct {
+	Pending int        `json:"pending"`
+	Oldest  *time.Time `json:"oldest,omitempty"`
+}
+
+// Outbox persists undelivered entries in the bolt database so they survive
+// restarts. Items are keyed by an increasing sequence number, so iteration
+// order is insertion order.
+type Outbox struct {
+	db *bolt.DB
+}
+
+// NewOutbox keeps the outbox in its own bucket of db.
+func NewOutbox(db *bolt.DB) (*Outbox, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(outboxBucket)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("initialising outbox: %w", err)
+	}
+	return &Outbox{db: db}, nil
+}
+
+// Add stores entries whose delivery failed with cause, due for retry at next.
+func (o *Outbox) Add(entries []models.WordEntry, cause error, next time.Time) error {
+	now := time.Now()
+	return o.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(outboxBucket)
+		for _, entry := range entries {
+			id, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			item := Item{
+				ID:          id,
+				Entry:       entry,
+				Attempts:    1,
+				LastError:   cause.Error(),
+				CreatedAt:   now,
+				NextAttempt: next,
+			}
+			if err := putItem(b, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Due returns up to limit items whose next attempt is at or before now.
+func (o *Outbox) Due(now time.Time, limit int) ([]Item, error) {
+	var items []Item
+	err := o.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(outboxBucket).Cursor()
+		for k, v := c.First(); k != nil && len(items) < limit; k, v = c.Next() {
+			var item Item
+			if err := json.Unmarshal(v, &item); err != nil {
+				return fmt.Errorf("decoding outbox item %d: %w", binary.BigEndian.Uint64(k), err)
+			}
+			if !item.NextAttempt.After(now) {
+				items = append(items, item)
+			}
+		}
+		return nil
+	})
+	return items, err
+}
+
+// Remove deletes delivered items.
+func (o *Outbox) Remove(items []Item) error {
+	return o.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(outboxBucket)
+		for _, item := range items {
+			if err := b.Delete(itemKey(item.ID)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Reschedule records another failed attempt for each item and when to try
+// next, as computed by nextAttempt from the item's new attempt count.
+func (o *Outbox) Reschedule(items []Item, cause error, nextAttempt func(attempts int) time.Time) error {
+	return o.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(outboxBucket)
+		for _, item := range items {
+			item.Attempts++
+			item.LastError = cause.Error()
+			item.NextAttempt = nextAttempt(item.Attempts)
+			if err := putItem(b, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Stats counts pending items and reports the age of the oldest.
+func (o *Outbox) Stats() (OutboxStats, error) {
+	var stats OutboxStats
+	err := o.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(outboxBucket)
+		stats.Pending = b.Stats().KeyN
+		if _, v := b.Cursor().First(); v != nil {
+			var item Item
+			if err := json.Unmarshal(v, &item); err != nil {
+				return err
+			}
+			stats.Oldest = &item.CreatedAt
+		}
+		return nil
+	})
+	return stats, err
+}
+
+func putItem(b *bolt.Bucket, item Item) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	return b.Put(itemKey(item.ID), data)
+}
+
+func itemKey(id uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, id)
+	return key
+}
@@ -0,0 +1,231 @@
+package dictionaries
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+)
+
+// teiPartsOfSpeech maps the part-of-speech abbreviations FreeDict uses in
+// <pos> to a PartOfSpeech.
+var teiPartsOfSpeech = map[string]models.PartOfSpeech{
+	"n":     models.Noun,
+	"pn":    models.ProperNoun,
+	"propn": models.ProperNoun,
+	"v":     models.Verb,
+	"vi":    models.Verb,
+	"vt":    models.Verb,
+	"adj":   models.Adjective,
+	"adv":   models.Adverb,
+	"pron":  models.Pronoun,
+	"art":   models.Determiner,
+	"det":   models.Determiner,
+	"prep":  models.Preposition,
+	"conj":  models.Conjunction,
+	"int":   models.Interjection,
+	"intj":  models.Interjection,
+	"num":   models.Numeral,
+	"ptcl":  models.Particle,
+	"abbr":  models.Abbreviation,
+	"phr":   models.Phrase,
+}
+
+// teiEntry is an <entry> of a FreeDict TEI file. Translations sit in
+// <cit type="trans"> of each <sense> (TEI P5) or in <trans><tr> (P4).
+type teiEntry struct {
+	Orths   []string   `xml:"form>orth"`
+	Prons   []string   `xml:"form>pron"`
+	GramGrp teiGramGrp `xml:"gramGrp"`
+	Senses  []teiSense `xml:"sense"`
+	Trans   []string   `xml:"trans>tr"`
+}
+
+type teiGramGrp struct {
+	Pos    []string `xml:"pos"`
+	Gender []string `xml:"gen"`
+}
+
+type teiSense struct {
+	GramGrp teiGramGrp `xml:"gramGrp"`
+	Cits    []teiCit   `xml:"cit"`
+	Defs    []string   `xml:"def"`
+	Senses  []teiSense `xml:"sense"`
+}
+
+type teiCit struct {
+	Type   string   `xml:"type,attr"`
+	Quotes []string `xml:"quote"`
+	Cits   []teiCit `xml:"cit"`
+}
+
+// parseFreeDict reads a FreeDict TEI dictionary. The title and licence come
+// from the TEI header; each translation becomes a meaning, with the
+// entry's examples on the first.
+func parseFreeDict(r io.Reader) (*Dictionary, error) {
+	d := &Dictionary{Format: "freedict", Entries: map[string][]models.SenseEntry{}}
+	decoder := xml.NewDecoder(r)
+	sawRoot := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading TEI: %w", err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "TEI", "TEI.2":
+			sawRoot = true
+		case "title":
+			var title string
+			if err := decoder.DecodeElement(&title, &start); err != nil {
+				return nil, err
+			}
+			if d.Name == "" {
+				d.Name = strings.TrimSpace(title)
+			}
+		case "licence":
+			var licence string
+			if err := decoder.DecodeElement(&licence, &start); err != nil {
+				return nil, err
+			}
+			if d.License == "" {
+				d.License = firstLine(licence)
+			}
+			if d.License == "" {
+				for _, attr := range start.Attr {
+					if attr.Name.Local == "target" {
+						d.License = attr.Value
+					}
+				}
+			}
+		case "entry":
+			var entry teiEntry
+			if err := decoder.DecodeElement(&entry, &start); err != nil {
+				return nil, err
+			}
+			if len(entry.Orths) > 0 {
+				d.add(strings.TrimSpace(entry.Orths[0]), entry.senses()...)
+			}
+		}
+	}
+	if !sawRoot {
+		return nil, fmt.Errorf("not a TEI file")
+	}
+	return d, nil
+}
+
+// senses turns the entry into one sense per TEI sense (or one for P4
+// translations), inheriting the entry's grammar and pronunciation.
+func (e teiEntry) senses() []models.SenseEntry {
+	base := models.SenseEntry{}
+	applyGramGrp(&base, e.GramGrp)
+	for _, pron := range e.Prons {
+		if pron = strings.TrimSpace(pron); pron != "" {
+			base.Pronunciations = append(base.Pronunciations, models.Pronunciation{Text: pron, Notation: models.NotationIPA})
+		}
+	}
+
+	var senses []models.SenseEntry
+	if len(e.Trans) > 0 {
+		sense := base
+		for _, tr := range e.Trans {
+			sense.Meanings = appendMeaning(sense.Meanings, tr)
+		}
+		senses = append(senses, sense)
+	}
+	var walk func(teiSense, models.SenseEntry)
+	walk = func(s teiSense, parent models.SenseEntry) {
+		sense := parent
+		sense.Meanings = nil
+		applyGramGrp(&sense, s.GramGrp)
+		var examples []string
+		for _, cit := range s.Cits {
+			switch cit.Type {
+			case "trans", "translation", "translationEquivalent":
+				for _, quote := range cit.Quotes {
+					sense.Meanings = appendMeaning(sense.Meanings, quote)
+				}
+			case "example":
+				examples = append(examples, cit.example())
+			}
+		}
+		for _, def := range s.Defs {
+			sense.Meanings = appendMeaning(sense.Meanings, def)
+		}
+		if len(sense.Meanings) > 0 {
+			sense.Meanings[0].Examples = examples
+			senses = append(senses, sense)
+		}
+		for _, sub := range s.Senses {
+			walk(sub, sense)
+		}
+	}
+	for _, s := range e.Senses {
+		walk(s, base)
+	}
+	return senses
+}
+
+// example is an example citation with its translation, as
+// "quote – translation".
+func (c teiCit) example() string {
+	text := strings.TrimSpace(strings.Join(c.Quotes, " "))
+	for _, sub := range c.Cits {
+		if len(sub.Quotes) > 0 {
+			return text + " – " + strings.TrimSpace(sub.Quotes[0])
+		}
+	}
+	return text
+}
+
+func applyGramGrp(sense *models.SenseEntry, g teiGramGrp) {
+	if len(g.Pos) > 0 {
+		pos := strings.TrimSpace(g.Pos[0])
+		sense.Category = pos
+		sense.PartOfSpeech = teiPartsOfSpeech[strings.ToLower(strings.TrimSuffix(pos, "."))]
+		if sense.PartOfSpeech == "" {
+			sense.PartOfSpeech = models.OtherPartOfSpeech
+		}
+	}
+	if len(g.Gender) > 0 {
+		sense.Gender = strings.TrimSpace(g.Gender[0])
+	}
+}
+
+func appendMeaning(meanings []models.MeaningEntry, text string) []models.MeaningEntry {
+	if text = strings.Join(strings.Fields(text), " "); text != "" {
+		meanings = append(meanings, models.MeaningEntry{Description: text})
+	}
+	return meanings
+}
+
+var (
+	lineBreakPattern = regexp.MustCompile(`(?i)<br\s*/?>|</p>|</div>|</li>`)
+	tagPattern       = regexp.MustCompile(`<[^>]*>`)
+)
+
+// stripMarkup turns HTML, Pango or XDXF markup into plain text, keeping
+// line breaks.
+func stripMarkup(s string) string {
+	s = lineBreakPattern.ReplaceAllString(s, "\n")
+	return html.UnescapeString(tagPattern.ReplaceAllString(s, ""))
+}
+
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
@@ -0,0 +1,160 @@
+package dictionaries
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// Read parses an uploaded dictionary, picking the format by file name:
+//
+//   - a FreeDict TEI file: .tei or .xml, optionally gzipped (.tei.gz)
+//   - a StarDict dictionary (.ifo, .idx and .dict files, with the usual
+//     .idx.gz and dictzip .dict.dz compression) packed as .zip, .tar,
+//     .tar.gz/.tgz or .tar.bz2; an archive may hold a TEI file instead
+//
+// Dictionaries without a title are named after the file.
+func Read(filename string, data []byte) (*Dictionary, error) {
+	lower := strings.ToLower(filename)
+	var files map[string][]byte
+	var err error
+	switch {
+	case hasSuffix(lower, ".tei", ".xml", ".tei.gz", ".xml.gz"):
+		files = map[string][]byte{lower: data}
+	case hasSuffix(lower, ".zip"):
+		files, err = readZip(data)
+	case hasSuffix(lower, ".tar"):
+		files, err = readTar(bytes.NewReader(data))
+	case hasSuffix(lower, ".tar.gz", ".tgz"):
+		var gz *gzip.Reader
+		if gz, err = gzip.NewReader(bytes.NewReader(data)); err == nil {
+			files, err = readTar(gz)
+		}
+	case hasSuffix(lower, ".tar.bz2", ".tbz2"):
+		files, err = readTar(bzip2.NewReader(bytes.NewReader(data)))
+	default:
+		return nil, fmt.Errorf("unsupported dictionary file %q: want .tei, .tei.gz, .zip, .tar, .tar.gz or .tar.bz2", filename)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", filename, err)
+	}
+
+	d, err := parseFiles(files)
+	if err != nil {
+		return nil, err
+	}
+	if d.Name == "" {
+		d.Name = strings.TrimSuffix(path.Base(filename), path.Ext(filename))
+	}
+	if len(d.Entries) == 0 {
+		return nil, fmt.Errorf("dictionary has no entries")
+	}
+	return d, nil
+}
+
+// parseFiles finds a TEI file or a StarDict file set among files, keyed by
+// lowercase name, and parses it.
+func parseFiles(files map[string][]byte) (*Dictionary, error) {
+	var ifo, idx, dict []byte
+	for name, data := range files {
+		var err error
+		switch {
+		case hasSuffix(name, ".tei", ".xml"):
+			return parseFreeDict(bytes.NewReader(data))
+		case hasSuffix(name, ".tei.gz", ".xml.gz"):
+			gz, err := gzip.NewReader(bytes.NewReader(data))
+			if err != nil {
+				return nil, fmt.Errorf("reading %s: %w", name, err)
+			}
+			return parseFreeDict(gz)
+		case hasSuffix(name, ".ifo"):
+			ifo = data
+		case hasSuffix(name, ".idx"):
+			idx = data
+		case hasSuffix(name, ".idx.gz"):
+			idx, err = gunzip(data)
+		case hasSuffix(name, ".dict"):
+			dict = data
+		case hasSuffix(name, ".dict.dz"):
+			// dictzip is gzip with a random-access index in an extra field
+			dict, err = gunzip(data)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", name, err)
+		}
+	}
+	if ifo == nil || idx == nil || dict == nil {
+		return nil, fmt.Errorf("no TEI file or complete StarDict dictionary (.ifo, .idx, .dict) found")
+	}
+	return parseStarDict(ifo, idx, dict)
+}
+
+func readZip(data []byte) (map[string][]byte, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	files := map[string][]byte{}
+	for _, f := range archive.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		r, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			return nil, err
+		}
+		files[strings.ToLower(f.Name)] = data
+	}
+	return files, nil
+}
+
+func readTar(r io.Reader) (map[string][]byte, error) {
+	archive := tar.NewReader(r)
+	files := map[string][]byte{}
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(archive)
+		if err != nil {
+			return nil, err
+		}
+		files[strings.ToLower(header.Name)] = data
+	}
+}
+
+func gunzip(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func hasSuffix(name string, suffixes ...string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
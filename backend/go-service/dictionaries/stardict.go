@@ -0,0 +1,125 @@
+package dictionaries
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+)
+
+// starDictTextTypes are the StarDict field types holding a definition as
+// text; "m" and "l" are plain, the others markup that is stripped.
+var starDictTextTypes = "mlgxhwky"
+
+// parseStarDict reads a StarDict dictionary from its .ifo, .idx and .dict
+// files (decompressed). Each definition line becomes a meaning.
+func parseStarDict(ifo, idx, dict []byte) (*Dictionary, error) {
+	if !bytes.HasPrefix(ifo, []byte("StarDict's dict ifo file")) {
+		return nil, fmt.Errorf("not a StarDict .ifo file")
+	}
+	info := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(ifo))
+	for scanner.Scan() {
+		if key, value, ok := strings.Cut(scanner.Text(), "="); ok {
+			info[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	offsetSize := 4
+	if info["idxoffsetbits"] == "64" {
+		offsetSize = 8
+	}
+
+	d := &Dictionary{
+		Name:    info["bookname"],
+		Format:  "stardict",
+		Entries: map[string][]models.SenseEntry{},
+	}
+	for len(idx) > 0 {
+		end := bytes.IndexByte(idx, 0)
+		if end < 0 || len(idx) < end+1+offsetSize+4 {
+			return nil, fmt.Errorf("truncated .idx file")
+		}
+		word := string(idx[:end])
+		idx = idx[end+1:]
+		var offset uint64
+		if offsetSize == 8 {
+			offset = binary.BigEndian.Uint64(idx)
+		} else {
+			offset = uint64(binary.BigEndian.Uint32(idx))
+		}
+		size := uint64(binary.BigEndian.Uint32(idx[offsetSize:]))
+		idx = idx[offsetSize+4:]
+		if offset+size > uint64(len(dict)) {
+			return nil, fmt.Errorf("entry %q points past the end of the .dict file", word)
+		}
+
+		var sense models.SenseEntry
+		for _, field := range starDictFields(dict[offset:offset+size], info["sametypesequence"]) {
+			text := field.text
+			if field.kind != 'm' && field.kind != 'l' {
+				text = stripMarkup(text)
+			}
+			switch {
+			case field.kind == 't':
+				sense.Pronunciations = append(sense.Pronunciations, models.Pronunciation{Text: strings.TrimSpace(text), Notation: models.NotationIPA})
+			case strings.ContainsRune(starDictTextTypes, field.kind):
+				for _, line := range strings.Split(text, "\n") {
+					if line = strings.TrimSpace(line); line != "" {
+						sense.Meanings = append(sense.Meanings, models.MeaningEntry{Description: line})
+					}
+				}
+			}
+		}
+		d.add(strings.TrimSpace(word), sense)
+	}
+	return d, nil
+}
+
+// starDictField is one typed field of a StarDict definition.
+type starDictField struct {
+	kind rune
+	text string
+}
+
+// starDictFields splits a definition into its fields. With a
+// sametypesequence the types aren't stored and the last field runs to the
+// end; otherwise each field starts with its type. Lowercase types are
+// NUL-terminated text, uppercase ones binary data after a 4-byte size,
+// which is skipped.
+func starDictFields(data []byte, sequence string) []starDictField {
+	var fields []starDictField
+	for i := 0; len(data) > 0; i++ {
+		var kind byte
+		last := false
+		if sequence != "" {
+			if i >= len(sequence) {
+				break
+			}
+			kind = sequence[i]
+			last = i == len(sequence)-1
+		} else {
+			kind, data = data[0], data[1:]
+		}
+
+		if kind >= 'A' && kind <= 'Z' {
+			if last || len(data) < 4 {
+				break
+			}
+			size := int(binary.BigEndian.Uint32(data))
+			data = data[min(4+size, len(data)):]
+			continue
+		}
+		end := len(data)
+		if !last {
+			if n := bytes.IndexByte(data, 0); n >= 0 {
+				end = n
+			}
+		}
+		fields = append(fields, starDictField{kind: rune(kind), text: string(data[:end])})
+		data = data[min(end+1, len(data)):]
+	}
+	return fields
+}
@@ -0,0 +1,221 @@
+// Package dictionaries keeps offline dictionaries (StarDict, FreeDict TEI)
+// imported by admins, so words can be looked up locally in languages whose
+// live sources are unreliable or not implemented yet.
+package dictionaries
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// entriesBucket holds a bucket per language, holding a bucket per
+	// dictionary that maps normalized words to their senses.
+	entriesBucket = []byte("dictionaries")
+	// infoBucket maps "<language>\x00<name>" to the dictionary's Info.
+	infoBucket = []byte("dictionary_info")
+)
+
+// ErrNotFound is returned for dictionaries that haven't been imported.
+var ErrNotFound = errors.New("dictionary not found")
+
+// Info describes an imported dictionary.
+type Info struct {
+	Name       string    `json:"name"`
+	Language   string    `json:"language"`
+	Format     string    `json:"format"` // "stardict" or "freedict"
+	License    string    `json:"license,omitempty"`
+	Entries    int       `json:"entries"`
+	ImportedAt time.Time `json:"imported_at"`
+}
+
+// Dictionary is a parsed dictionary ready to be stored.
+type Dictionary struct {
+	Name    string
+	Format  string
+	License string
+	// Entries maps headwords to their senses.
+	Entries map[string][]models.SenseEntry
+}
+
+// add appends the senses that have meanings to a headword.
+func (d *Dictionary) add(word string, senses ...models.SenseEntry) {
+	for _, sense := range senses {
+		if word != "" && len(sense.Meanings) > 0 {
+			d.Entries[word] = append(d.Entries[word], sense)
+		}
+	}
+}
+
+// Store keeps dictionaries in the service's bolt database.
+type Store struct {
+	db *bolt.DB
+}
+
+// NewStore keeps dictionaries in their own buckets of db.
+func NewStore(db *bolt.DB) (*Store, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(entriesBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists(infoBucket)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("initialising dictionaries: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Put stores a dictionary for a canonical language, replacing any earlier
+// import of the same name.
+func (s *Store) Put(language string, d *Dictionary) (Info, error) {
+	info := Info{
+		Name:       d.Name,
+		Language:   language,
+		Format:     d.Format,
+		License:    d.License,
+		ImportedAt: time.Now().UTC(),
+	}
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		languages, err := tx.Bucket(entriesBucket).CreateBucketIfNotExists([]byte(language))
+		if err != nil {
+			return err
+		}
+		if languages.Bucket([]byte(d.Name)) != nil {
+			if err := languages.DeleteBucket([]byte(d.Name)); err != nil {
+				return err
+			}
+		}
+		b, err := languages.CreateBucket([]byte(d.Name))
+		if err != nil {
+			return err
+		}
+		// Merge headwords that only differ before normalization
+		merged := map[string][]models.SenseEntry{}
+		for word, senses := range d.Entries {
+			key := models.NormalizeWord(language, word)
+			merged[key] = append(merged[key], senses...)
+		}
+		prefix := senseIDPrefix(d.Name)
+		for word, senses := range merged {
+			for i := range senses {
+				senses[i].ID = fmt.Sprintf("%s_%d", prefix, i+1)
+			}
+			data, err := json.Marshal(senses)
+			if err != nil {
+				return err
+			}
+			if err := b.Put([]byte(word), data); err != nil {
+				return err
+			}
+		}
+		info.Entries = len(merged)
+
+		data, err := json.Marshal(info)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(infoBucket).Put(infoKey(language, d.Name), data)
+	})
+	return info, err
+}
+
+// Lookup returns the senses every dictionary of a canonical language has
+// for word, and false when none has it. Source and License name the
+// dictionaries the senses came from.
+func (s *Store) Lookup(word, language string) (models.WordEntry, bool) {
+	word = models.NormalizeWord(language, word)
+	entry := models.WordEntry{Word: word}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		languages := tx.Bucket(entriesBucket).Bucket([]byte(language))
+		if languages == nil {
+			return nil
+		}
+		return languages.ForEachBucket(func(name []byte) error {
+			data := languages.Bucket(name).Get([]byte(word))
+			if data == nil {
+				return nil
+			}
+			var senses []models.SenseEntry
+			if err := json.Unmarshal(data, &senses); err != nil {
+				return err
+			}
+			entry.Senses = append(entry.Senses, senses...)
+			if entry.Source == "" {
+				entry.Source = string(name)
+				if info, ok := s.info(tx, language, string(name)); ok {
+					entry.License = info.License
+				}
+			}
+			return nil
+		})
+	})
+	return entry, err == nil && len(entry.Senses) > 0
+}
+
+// List returns the imported dictionaries, by language and name.
+func (s *Store) List() ([]Info, error) {
+	infos := []Info{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(infoBucket).ForEach(func(_, data []byte) error {
+			var info Info
+			if err := json.Unmarshal(data, &info); err != nil {
+				return err
+			}
+			infos = append(infos, info)
+			return nil
+		})
+	})
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].Language != infos[j].Language {
+			return infos[i].Language < infos[j].Language
+		}
+		return infos[i].Name < infos[j].Name
+	})
+	return infos, err
+}
+
+// Delete removes an imported dictionary.
+func (s *Store) Delete(language, name string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if _, ok := s.info(tx, language, name); !ok {
+			return ErrNotFound
+		}
+		if languages := tx.Bucket(entriesBucket).Bucket([]byte(language)); languages != nil && languages.Bucket([]byte(name)) != nil {
+			if err := languages.DeleteBucket([]byte(name)); err != nil {
+				return err
+			}
+		}
+		return tx.Bucket(infoBucket).Delete(infoKey(language, name))
+	})
+}
+
+func (s *Store) info(tx *bolt.Tx, language, name string) (Info, bool) {
+	var info Info
+	data := tx.Bucket(infoBucket).Get(infoKey(language, name))
+	if data == nil || json.Unmarshal(data, &info) != nil {
+		return Info{}, false
+	}
+	return info, true
+}
+
+// senseIDPrefix turns a dictionary name into a sense ID prefix, e.g.
+// "English-Norwegian FreeDict" into "english_norwegian_freedict".
+func senseIDPrefix(name string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), "_")
+}
+
+func infoKey(language, name string) []byte {
+	return []byte(language + "\x00" + name)
+}
@@ -0,0 +1,203 @@
+package documents
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+)
+
+// maxEPUBBytes caps what is read from one EPUB once decompressed, all
+// files together, as a small archive can unpack to gigabytes.
+const maxEPUBBytes = 64 << 20
+
+// ErrTooLarge is returned for e-books that decompress to more than
+// maxEPUBBytes.
+var ErrTooLarge = errors.New("e-book too large once decompressed")
+
+// epubContainer is META-INF/container.xml, naming the package document.
+type epubContainer struct {
+	Rootfiles []struct {
+		FullPath string `xml:"full-path,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+// epubPackage is the package document (.opf): the book's files and the
+// order they are read in.
+type epubPackage struct {
+	Items []struct {
+		ID        string `xml:"id,attr"`
+		Href      string `xml:"href,attr"`
+		MediaType string `xml:"media-type,attr"`
+	} `xml:"manifest>item"`
+	Spine []struct {
+		IDRef string `xml:"idref,attr"`
+	} `xml:"spine>itemref"`
+}
+
+// epubText reads the chapters of an EPUB in spine order. Books without a
+// readable package document fall back to their XHTML files in name order.
+func epubText(data []byte) (string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("reading EPUB: %w", err)
+	}
+	files := map[string]*zip.File{}
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+	budget := zipBudget{left: maxEPUBBytes}
+
+	chapters := epubSpine(files, &budget)
+	if len(chapters) == 0 {
+		for name := range files {
+			if hasExt(name, ".xhtml", ".html", ".htm") {
+				chapters = append(chapters, name)
+			}
+		}
+		slices.Sort(chapters)
+	}
+	if len(chapters) == 0 {
+		return "", fmt.Errorf("EPUB has no chapters")
+	}
+
+	var text strings.Builder
+	for _, name := range chapters {
+		f, ok := files[name]
+		if !ok {
+			continue
+		}
+		content, err := budget.read(f)
+		if err != nil {
+			return "", fmt.Errorf("reading %s: %w", name, err)
+		}
+		htmlText(&text, content)
+		text.WriteString("\n")
+	}
+	return text.String(), nil
+}
+
+// epubSpine returns the archive paths of the book's XHTML files in reading
+// order, or nil if the package document can't be read.
+func epubSpine(files map[string]*zip.File, budget *zipBudget) []string {
+	var container epubContainer
+	if !budget.decodeXML(files["META-INF/container.xml"], &container) || len(container.Rootfiles) == 0 {
+		return nil
+	}
+	opfPath := container.Rootfiles[0].FullPath
+	var pkg epubPackage
+	if !budget.decodeXML(files[opfPath], &pkg) {
+		return nil
+	}
+	hrefs := map[string]string{}
+	for _, item := range pkg.Items {
+		if item.MediaType == "application/xhtml+xml" || hasExt(item.Href, ".xhtml", ".html", ".htm") {
+			hrefs[item.ID] = item.Href
+		}
+	}
+	var chapters []string
+	for _, ref := range pkg.Spine {
+		if href, ok := hrefs[ref.IDRef]; ok {
+			// Hrefs are relative to the package document and may be escaped
+			if unescaped, err := url.PathUnescape(href); err == nil {
+				href = unescaped
+			}
+			chapters = append(chapters, path.Join(path.Dir(opfPath), href))
+		}
+	}
+	return chapters
+}
+
+// blockElements end a line of extracted text.
+var blockElements = map[string]bool{
+	"p": true, "div": true, "br": true, "li": true, "tr": true, "blockquote": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"section": true, "article": true, "dt": true, "dd": true,
+}
+
+// htmlText appends the text of an (X)HTML document's body to text, one
+// line per block, leaving out scripts and styles. The parser is lenient,
+// as EPUB files aren't always well-formed.
+func htmlText(text *strings.Builder, content []byte) {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+	skip := 0
+	inBody := false
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch name := strings.ToLower(t.Name.Local); {
+			case name == "body":
+				inBody = true
+			case name == "script" || name == "style":
+				skip++
+			case blockElements[name]:
+				text.WriteString("\n")
+			}
+		case xml.EndElement:
+			switch name := strings.ToLower(t.Name.Local); {
+			case name == "script" || name == "style":
+				skip = max(skip-1, 0)
+			case blockElements[name]:
+				text.WriteString("\n")
+			}
+		case xml.CharData:
+			if inBody && skip == 0 {
+				text.Write(t)
+			}
+		}
+	}
+}
+
+// zipBudget is how many more decompressed bytes may be read from an
+// archive.
+type zipBudget struct {
+	left int64
+}
+
+func (b *zipBudget) decodeXML(f *zip.File, v any) bool {
+	if f == nil {
+		return false
+	}
+	content, err := b.read(f)
+	if err != nil {
+		return false
+	}
+	return xml.Unmarshal(content, v) == nil
+}
+
+// read decompresses f, failing with ErrTooLarge once the budget runs out.
+// The sizes in the archive's headers aren't trusted.
+func (b *zipBudget) read(f *zip.File) ([]byte, error) {
+	r, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	content, err := io.ReadAll(io.LimitReader(r, b.left+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > b.left {
+		b.left = 0
+		return nil, ErrTooLarge
+	}
+	b.left -= int64(len(content))
+	return content, nil
+}
+
+func hasExt(name string, exts ...string) bool {
+	return slices.Contains(exts, strings.ToLower(path.Ext(name)))
+}
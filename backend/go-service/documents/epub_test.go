@@ -0,0 +1,58 @@
+package documents
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// buildEPUB zips files into an EPUB without a package document, so its
+// chapters are read in name order.
+func buildEPUB(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestEPUBText(t *testing.T) {
+	data := buildEPUB(t, map[string]string{
+		"ch1.xhtml": "<html><body><p>Hunden spiser.</p></body></html>",
+		"ch2.xhtml": "<html><body><p>Katten sover.</p></body></html>",
+	})
+	text, err := Text("book.epub", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(text, "Hunden spiser.") || !strings.Contains(text, "Katten sover.") {
+		t.Errorf("text = %q, want both chapters", text)
+	}
+}
+
+func TestEPUBTextRefusesZipBomb(t *testing.T) {
+	// Compresses to well under a megabyte
+	chapter := "<html><body><p>" + strings.Repeat("a", maxEPUBBytes+1) + "</p></body></html>"
+	data := buildEPUB(t, map[string]string{"ch1.xhtml": chapter})
+	if len(data) > 1<<20 {
+		t.Fatalf("archive is %d bytes, want a highly compressed one", len(data))
+	}
+
+	_, err := Text("book.epub", data)
+	if !errors.Is(err, ErrTooLarge) {
+		t.Fatalf("Text error = %v, want ErrTooLarge", err)
+	}
+}
@@ -0,0 +1,48 @@
+// Package documents extracts the running text of uploaded e-books and
+// subtitle files, for analyzing their vocabulary.
+package documents
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+	"unicode/utf8"
+)
+
+// Text returns the text of an uploaded document, picking the format by
+// file name:
+//
+//   - an EPUB e-book (.epub), its chapters in reading order
+//   - SubRip (.srt) or WebVTT (.vtt) subtitles, without cue numbers,
+//     timings and styling
+//   - plain text (.txt)
+//
+// Text that isn't UTF-8 is read as Latin-1, which older subtitle files
+// often are.
+func Text(filename string, data []byte) (string, error) {
+	switch strings.ToLower(path.Ext(filename)) {
+	case ".epub":
+		return epubText(data)
+	case ".srt", ".vtt":
+		return subtitleText(decode(data)), nil
+	case ".txt":
+		return decode(data), nil
+	default:
+		return "", fmt.Errorf("unsupported file %q: want .epub, .srt, .vtt or .txt", filename)
+	}
+}
+
+// decode returns data as a string, dropping a byte order mark and reading
+// it as Latin-1 if it isn't valid UTF-8.
+func decode(data []byte) string {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if utf8.Valid(data) {
+		return string(data)
+	}
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+	return string(runes)
+}
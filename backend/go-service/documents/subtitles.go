@@ -0,0 +1,49 @@
+package documents
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	cueTagPattern   = regexp.MustCompile(`<[^>]*>`)
+	assTagPattern   = regexp.MustCompile(`\{\\[^}]*\}`)
+	cueIndexPattern = regexp.MustCompile(`^\d+$`)
+)
+
+// subtitleText keeps the spoken lines of SubRip or WebVTT subtitles,
+// dropping the header, cue numbers and identifiers, timings, NOTE and
+// STYLE blocks, and styling tags such as <i> and {\an8}.
+func subtitleText(s string) string {
+	var lines []string
+	skipBlock := false
+	for _, block := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n\n") {
+		blockLines := strings.Split(strings.TrimSpace(block), "\n")
+		first := blockLines[0]
+		if strings.HasPrefix(first, "WEBVTT") || strings.HasPrefix(first, "NOTE") ||
+			strings.HasPrefix(first, "STYLE") || strings.HasPrefix(first, "REGION") {
+			skipBlock = true
+			continue
+		}
+		// A cue's text starts after its timing line; blocks without one
+		// continue a skipped block or are stray lines kept as they are
+		start := 0
+		for i, line := range blockLines {
+			if strings.Contains(line, "-->") {
+				start = i + 1
+				skipBlock = false
+				break
+			}
+		}
+		if skipBlock {
+			continue
+		}
+		for _, line := range blockLines[start:] {
+			line = assTagPattern.ReplaceAllString(cueTagPattern.ReplaceAllString(line, ""), "")
+			if line = strings.TrimSpace(line); line != "" && !cueIndexPattern.MatchString(line) {
+				lines = append(lines, line)
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
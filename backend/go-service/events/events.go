@@ -0,0 +1,88 @@
+// Package events defines the dictionary events emitted by the Go service
+// and publishes them to Kafka. Every event is wrapped in an Envelope naming
+// its type and schema version; the payload schemas are in schemas/ as JSON
+// Schema documents, one per type and version.
+package events
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+)
+
+// Type names an event and, with the topic prefix, its Kafka topic.
+type Type string
+
+const (
+	TypeWordScraped  Type = "word_scraped"
+	TypeWordUpdated  Type = "word_updated"
+	TypeScrapeFailed Type = "scrape_failed"
+)
+
+// SchemaVersion is bumped when a payload changes incompatibly.
+const SchemaVersion = 1
+
+// Envelope is the message written to Kafka.
+type Envelope struct {
+	ID            string          `json:"id"`
+	Type          Type            `json:"type"`
+	SchemaVersion int             `json:"schema_version"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	RequestID     string          `json:"request_id,omitempty"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+// WordScraped is emitted for every successful scrape.
+type WordScraped struct {
+	Word        string           `json:"word"`
+	Language    string           `json:"language"`
+	ContentHash string           `json:"content_hash"`
+	Entry       models.WordEntry `json:"entry"`
+}
+
+// WordUpdated is emitted when a scrape returns different content than the
+// previous scrape of the same word seen by this process.
+type WordUpdated struct {
+	Word                string           `json:"word"`
+	Language            string           `json:"language"`
+	ContentHash         string           `json:"content_hash"`
+	PreviousContentHash string           `json:"previous_content_hash"`
+	Entry               models.WordEntry `json:"entry"`
+}
+
+// ScrapeFailed is emitted when a scrape returns an error.
+type ScrapeFailed struct {
+	Word     string `json:"word"`
+	Language string `json:"language"`
+	Error    string `json:"error"`
+}
+
+// ContentHash fingerprints an entry's content. ScrapedAt is left out, so a
+// re-scrape that finds the same content hashes the same.
+func ContentHash(entry models.WordEntry) string {
+	entry.ScrapedAt = time.Time{}
+	data, _ := json.Marshal(entry)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func newEnvelope(t Type, requestID string, payload interface{}) (Envelope, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Envelope{}, err
+	}
+	id := make([]byte, 16)
+	rand.Read(id)
+	return Envelope{
+		ID:            hex.EncodeToString(id),
+		Type:          t,
+		SchemaVersion: SchemaVersion,
+		OccurredAt:    time.Now().UTC(),
+		RequestID:     requestID,
+		Payload:       data,
+	}, nil
+}
@@ -0,0 +1,130 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"github.com/segmentio/kafka-go"
+
+	"vocabulary-app/backend/go-service/config"
+	"vocabulary-app/backend/go-service/requestid"
+)
+
+// maxTrackedHashes bounds the memory used to detect updated words; when it
+// is reached the history is reset and the next scrapes count as new again.
+const maxTrackedHashes = 100_000
+
+// Emitter writes events to one Kafka topic per event type
+// (<prefix>.word_scraped etc.), keyed by language and word so all events
+// for a word land in the same partition in order.
+type Emitter struct {
+	writer *kafka.Writer
+	prefix string
+
+	mu     sync.Mutex
+	hashes map[string]string // language/word -> last content hash
+}
+
+// NewEmitter creates an emitter for the configured brokers. Writes are
+// batched and asynchronous, so emitting never blocks a request.
+func NewEmitter(cfg config.EventsConfig) *Emitter {
+	return &Emitter{
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(cfg.KafkaBrokers...),
+			Balancer:               &kafka.Hash{},
+			BatchTimeout:           cfg.BatchTimeout,
+			Async:                  true,
+			AllowAutoTopicCreation: true,
+			Completion: func(messages []kafka.Message, err error) {
+				if err != nil {
+					slog.Warn("writing events to Kafka failed", "events", len(messages), "error", err)
+				}
+			},
+		},
+		prefix: cfg.TopicPrefix,
+		hashes: make(map[string]string),
+	}
+}
+
+// Close flushes pending events and closes the writer.
+func (e *Emitter) Close() error {
+	return e.writer.Close()
+}
+
+// Scraped emits word_scraped, plus word_updated if the content changed
+// since the word was last scraped.
+func (e *Emitter) Scraped(ctx context.Context, entry models.WordEntry) {
+	hash := ContentHash(entry)
+	key := entry.Language + "/" + entry.Word
+
+	e.mu.Lock()
+	previous, seen := e.hashes[key]
+	if len(e.hashes) >= maxTrackedHashes {
+		e.hashes = make(map[string]string)
+	}
+	e.hashes[key] = hash
+	e.mu.Unlock()
+
+	e.emit(ctx, TypeWordScraped, key, WordScraped{
+		Word:        entry.Word,
+		Language:    entry.Language,
+		ContentHash: hash,
+		Entry:       entry,
+	})
+	if seen && previous != hash {
+		e.emit(ctx, TypeWordUpdated, key, WordUpdated{
+			Word:                entry.Word,
+			Language:            entry.Language,
+			ContentHash:         hash,
+			PreviousContentHash: previous,
+			Entry:               entry,
+		})
+	}
+}
+
+// Failed emits scrape_failed.
+func (e *Emitter) Failed(ctx context.Context, word, language string, err error) {
+	e.emit(ctx, TypeScrapeFailed, language+"/"+word, ScrapeFailed{
+		Word:     word,
+		Language: language,
+		Error:    err.Error(),
+	})
+}
+
+// Topic returns the Kafka topic for an event type.
+func (e *Emitter) Topic(t Type) string {
+	return strings.TrimSuffix(e.prefix, ".") + "." + string(t)
+}
+
+func (e *Emitter) emit(ctx context.Context, t Type, key string, payload interface{}) {
+	envelope, err := newEnvelope(t, requestid.FromContext(ctx), payload)
+	if err != nil {
+		slog.Error("building event failed", "type", t, "error", err)
+		return
+	}
+	value, err := json.Marshal(envelope)
+	if err != nil {
+		slog.Error("encoding event failed", "type", t, "error", err)
+		return
+	}
+
+	// Async writer: this only queues the message
+	err = e.writer.WriteMessages(context.WithoutCancel(ctx), kafka.Message{
+		Topic: e.Topic(t),
+		Key:   []byte(key),
+		Value: value,
+		Time:  envelope.OccurredAt,
+		Headers: []kafka.Header{
+			{Key: "event_type", Value: []byte(t)},
+			{Key: "schema_version", Value: []byte(fmt.Sprint(SchemaVersion))},
+		},
+	})
+	if err != nil {
+		slog.Warn("queueing event failed", "type", t, "error", err)
+	}
+}
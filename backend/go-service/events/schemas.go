@@ -0,0 +1,9 @@
+package events
+
+import "embed"
+
+// Schemas holds the JSON Schema documents for the envelope and each payload,
+// named <type>.v<version>.json.
+//
+//go:embed schemas/*.json
+var Schemas embed.FS
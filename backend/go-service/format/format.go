@@ -0,0 +1,89 @@
+// Package format writes entries and other responses as JSON, YAML or a
+// human-readable table, for the vocab command and the HTTP API.
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is an output format.
+type Format string
+
+const (
+	JSON  Format = "json"
+	YAML  Format = "yaml"
+	Table Format = "table"
+)
+
+// Parse returns the format called name.
+func Parse(name string) (Format, error) {
+	switch f := Format(name); f {
+	case JSON, YAML, Table:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown output format %q: must be json, yaml or table", name)
+}
+
+// Write writes v to w in format f. Only entries can be written as a table.
+func Write(w io.Writer, f Format, v interface{}) error {
+	switch f {
+	case YAML:
+		data, err := MarshalYAML(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	case Table:
+		entry, ok := v.(models.WordEntry)
+		if !ok {
+			return fmt.Errorf("table output is only available for entries, not %T", v)
+		}
+		return WriteTable(w, entry)
+	default:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+}
+
+// MarshalYAML encodes v as YAML with the field names and field order of
+// its JSON encoding, so both formats describe entries the same way.
+func MarshalYAML(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	// JSON is YAML in flow style; parsing it as a node keeps the key order
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	blockStyle(&node)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// blockStyle drops the flow style and quoting JSON input was parsed with,
+// leaving the encoder to quote only strings that need it.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
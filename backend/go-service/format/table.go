@@ -0,0 +1,101 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+)
+
+// WriteTable writes entry for reading in a terminal: a heading, then each
+// sense with its numbered meanings, examples, expressions and a table of
+// its forms, then any warnings.
+func WriteTable(w io.Writer, entry models.WordEntry) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	heading := entry.Word
+	if entry.Language != "" {
+		heading += " (" + entry.Language + ")"
+	}
+	if entry.Query != "" && entry.Query != entry.Word {
+		heading += ", looked up as " + entry.Query
+	}
+	fmt.Fprintln(tw, heading)
+	if prons := pronunciationTexts(entry.Pronunciations); prons != "" {
+		fmt.Fprintf(tw, "Pronunciation:\t%s\n", prons)
+	}
+	if entry.Frequency != nil {
+		fmt.Fprintf(tw, "Frequency:\trank %d, band %d\n", entry.Frequency.Rank, entry.Frequency.Band)
+	}
+	if entry.CEFR != nil {
+		fmt.Fprintf(tw, "CEFR level:\t%s\n", entry.CEFR.Level)
+	}
+	if entry.Source != "" {
+		fmt.Fprintf(tw, "Source:\t%s\n", entry.Source)
+	}
+
+	for i, sense := range entry.Senses {
+		fmt.Fprintln(tw)
+		fmt.Fprintf(tw, "%d. %s\n", i+1, senseHeading(sense))
+		if prons := pronunciationTexts(sense.Pronunciations); prons != "" {
+			fmt.Fprintf(tw, "   Pronunciation:\t%s\n", prons)
+		}
+		for j, meaning := range sense.Meanings {
+			fmt.Fprintf(tw, "   %d) %s\n", j+1, meaning.Description)
+			for _, example := range meaning.Examples {
+				fmt.Fprintf(tw, "      - %s\n", example)
+			}
+		}
+		for _, expr := range sense.Expressions {
+			fmt.Fprintf(tw, "   * %s: %s\n", expr.Phrase, expr.Explanation)
+		}
+		if len(sense.WordForms) > 0 {
+			fmt.Fprintln(tw, "   Forms:")
+			for _, form := range sense.WordForms {
+				fmt.Fprintf(tw, "     %s\t%s\n", form.Label, strings.Join(form.Forms, ", "))
+			}
+		}
+	}
+
+	if len(entry.Warnings) > 0 {
+		fmt.Fprintln(tw)
+		fmt.Fprintln(tw, "Warnings:")
+		for _, warning := range entry.Warnings {
+			if warning.SenseID != "" {
+				fmt.Fprintf(tw, "  %s\t%s\t%s\n", warning.Code, warning.SenseID, warning.Message)
+			} else {
+				fmt.Fprintf(tw, "  %s\t\t%s\n", warning.Code, warning.Message)
+			}
+		}
+	}
+	return tw.Flush()
+}
+
+// senseHeading describes a sense by its category, article and gender,
+// e.g. "substantiv (noun), en, hankjønn".
+func senseHeading(sense models.SenseEntry) string {
+	parts := []string{sense.Category}
+	if sense.PartOfSpeech != "" && string(sense.PartOfSpeech) != sense.Category {
+		parts[0] += " (" + string(sense.PartOfSpeech) + ")"
+	}
+	for _, part := range []string{sense.Article, sense.Gender} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func pronunciationTexts(prons []models.Pronunciation) string {
+	var texts []string
+	for _, p := range prons {
+		text := p.Text
+		if p.Variant != "" {
+			text += " (" + p.Variant + ")"
+		}
+		texts = append(texts, text)
+	}
+	return strings.Join(texts, ", ")
+}
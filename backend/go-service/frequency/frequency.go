@@ -0,0 +1,230 @@
+// Package frequency ranks words by how common they are, from one frequency
+// list per language (e.g. NoWaC for Norwegian, SUBTLEX for English), so
+// decks can be sorted by usefulness, and estimates their CEFR level.
+//
+// A list is a text file named after the canonical language code (no-bm.txt,
+// en.txt, ...) with one word per line, most frequent first. Anything after
+// the word on a line (such as a count) is ignored, as are blank lines and
+// lines starting with "#".
+//
+// Curated CEFR lists use the same names and layout, with the word's level
+// (A1–C2) after it on each line.
+package frequency
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+)
+
+// bandLimits are the highest ranks in bands 1 to 4; rarer words are band 5.
+var bandLimits = []int{1000, 3000, 10000, 30000}
+
+// levelLimits are the highest frequency ranks estimated at A1 to C1; rarer
+// words are estimated at C2.
+var levelLimits = []int{500, 1000, 2000, 4000, 8000}
+
+// levels are the CEFR levels from easiest to hardest.
+var levels = []string{"A1", "A2", "B1", "B2", "C1", "C2"}
+
+// Lists holds the loaded frequency and CEFR lists, keyed by canonical
+// language code.
+type Lists struct {
+	ranks  map[string]map[string]int
+	levels map[string]map[string]string
+}
+
+// Load reads every frequency list in dir and every CEFR list in cefrDir.
+// Missing directories give empty lists, so ranks and levels are simply
+// left off entries.
+func Load(dir, cefrDir string) (*Lists, error) {
+	l := &Lists{ranks: map[string]map[string]int{}, levels: map[string]map[string]string{}}
+	err := eachList(dir, func(language string, scanner *bufio.Scanner) error {
+		ranks, err := readRanks(scanner)
+		l.ranks[language] = ranks
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = eachList(cefrDir, func(language string, scanner *bufio.Scanner) error {
+		levels, err := readLevels(scanner)
+		l.levels[language] = levels
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// eachList calls read with a line scanner for each <language>.txt in dir.
+func eachList(dir string, read func(language string, scanner *bufio.Scanner) error) error {
+	if dir == "" {
+		return nil
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		err = read(strings.TrimSuffix(filepath.Base(path), ".txt"), bufio.NewScanner(f))
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// fields returns the fields of the scanner's next meaningful line.
+func fields(scanner *bufio.Scanner) ([]string, bool) {
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			return strings.Fields(line), true
+		}
+	}
+	return nil, false
+}
+
+func readRanks(scanner *bufio.Scanner) (map[string]int, error) {
+	ranks := map[string]int{}
+	rank := 0
+	for {
+		f, ok := fields(scanner)
+		if !ok {
+			return ranks, scanner.Err()
+		}
+		word := strings.ToLower(f[0])
+		rank++
+		// A word listed twice (e.g. once per part of speech) keeps its best rank
+		if _, ok := ranks[word]; !ok {
+			ranks[word] = rank
+		}
+	}
+}
+
+func readLevels(scanner *bufio.Scanner) (map[string]string, error) {
+	known := map[string]bool{}
+	for _, level := range levels {
+		known[level] = true
+	}
+	wordLevels := map[string]string{}
+	for {
+		f, ok := fields(scanner)
+		if !ok {
+			return wordLevels, scanner.Err()
+		}
+		if len(f) < 2 || !known[strings.ToUpper(f[len(f)-1])] {
+			continue
+		}
+		// Multi-word entries ("ha lyst") keep every word but the level
+		word := strings.ToLower(strings.Join(f[:len(f)-1], " "))
+		// A word listed at several levels (one per sense) is learned at the first
+		if _, ok := wordLevels[word]; !ok {
+			wordLevels[word] = strings.ToUpper(f[len(f)-1])
+		}
+	}
+}
+
+// Languages returns the codes of the languages with a frequency or CEFR list.
+func (l *Lists) Languages() []string {
+	var languages []string
+	for language := range l.ranks {
+		languages = append(languages, language)
+	}
+	for language := range l.levels {
+		if _, ok := l.ranks[language]; !ok {
+			languages = append(languages, language)
+		}
+	}
+	return languages
+}
+
+// Lookup returns the rank and band of word in a canonical language, and
+// false when the language has no list or the word isn't on it.
+func (l *Lists) Lookup(word, language string) (models.Frequency, bool) {
+	rank, ok := l.ranks[language][strings.ToLower(word)]
+	if !ok {
+		return models.Frequency{}, false
+	}
+	return models.Frequency{Rank: rank, Band: Band(rank)}, true
+}
+
+// Top returns the n most frequent words of a canonical language, most
+// frequent first.
+func (l *Lists) Top(language string, n int) []string {
+	ranks := l.ranks[language]
+	words := make([]string, 0, len(ranks))
+	for word := range ranks {
+		words = append(words, word)
+	}
+	sort.Slice(words, func(i, j int) bool { return ranks[words[i]] < ranks[words[j]] })
+	return words[:min(n, len(words))]
+}
+
+// Band groups a rank into 1 (the 1,000 most frequent words) through 5
+// (beyond the 30,000 most frequent).
+func Band(rank int) int {
+	for i, limit := range bandLimits {
+		if rank <= limit {
+			return i + 1
+		}
+	}
+	return Bands
+}
+
+// Bands is the number of frequency bands: one per limit in bandLimits,
+// and one for the rarer words.
+const Bands = 5
+
+// BandLimit returns the highest rank in a band, or 0 for the last band,
+// which has no limit.
+func BandLimit(band int) int {
+	if band < 1 || band > len(bandLimits) {
+		return 0
+	}
+	return bandLimits[band-1]
+}
+
+// Ranked reports whether a canonical language has a frequency list.
+func (l *Lists) Ranked(language string) bool {
+	return len(l.ranks[language]) > 0
+}
+
+// Level returns the CEFR level of word in a canonical language: from the
+// curated list when the word is on it, otherwise estimated from its
+// frequency rank. It returns false when neither list has the word.
+func (l *Lists) Level(word, language string) (models.CEFRLevel, bool) {
+	word = strings.ToLower(word)
+	if level, ok := l.levels[language][word]; ok {
+		return models.CEFRLevel{Level: level, Basis: models.CEFRBasisList}, true
+	}
+	rank, ok := l.ranks[language][word]
+	if !ok {
+		return models.CEFRLevel{}, false
+	}
+	return models.CEFRLevel{Level: EstimateLevel(rank), Basis: models.CEFRBasisFrequency}, true
+}
+
+// EstimateLevel maps a frequency rank to a CEFR level: the 500 most
+// frequent words are A1, then A2 to 1,000, B1 to 2,000, B2 to 4,000, C1 to
+// 8,000, and C2 beyond.
+func EstimateLevel(rank int) string {
+	for i, limit := range levelLimits {
+		if rank <= limit {
+			return levels[i]
+		}
+	}
+	return levels[len(levels)-1]
+}
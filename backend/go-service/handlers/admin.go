@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/langcode"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/sources"
+
+	"vocabulary-app/backend/go-service/client"
+)
+
+// AdminStatsHandler reports operational statistics for the admin page:
+// word scrapes per language in total and over the last hour and day, the
+// job queue, caches, the outbox, the dead letters and the state of the
+// sources.
+func AdminStatsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	stats := map[string]interface{}{
+		"scrapes": map[string]interface{}{
+			"total":     scrapeMetrics.Scrapes(0),
+			"last_hour": scrapeMetrics.Scrapes(time.Hour),
+			"last_day":  scrapeMetrics.Scrapes(24 * time.Hour),
+		},
+		"jobs":    jobScheduler.Stats(),
+		"budgets": sources.Default.Budgets(),
+		"proxies": sources.Default.Proxies(),
+		"backoff": sources.Default.Backoffs(),
+	}
+	if c, ok := pythonClient.(interface{ BreakerStatus() client.BreakerStatus }); ok {
+		stats["python_service"] = c.BreakerStatus()
+	}
+	if canaryMonitor != nil {
+		canaries := map[string]string{}
+		for _, s := range canaryMonitor.Status() {
+			canaries[s.Language] = s.Status
+		}
+		stats["canaries"] = canaries
+	}
+	if entries, ok := languageRouter.EntryCacheStats(); ok {
+		stats["entry_cache"] = entries
+	}
+	if cacheWarmer != nil {
+		stats["cache_warming"] = cacheWarmer.Status()
+	}
+	if deliverer != nil {
+		if outbox, err := deliverer.Stats(); err == nil {
+			stats["outbox"] = outbox
+		}
+	}
+	if deadLetters != nil {
+		if counts, err := deadLetters.Counts(); err == nil {
+			stats["dead_letters"] = counts
+		}
+	}
+	json.NewEncoder(w).Encode(stats)
+}
+
+// FlagsHandler reports which sources and languages are switched on.
+func FlagsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sources.Default.Flags())
+}
+
+type setFlagRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// SetSourceFlagHandler switches a source (and all its languages) on or off,
+// e.g. when an upstream redesign breaks its scraper.
+func SetSourceFlagHandler(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	setFlag(w, r, "source", name, func(enabled bool) error {
+		return sources.Default.SetSourceEnabled(name, enabled)
+	}, FlagsHandler)
+}
+
+// SetLanguageFlagHandler switches a single language on or off.
+func SetLanguageFlagHandler(w http.ResponseWriter, r *http.Request) {
+	language, ok := langcode.Normalize(r.PathValue("language"))
+	if !ok {
+		httpError(w, r, "Unsupported language: "+r.PathValue("language"), http.StatusNotFound)
+		return
+	}
+	setFlag(w, r, "language", language, func(enabled bool) error {
+		return sources.Default.SetLanguageEnabled(language, enabled)
+	}, FlagsHandler)
+}
+
+// setFlag applies the body's "enabled" with set, audits the change and
+// answers with respond.
+func setFlag(w http.ResponseWriter, r *http.Request, kind, name string, set func(enabled bool) error, respond http.HandlerFunc) {
+	var req setFlagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Enabled == nil {
+		httpError(w, r, `Invalid JSON body: want {"enabled": true|false}`, http.StatusBadRequest)
+		return
+	}
+	if err := set(*req.Enabled); err != nil {
+		httpError(w, r, "Failed to update "+kind+" flag: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	audit(r, "update", kind+"_flag", name, map[string]interface{}{"enabled": *req.Enabled})
+	respond(w, r)
+}
+
+// sourceStatus is a source as SourceStatusHandler reports it.
+type sourceStatus struct {
+	Name      string                 `json:"name"`
+	Host      string                 `json:"host,omitempty"`
+	Version   string                 `json:"version"`
+	Enabled   bool                   `json:"enabled"`
+	Languages []sourceLanguageStatus `json:"languages"`
+	Backoff   sources.BackoffStatus  `json:"backoff"`
+	Budget    sources.BudgetStatus   `json:"budget"`
+}
+
+type sourceLanguageStatus struct {
+	Language string `json:"language"`
+	Enabled  bool   `json:"enabled"`
+	// Canary is the language's last canary result, when canaries run
+	Canary string `json:"canary,omitempty"`
+}
+
+// SourceStatusHandler reports every scraper source: whether it and each of
+// its languages is switched on, its canary results, backoff and budget.
+func SourceStatusHandler(w http.ResponseWriter, r *http.Request) {
+	flags := sources.Default.Flags()
+	canaries := map[string]string{}
+	if canaryMonitor != nil {
+		for _, s := range canaryMonitor.Status() {
+			canaries[s.Language] = s.Status
+		}
+	}
+	// Backoffs and Budgets list the sources in the same order as All
+	backoffs, budgets := sources.Default.Backoffs(), sources.Default.Budgets()
+	statuses := make([]sourceStatus, 0, len(backoffs))
+	for i, p := range sources.Default.All() {
+		status := sourceStatus{
+			Name:    p.Name,
+			Host:    p.Host,
+			Version: p.Version,
+			Enabled: flags.Sources[p.Name],
+			Backoff: backoffs[i],
+			Budget:  budgets[i],
+		}
+		for _, language := range p.Languages {
+			status.Languages = append(status.Languages, sourceLanguageStatus{
+				Language: language,
+				Enabled:  flags.Languages[language],
+				Canary:   canaries[language],
+			})
+		}
+		statuses = append(statuses, status)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"sources": statuses})
+}
+
+// SetSourceStatusHandler switches a source, and with it all its languages,
+// on or off, and answers with the status of every source. The change is
+// kept across restarts.
+func SetSourceStatusHandler(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	setFlag(w, r, "source", name, func(enabled bool) error {
+		return sources.Default.SetSourceEnabled(name, enabled)
+	}, SourceStatusHandler)
+}
+
+// SetSourceLanguageStatusHandler switches one language of a source on or
+// off, like SetSourceStatusHandler.
+func SetSourceLanguageStatusHandler(w http.ResponseWriter, r *http.Request) {
+	language, ok := langcode.Normalize(r.PathValue("language"))
+	if p, found := sources.Default.ForLanguage(language); !ok || !found || p.Name != r.PathValue("name") {
+		httpError(w, r, "Source "+r.PathValue("name")+" has no language "+r.PathValue("language"), http.StatusNotFound)
+		return
+	}
+	setFlag(w, r, "language", language, func(enabled bool) error {
+		return sources.Default.SetLanguageEnabled(language, enabled)
+	}, SourceStatusHandler)
+}
+
+// PurgeCacheHandler drops cached entries so their words are scraped again,
+// e.g. after a parser fix. ?language= limits it to one language, ?word= to
+// one word and ?pattern= to words matching a glob such as "hus*"; without
+// any, the whole cache is dropped. It answers with how many entries went.
+func PurgeCacheHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var language string
+	if raw := query.Get("language"); raw != "" {
+		canonical, ok := langcode.Normalize(raw)
+		if !ok {
+			httpError(w, r, "Unsupported language: "+raw, http.StatusBadRequest)
+			return
+		}
+		language = canonical
+	}
+	word, pattern := query.Get("word"), query.Get("pattern")
+	if _, err := path.Match(pattern, ""); err != nil {
+		httpError(w, r, "Invalid pattern parameter: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	purged, ok := languageRouter.PurgeEntryCache(func(cachedLanguage, cachedWord string) bool {
+		if language != "" && cachedLanguage != language {
+			return false
+		}
+		if word != "" && cachedWord != models.NormalizeWord(cachedLanguage, word) {
+			return false
+		}
+		if pattern != "" {
+			matched, _ := path.Match(models.NormalizeWord(cachedLanguage, pattern), cachedWord)
+			return matched
+		}
+		return true
+	})
+	if !ok {
+		httpError(w, r, "The entry cache is off", http.StatusNotFound)
+		return
+	}
+	audit(r, "purge", "entry_cache", language, map[string]interface{}{"word": word, "pattern": pattern, "purged": purged})
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]int{"purged": purged})
+}
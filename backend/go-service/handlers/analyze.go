@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/langcode"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/sources"
+
+	"vocabulary-app/backend/go-service/config"
+	"vocabulary-app/backend/go-service/documents"
+	"vocabulary-app/backend/go-service/routes"
+)
+
+// maxAnalyzeSize caps the request body of a text analysis.
+const maxAnalyzeSize = 256 << 10
+
+// analyzeConfig bounds text analysis; see ConfigureAnalyze.
+var analyzeConfig = config.AnalyzeConfig{MaxWords: 300, Timeout: 20 * time.Second, MaxUploadBytes: 20 << 20}
+
+// ConfigureAnalyze applies cfg to the text analysis endpoint.
+func ConfigureAnalyze(cfg config.AnalyzeConfig) {
+	analyzeConfig = cfg
+}
+
+type analyzeRequest struct {
+	Text     string `json:"text"`
+	Language string `json:"language"`
+}
+
+// AnalyzeHandler splits a text into words and returns each distinct word in
+// its dictionary form, with the forms the text used and how often. Words
+// past the configured cap, or left when the time runs out, keep the form
+// they were written in and the response is marked partial.
+func AnalyzeHandler(w http.ResponseWriter, r *http.Request) {
+	if analysis, ok := analyzeBody(w, r); ok {
+		writeEncoded(w, r, http.StatusOK, analysis)
+	}
+}
+
+// AnalyzeProfileHandler returns how a text's running words spread over the
+// frequency bands of its language, analyzing it as AnalyzeHandler does.
+func AnalyzeProfileHandler(w http.ResponseWriter, r *http.Request) {
+	if analysis, ok := analyzeBody(w, r); ok {
+		if frequencyLists == nil || !frequencyLists.Ranked(analysis.Language) {
+			httpError(w, r, "No frequency list for "+analysis.Language, http.StatusNotFound)
+			return
+		}
+		writeEncoded(w, r, http.StatusOK, analysis.Profile())
+	}
+}
+
+// analyzeBody analyzes the text of a JSON request body. On failure it
+// writes the error and returns false.
+func analyzeBody(w http.ResponseWriter, r *http.Request) (routes.Analysis, bool) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxAnalyzeSize)
+	var req analyzeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			httpError(w, r, fmt.Sprintf("Text too large: must be at most %d bytes", maxAnalyzeSize), http.StatusRequestEntityTooLarge)
+			return routes.Analysis{}, false
+		}
+		httpError(w, r, "Invalid JSON body: "+err.Error(), http.StatusBadRequest)
+		return routes.Analysis{}, false
+	}
+	if strings.TrimSpace(req.Text) == "" {
+		httpError(w, r, "Missing text", http.StatusBadRequest)
+		return routes.Analysis{}, false
+	}
+	language, ok := langcode.Normalize(req.Language)
+	if !ok {
+		httpError(w, r, "Unsupported language: "+req.Language, http.StatusBadRequest)
+		return routes.Analysis{}, false
+	}
+	if err := sources.Default.CheckEnabled(language); err != nil {
+		httpError(w, r, "Cannot analyze "+language+": "+err.Error(), http.StatusServiceUnavailable)
+		return routes.Analysis{}, false
+	}
+	return analyze(w, r, req.Text, language)
+}
+
+// AnalyzeUploadHandler analyzes the text of an uploaded EPUB e-book or
+// SubRip/WebVTT subtitle file like AnalyzeHandler, with the most used
+// words lemmatized first. The file is the "file" part of a multipart form;
+// the language comes from ?language= or a "language" field.
+func AnalyzeUploadHandler(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, analyzeConfig.MaxUploadBytes)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			httpError(w, r, fmt.Sprintf("File too large: must be at most %d bytes", analyzeConfig.MaxUploadBytes), http.StatusRequestEntityTooLarge)
+			return
+		}
+		httpError(w, r, "Missing file in upload", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		httpError(w, r, "Failed to read upload: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rawLanguage := r.URL.Query().Get("language")
+	if rawLanguage == "" {
+		rawLanguage = r.FormValue("language")
+	}
+	language, ok := langcode.Normalize(rawLanguage)
+	if !ok {
+		httpError(w, r, "Unsupported language: "+rawLanguage, http.StatusBadRequest)
+		return
+	}
+	if err := sources.Default.CheckEnabled(language); err != nil {
+		httpError(w, r, "Cannot analyze "+language+": "+err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	text, err := documents.Text(header.Filename, data)
+	if errors.Is(err, documents.ErrTooLarge) {
+		httpError(w, r, "File too large: "+err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		httpError(w, r, "Invalid file: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(text) == "" {
+		httpError(w, r, "File has no text", http.StatusBadRequest)
+		return
+	}
+	if analysis, ok := analyze(w, r, text, language); ok {
+		writeEncoded(w, r, http.StatusOK, analysis)
+	}
+}
+
+// analyze analyzes text, in a canonical language, within the configured
+// limits. On failure it writes the error and returns false.
+func analyze(w http.ResponseWriter, r *http.Request, text, language string) (routes.Analysis, bool) {
+	ctx, cancel := context.WithTimeout(r.Context(), analyzeConfig.Timeout)
+	defer cancel()
+	analysis, err := languageRouter.Analyze(ctx, text, language, analyzeConfig.MaxWords)
+	if err != nil {
+		httpError(w, r, err.Error(), http.StatusBadRequest)
+		return routes.Analysis{}, false
+	}
+	return analysis, true
+}
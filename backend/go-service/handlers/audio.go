@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"vocabulary-app/backend/go-service/audio"
+	"vocabulary-app/backend/go-service/config"
+)
+
+// audioProxy serves pronunciation recordings; nil when disabled.
+var audioProxy *audio.Proxy
+
+// EnableAudio sets up the audio proxy and, with a Forvo API key, adds Forvo
+// recordings to every scraped entry.
+func EnableAudio(cfg config.AudioConfig) error {
+	if cfg.ProxyEnabled {
+		p, err := audio.NewProxy(cfg)
+		if err != nil {
+			return err
+		}
+		audioProxy = p
+	}
+	if cfg.ForvoAPIKey != "" {
+		languageRouter.SetAudioLookup(audio.NewForvo(cfg.ForvoAPIKey, cfg.Timeout).Lookup)
+	}
+	return nil
+}
+
+// AudioHandler proxies the recording at ?url= from an allowed host, so the
+// browser can play it from our origin.
+func AudioHandler(w http.ResponseWriter, r *http.Request) {
+	if audioProxy == nil {
+		httpError(w, r, "Audio proxy is disabled", http.StatusNotFound)
+		return
+	}
+	rawURL := r.URL.Query().Get("url")
+	if rawURL == "" {
+		httpError(w, r, "Missing url parameter", http.StatusBadRequest)
+		return
+	}
+
+	body, contentType, err := audioProxy.Get(r.Context(), rawURL)
+	switch {
+	case errors.Is(err, audio.ErrNotAllowed):
+		httpError(w, r, err.Error(), http.StatusForbidden)
+		return
+	case errors.Is(err, audio.ErrNotFound):
+		httpError(w, r, "Audio not found", http.StatusNotFound)
+		return
+	case err != nil:
+		slog.WarnContext(r.Context(), "audio proxy failed", "url", rawURL, "error", err)
+		httpError(w, r, "Failed to fetch audio: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	if contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.Header().Set("Cache-Control", "public, max-age=604800, immutable")
+	w.Write(body)
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+
+	"vocabulary-app/backend/go-service/client"
+	"vocabulary-app/backend/go-service/middleware"
+)
+
+// pythonClient is used to reach the Python service; nil until main calls
+// SetPythonClient, and audit events are dropped until then.
+var pythonClient client.PythonClient
+
+// SetPythonClient sets the client handlers use to reach the Python service.
+func SetPythonClient(c client.PythonClient) {
+	pythonClient = c
+}
+
+// audit records an action performed through the Go API, attributed to the
+// authenticated user when there is one.
+func audit(r *http.Request, action, entityType, entityID string, details map[string]interface{}) {
+	if pythonClient == nil {
+		return
+	}
+	event := client.AuditEvent{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Details:    details,
+	}
+	if claims, ok := middleware.ClaimsFromContext(r.Context()); ok {
+		event.UserID = claims.ID
+		event.UserEmail = claims.Email
+	}
+	client.RecordAudit(r.Context(), pythonClient, event)
+}
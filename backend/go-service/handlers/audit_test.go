@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"vocabulary-app/backend/go-service/client"
+	"vocabulary-app/backend/go-service/deadletter"
+	"vocabulary-app/backend/go-service/storage"
+)
+
+func TestDeleteDeadLetterAudits(t *testing.T) {
+	db, err := storage.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	store, err := deadletter.NewStore(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	letter, err := store.Add(deadletter.Letter{Kind: deadletter.KindDelivery, Payload: []byte(`{}`), Error: "boom"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock := &client.MockPythonClient{}
+	prevLetters, prevClient := deadLetters, pythonClient
+	deadLetters, pythonClient = store, mock
+	t.Cleanup(func() { deadLetters, pythonClient = prevLetters, prevClient })
+
+	id := strconv.FormatUint(letter.ID, 10)
+	req := httptest.NewRequest(http.MethodDelete, "/api/admin/dead-letters/"+id, nil)
+	req.SetPathValue("id", id)
+	rec := httptest.NewRecorder()
+	DeleteDeadLetterHandler(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusNoContent, rec.Body)
+	}
+
+	// Audit events are sent in the background
+	deadline := time.Now().Add(2 * time.Second)
+	var events []client.AuditEvent
+	for len(events) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+		events = mock.AuditEvents()
+	}
+	if len(events) != 1 {
+		t.Fatalf("recorded %d audit events, want 1", len(events))
+	}
+	got := events[0]
+	if got.Action != "delete" || got.EntityType != "dead_letter" || got.EntityID != id || got.Service != "go" {
+		t.Errorf("audit event = %+v, want delete of dead_letter %s from go", got, id)
+	}
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"vocabulary-app/backend/go-service/canary"
+	"vocabulary-app/backend/go-service/config"
+)
+
+// canaryMonitor checks scraper selectors with known words; nil when disabled.
+var canaryMonitor *canary.Monitor
+
+// EnableCanaries starts periodic canary scrapes until ctx is cancelled.
+// Canary entries are only checked, never delivered.
+func EnableCanaries(ctx context.Context, cfg config.CanaryConfig) error {
+	m, err := canary.NewMonitor(languageRouter.ScrapeWordByLanguage, cfg.Words, cfg.Interval)
+	if err != nil {
+		return err
+	}
+	canaryMonitor = m
+	go m.Run(ctx)
+	return nil
+}
+
+// CanaryStatusHandler reports the latest canary result per language.
+func CanaryStatusHandler(w http.ResponseWriter, r *http.Request) {
+	if canaryMonitor == nil {
+		httpError(w, r, "Canary checks are disabled", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"languages": canaryMonitor.Status(),
+	})
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"vocabulary-app/backend/go-service/deadletter"
+	"vocabulary-app/backend/go-service/jobs"
+)
+
+// deadLetters keeps work that failed for good; nil until EnableDeadLetters
+// is called.
+var deadLetters *deadletter.Store
+
+// maxDeadLetters caps ?limit= when listing dead letters.
+const maxDeadLetters = 1000
+
+// EnableDeadLetters keeps failed jobs, and the deliveries and webhook
+// notifications enabled after it, in store. Call it before SetDeliverer
+// and EnableWebhooks.
+func EnableDeadLetters(store *deadletter.Store) {
+	deadLetters = store
+	store.Handle(deadletter.KindJob, redriveJob)
+	jobScheduler.OnFinish(deadLetterJob)
+}
+
+// failedJob is the payload of a job dead letter: what it takes to submit
+// the job's failed words again.
+type failedJob struct {
+	Kind     jobs.Kind `json:"kind"`
+	Language string    `json:"language"`
+	Words    []string  `json:"words"`
+	OwnerID  int       `json:"owner_id,omitempty"`
+	Strict   bool      `json:"strict,omitempty"`
+}
+
+// deadLetterJob keeps the failed words of a failed job.
+func deadLetterJob(job *jobs.Job) {
+	if job.Status != jobs.StatusFailed {
+		return
+	}
+	failed := failedJob{Kind: job.Kind, Language: job.Language, OwnerID: job.OwnerID, Strict: job.Strict}
+	for _, result := range job.Results {
+		if result.Error != "" {
+			failed.Words = append(failed.Words, result.Word)
+		}
+	}
+	payload, err := json.Marshal(failed)
+	if err == nil {
+		letter := deadletter.Letter{
+			Kind:    deadletter.KindJob,
+			Payload: payload,
+			Context: map[string]string{
+				"job_id":   job.ID,
+				"language": job.Language,
+				"words":    strconv.Itoa(len(failed.Words)),
+			},
+			Error:    job.Error,
+			Attempts: 1,
+			FirstTry: job.CreatedAt,
+		}
+		_, err = deadLetters.Add(letter)
+	}
+	if err != nil {
+		slog.Error("saving failed job to the dead letters failed", "job_id", job.ID, "error", err)
+	}
+}
+
+// redriveJob submits the failed words of a job dead letter as a new job.
+func redriveJob(ctx context.Context, letter deadletter.Letter) error {
+	var failed failedJob
+	if err := json.Unmarshal(letter.Payload, &failed); err != nil {
+		return err
+	}
+	job, err := jobScheduler.Submit(failed.Kind, failed.Language, failed.Words, failed.OwnerID, failed.Strict)
+	if err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "failed job redriven", "job_id", letter.Context["job_id"], "new_job_id", job.ID, "words", len(failed.Words))
+	return nil
+}
+
+// DeadLettersHandler lists dead letters, oldest first, optionally of one
+// ?kind= only, up to ?limit= (default 100).
+func DeadLettersHandler(w http.ResponseWriter, r *http.Request) {
+	limit := 100
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxDeadLetters {
+			httpError(w, r, "Invalid limit parameter: must be between 1 and "+strconv.Itoa(maxDeadLetters), http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+	letters, err := deadLetters.List(r.URL.Query().Get("kind"), limit)
+	if err != nil {
+		httpError(w, r, "Failed to list dead letters: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeEncoded(w, r, http.StatusOK, map[string]interface{}{"dead_letters": letters})
+}
+
+// DeadLetterHandler returns one dead letter with its payload.
+func DeadLetterHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := deadLetterID(w, r)
+	if !ok {
+		return
+	}
+	letter, err := deadLetters.Get(id)
+	if err != nil {
+		deadLetterError(w, r, "load", err)
+		return
+	}
+	writeEncoded(w, r, http.StatusOK, letter)
+}
+
+// RedriveDeadLetterHandler tries a dead letter's work again. The letter is
+// removed when that succeeds; otherwise it stays, with the new error, and
+// the response is a 502.
+func RedriveDeadLetterHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := deadLetterID(w, r)
+	if !ok {
+		return
+	}
+	if err := deadLetters.Redrive(r.Context(), id); err != nil {
+		deadLetterError(w, r, "redrive", err)
+		return
+	}
+	audit(r, "redrive", "dead_letter", r.PathValue("id"), nil)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RedriveDeadLettersHandler tries the work of every dead letter again, or
+// of those of one ?kind=, e.g. once the Python service is back. It reports
+// how many were redriven and how many failed again.
+func RedriveDeadLettersHandler(w http.ResponseWriter, r *http.Request) {
+	kind := r.URL.Query().Get("kind")
+	letters, err := deadLetters.List(kind, maxDeadLetters)
+	if err != nil {
+		httpError(w, r, "Failed to list dead letters: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	redriven, failed := 0, 0
+	for _, letter := range letters {
+		if err := deadLetters.Redrive(r.Context(), letter.ID); err != nil {
+			failed++
+			continue
+		}
+		redriven++
+	}
+	audit(r, "redrive", "dead_letter", kind, map[string]interface{}{"redriven": redriven, "failed": failed})
+	writeEncoded(w, r, http.StatusOK, map[string]int{"redriven": redriven, "failed": failed})
+}
+
+// DeleteDeadLetterHandler discards a dead letter without redriving it.
+func DeleteDeadLetterHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := deadLetterID(w, r)
+	if !ok {
+		return
+	}
+	if err := deadLetters.Remove(id); err != nil {
+		deadLetterError(w, r, "delete", err)
+		return
+	}
+	audit(r, "delete", "dead_letter", r.PathValue("id"), nil)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// deadLetterID reads the {id} path value, writing a 404 and returning false
+// when it isn't a letter ID.
+func deadLetterID(w http.ResponseWriter, r *http.Request) (uint64, bool) {
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		httpError(w, r, "Dead letter not found", http.StatusNotFound)
+		return 0, false
+	}
+	return id, true
+}
+
+func deadLetterError(w http.ResponseWriter, r *http.Request, action string, err error) {
+	switch {
+	case errors.Is(err, deadletter.ErrNotFound):
+		httpError(w, r, "Dead letter not found", http.StatusNotFound)
+	case errors.Is(err, deadletter.ErrNoRedrive):
+		httpError(w, r, "Failed to "+action+" dead letter: "+err.Error(), http.StatusConflict)
+	case action == "redrive":
+		httpError(w, r, "Redrive failed: "+err.Error(), http.StatusBadGateway)
+	default:
+		httpError(w, r, "Failed to "+action+" dead letter: "+err.Error(), http.StatusInternalServerError)
+	}
+}
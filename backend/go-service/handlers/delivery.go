@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+
+	"vocabulary-app/backend/go-service/deadletter"
+	"vocabulary-app/backend/go-service/delivery"
+	"vocabulary-app/backend/go-service/queue"
+)
+
+// deliverer forwards scraped entries to the Python service; nil when delivery is disabled.
+var deliverer *delivery.Deliverer
+
+// SetDeliverer enables delivery of scraped entries through d, and the
+// redriving of deliveries that ran out of retries.
+func SetDeliverer(d *delivery.Deliverer) {
+	deliverer = d
+	deadLetters.Handle(deadletter.KindDelivery, d.Redrive)
+}
+
+// publisher publishes scraped entries to the message queue; nil when disabled.
+var publisher *queue.Publisher
+
+// SetPublisher enables publishing of scraped entries through p.
+func SetPublisher(p *queue.Publisher) {
+	publisher = p
+}
+
+// deliver hands a successfully scraped entry to the deliverer and the
+// message queue, whichever are enabled.
+func deliver(ctx context.Context, entry models.WordEntry) {
+	if deliverer != nil {
+		deliverer.Deliver(ctx, entry)
+	}
+	if publisher != nil {
+		ctx = context.WithoutCancel(ctx)
+		go func() {
+			if err := publisher.Publish(ctx, entry); err != nil {
+				slog.WarnContext(ctx, "publishing entry to the queue failed", "word", entry.Word, "language", entry.Language, "error", err)
+			}
+		}()
+	}
+}
+
+// scrapeAndDeliver is the scrape function used by background jobs. In
+// strict mode an incomplete entry fails and isn't delivered.
+func scrapeAndDeliver(word, language string, strict bool) (models.WordEntry, error) {
+	entry, err := languageRouter.ScrapeWordByLanguage(context.Background(), word, language)
+	if strict && err == nil {
+		err = entry.CheckComplete()
+	}
+	emitScrapeEvent(context.Background(), word, language, entry, err)
+	if err == nil {
+		deliver(context.Background(), entry)
+	}
+	return entry, err
+}
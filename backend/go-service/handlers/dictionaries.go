@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/langcode"
+
+	"vocabulary-app/backend/go-service/config"
+	"vocabulary-app/backend/go-service/dictionaries"
+)
+
+// dictionaryStore holds the imported offline dictionaries; nil until
+// EnableDictionaries is called.
+var dictionaryStore *dictionaries.Store
+
+// maxDictionaryUpload caps an uploaded dictionary file.
+var maxDictionaryUpload int64 = 256 << 20
+
+// EnableDictionaries serves words from the offline dictionaries in store:
+// first for the languages cfg prefers, and for the others when a scrape
+// fails or finds nothing.
+func EnableDictionaries(store *dictionaries.Store, cfg config.DictionariesConfig) error {
+	var preferred []string
+	for _, code := range cfg.Prefer {
+		language, ok := langcode.Normalize(code)
+		if !ok {
+			return fmt.Errorf("unsupported language in DICTIONARIES_PREFER: %s", code)
+		}
+		preferred = append(preferred, language)
+	}
+	dictionaryStore = store
+	maxDictionaryUpload = cfg.MaxUploadBytes
+	languageRouter.SetOfflineLookup(store.Lookup, preferred)
+	return nil
+}
+
+// DictionariesHandler lists the imported offline dictionaries.
+func DictionariesHandler(w http.ResponseWriter, r *http.Request) {
+	infos, err := dictionaryStore.List()
+	if err != nil {
+		httpError(w, r, "Failed to list dictionaries: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeEncoded(w, r, http.StatusOK, map[string]interface{}{"dictionaries": infos})
+}
+
+// ImportDictionaryHandler imports an uploaded StarDict or FreeDict
+// dictionary for a language, replacing an earlier import of the same name.
+// The file is the "file" part of a multipart form; optional "name" and
+// "license" fields override what the dictionary says about itself.
+func ImportDictionaryHandler(w http.ResponseWriter, r *http.Request) {
+	language, ok := langcode.Normalize(r.PathValue("language"))
+	if !ok {
+		httpError(w, r, "Unsupported language: "+r.PathValue("language"), http.StatusNotFound)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxDictionaryUpload)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			httpError(w, r, fmt.Sprintf("Dictionary too large: must be at most %d bytes", maxDictionaryUpload), http.StatusRequestEntityTooLarge)
+			return
+		}
+		httpError(w, r, "Missing file in upload", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		httpError(w, r, "Failed to read upload: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dictionary, err := dictionaries.Read(header.Filename, data)
+	if err != nil {
+		httpError(w, r, "Invalid dictionary: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if name := strings.TrimSpace(r.FormValue("name")); name != "" {
+		dictionary.Name = name
+	}
+	if license := strings.TrimSpace(r.FormValue("license")); license != "" {
+		dictionary.License = license
+	}
+
+	info, err := dictionaryStore.Put(language, dictionary)
+	if err != nil {
+		httpError(w, r, "Failed to store dictionary: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	slog.InfoContext(r.Context(), "dictionary imported", "name", info.Name, "language", language, "format", info.Format, "entries", info.Entries)
+	audit(r, "import", "dictionary", language+":"+info.Name, map[string]interface{}{
+		"format":  info.Format,
+		"entries": info.Entries,
+	})
+	writeEncoded(w, r, http.StatusCreated, info)
+}
+
+// DeleteDictionaryHandler removes an imported dictionary.
+func DeleteDictionaryHandler(w http.ResponseWriter, r *http.Request) {
+	language, ok := langcode.Normalize(r.PathValue("language"))
+	if !ok {
+		httpError(w, r, "Unsupported language: "+r.PathValue("language"), http.StatusNotFound)
+		return
+	}
+	name := r.PathValue("name")
+	if err := dictionaryStore.Delete(language, name); err != nil {
+		if errors.Is(err, dictionaries.ErrNotFound) {
+			httpError(w, r, "Dictionary not found", http.StatusNotFound)
+			return
+		}
+		httpError(w, r, "Failed to delete dictionary: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	audit(r, "delete", "dictionary", language+":"+name, nil)
+	w.WriteHeader(http.StatusNoContent)
+}
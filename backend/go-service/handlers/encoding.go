@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/vmihailenco/msgpack/v5"
+
+	"vocabulary-app/backend/go-service/format"
+)
+
+const (
+	contentTypeJSON    = "application/json"
+	contentTypeMsgPack = "application/msgpack"
+	contentTypeYAML    = "application/yaml"
+	contentTypeNDJSON  = "application/x-ndjson"
+)
+
+// negotiateEncoding picks the response encoding from the Accept header.
+// MessagePack and YAML are used only when the client explicitly asks for
+// them; anything else, including a missing header or */*, gets JSON.
+func negotiateEncoding(r *http.Request) string {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || params["q"] == "0" {
+			continue
+		}
+		switch mediaType {
+		case contentTypeMsgPack, "application/x-msgpack", "application/vnd.msgpack":
+			return contentTypeMsgPack
+		case contentTypeYAML, "application/x-yaml", "text/yaml":
+			return contentTypeYAML
+		case contentTypeJSON:
+			return contentTypeJSON
+		}
+	}
+	return contentTypeJSON
+}
+
+// wantsStream reports whether the Accept header asks for NDJSON, which
+// endpoints returning many entries stream as they are scraped.
+func wantsStream(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err == nil && params["q"] != "0" && mediaType == contentTypeNDJSON {
+			return true
+		}
+	}
+	return false
+}
+
+// writeEncoded writes v with the given status, encoded as JSON, MessagePack
+// or YAML depending on the request's Accept header. MessagePack and YAML
+// output use the json field names, so all encodings have the same fields.
+func writeEncoded(w http.ResponseWriter, r *http.Request, status int, v interface{}) {
+	w.Header().Add("Vary", "Accept")
+
+	switch negotiateEncoding(r) {
+	case contentTypeYAML:
+		data, err := format.MarshalYAML(v)
+		if err != nil {
+			httpError(w, r, "Failed to encode response: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", contentTypeYAML)
+		w.WriteHeader(status)
+		w.Write(data)
+		return
+	case contentTypeJSON:
+		w.Header().Set("Content-Type", contentTypeJSON)
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(v)
+		return
+	}
+
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	enc.SetOmitEmpty(true)
+	if err := enc.Encode(v); err != nil {
+		httpError(w, r, "Failed to encode response: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentTypeMsgPack)
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+}
@@ -0,0 +1,16 @@
+package handlers
+
+import (
+	"net/http"
+
+	"vocabulary-app/backend/go-service/requestid"
+)
+
+// httpError writes a plain-text error like http.Error, tagged with the
+// request ID so users can quote it when reporting problems.
+func httpError(w http.ResponseWriter, r *http.Request, msg string, code int) {
+	if id := requestid.FromContext(r.Context()); id != "" {
+		msg += " (request_id: " + id + ")"
+	}
+	http.Error(w, msg, code)
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/langcode"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+
+	"vocabulary-app/backend/go-service/events"
+)
+
+// emitter publishes dictionary events to Kafka; nil when disabled.
+var emitter *events.Emitter
+
+// SetEmitter enables dictionary events through e.
+func SetEmitter(e *events.Emitter) {
+	emitter = e
+}
+
+// emitScrapeEvent reports the outcome of a scrape as a dictionary event.
+func emitScrapeEvent(ctx context.Context, word, language string, entry models.WordEntry, err error) {
+	if emitter == nil {
+		return
+	}
+	if err != nil {
+		if canonical, ok := langcode.Normalize(language); ok {
+			language = canonical
+		}
+		emitter.Failed(ctx, word, language, err)
+		return
+	}
+	emitter.Scraped(ctx, entry)
+}
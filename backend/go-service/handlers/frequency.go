@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"log/slog"
+
+	"vocabulary-app/backend/go-service/config"
+	"vocabulary-app/backend/go-service/frequency"
+)
+
+// frequencyLists holds the loaded frequency and CEFR lists; nil until
+// EnableFrequency finds some.
+var frequencyLists *frequency.Lists
+
+// EnableFrequency loads the frequency and CEFR lists in cfg and adds ranks
+// and levels to every scraped entry whose language has a list.
+func EnableFrequency(cfg config.FrequencyConfig) error {
+	lists, err := frequency.Load(cfg.Dir, cfg.CEFRDir)
+	if err != nil {
+		return err
+	}
+	languages := lists.Languages()
+	if len(languages) == 0 {
+		slog.Info("no frequency or CEFR lists found, entries will not be ranked", "dir", cfg.Dir, "cefr_dir", cfg.CEFRDir)
+		return nil
+	}
+	slog.Info("frequency lists loaded", "dir", cfg.Dir, "cefr_dir", cfg.CEFRDir, "languages", languages)
+	frequencyLists = lists
+	languageRouter.SetFrequencyLookup(lists.Lookup)
+	languageRouter.SetLevelLookup(lists.Level)
+	return nil
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"vocabulary-app/backend/go-service/health"
+)
+
+// liveness and readiness run the /healthz and /readyz checks; nil until
+// SetHealthCheckers is called, in which case the probes report ok.
+var liveness, readiness *health.Checker
+
+// SetHealthCheckers sets the checks run by the probes.
+func SetHealthCheckers(live, ready *health.Checker) {
+	liveness, readiness = live, ready
+}
+
+// HealthzHandler reports whether the service itself is healthy: its local
+// database and Chrome.
+func HealthzHandler(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, r, liveness)
+}
+
+// ReadyzHandler reports whether the service can do useful work, which also
+// requires the Python service (and its database) to be reachable.
+func ReadyzHandler(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, r, readiness)
+}
+
+func writeHealth(w http.ResponseWriter, r *http.Request, checker *health.Checker) {
+	report := health.Report{Status: health.StatusOK, Checks: map[string]health.Result{}}
+	if checker != nil {
+		report = checker.Run(r.Context())
+	}
+
+	status := http.StatusOK
+	if report.Status == health.StatusUnavailable {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"vocabulary-app/backend/go-service/jobs"
+	"vocabulary-app/backend/go-service/wordlist"
+)
+
+// maxImportSize caps an uploaded word list.
+const maxImportSize = 1 << 20
+
+// ImportJobHandler queues a batch job scraping every word in an uploaded
+// word list. The list is sent either as a multipart form with a "file" part
+// (and optionally a "language" field), or as the raw request body. CSV lists
+// (text/csv, or a .csv file name) take words from the "word" column, or the
+// first column if there is no such header; anything else is read as plain
+// text with one word per line, skipping blank lines and # comments. The
+// language comes from ?language= or the form. Per-word results are reported
+// on the job like any other batch, as with ?wait=; ?strict=true fails words
+// whose entries are incomplete.
+func ImportJobHandler(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	language := r.URL.Query().Get("language")
+	strict, ok := parseBool(w, r, "strict")
+	if !ok {
+		return
+	}
+
+	var list io.Reader = r.Body
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		if err := r.ParseMultipartForm(maxImportSize); err != nil {
+			httpError(w, r, "Invalid upload: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			httpError(w, r, "Missing file in upload", http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		list = file
+		mediaType = header.Header.Get("Content-Type")
+		if strings.EqualFold(path.Ext(header.Filename), ".csv") {
+			mediaType = "text/csv"
+		}
+		if language == "" {
+			language = r.FormValue("language")
+		}
+	}
+
+	words, err := wordlist.Read(list, strings.HasPrefix(mediaType, "text/csv"))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			httpError(w, r, fmt.Sprintf("Word list too large: must be at most %d bytes", maxImportSize), http.StatusRequestEntityTooLarge)
+			return
+		}
+		httpError(w, r, "Invalid word list: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	queueJob(w, r, jobs.KindBatch, language, words, strict)
+}
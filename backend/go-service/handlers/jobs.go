@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/langcode"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/sources"
+	bolt "go.etcd.io/bbolt"
+
+	"vocabulary-app/backend/go-service/config"
+	"vocabulary-app/backend/go-service/jobs"
+	"vocabulary-app/backend/go-service/middleware"
+)
+
+var jobScheduler = jobs.NewScheduler(scrapeAndDeliver, sources.Default)
+
+// StartJobScheduler runs the background job scheduler until ctx is cancelled.
+func StartJobScheduler(ctx context.Context) {
+	go jobScheduler.Run(ctx)
+}
+
+// PersistJobs keeps jobs in db, so queued and running jobs resume after a
+// restart. Call it before StartJobScheduler.
+func PersistJobs(db *bolt.DB) error {
+	return jobScheduler.Persist(db)
+}
+
+// ConfigureJobs sets how many words of a job are scraped at once, per
+// language, how many a job may have and how long finished jobs are kept.
+// Call it before PersistJobs.
+func ConfigureJobs(cfg config.JobsConfig) error {
+	perLanguage, err := jobs.ParseConcurrency(cfg.LanguageConcurrency)
+	if err != nil {
+		return err
+	}
+	jobScheduler.SetConcurrency(cfg.Concurrency, perLanguage)
+	jobScheduler.SetLimits(cfg.MaxWords, cfg.Retention)
+	return nil
+}
+
+// maxJobWait caps the ?wait= long-poll on job creation.
+var maxJobWait = 30 * time.Second
+
+// SetMaxJobWait sets the cap on the ?wait= long-poll.
+func SetMaxJobWait(d time.Duration) {
+	maxJobWait = d
+}
+
+// writeTimeout is the server's write timeout, which the ?wait= long-poll
+// and streamed jobs extend as they go; 0 when the server has none.
+var writeTimeout time.Duration
+
+// SetWriteTimeout tells the job handlers the server's write timeout.
+func SetWriteTimeout(d time.Duration) {
+	writeTimeout = d
+}
+
+// extendWriteDeadline gives the response another write timeout, after
+// extra, to be written.
+func extendWriteDeadline(rc *http.ResponseController, extra time.Duration) {
+	if writeTimeout > 0 {
+		rc.SetWriteDeadline(time.Now().Add(extra + writeTimeout))
+	}
+}
+
+type createJobRequest struct {
+	Kind     jobs.Kind `json:"kind"`
+	Language string    `json:"language"`
+	Words    []string  `json:"words"`
+	Strict   bool      `json:"strict"`
+}
+
+// CreateJobHandler queues a batch scrape job and returns it with 202 Accepted.
+// With ?wait=15s it holds the request until the job finishes, up to that long,
+// and returns the finished job with 200 OK; if the job is still pending when
+// the wait runs out the response is the usual 202 with a Location to poll.
+// With Accept: application/x-ndjson the results are streamed instead, as
+// streamJob describes.
+func CreateJobHandler(w http.ResponseWriter, r *http.Request) {
+	var req createJobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpError(w, r, "Invalid JSON body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.Kind == "" {
+		req.Kind = jobs.KindBatch
+	}
+	if req.Kind != jobs.KindBatch && req.Kind != jobs.KindWarm {
+		httpError(w, r, "Unsupported job kind: "+string(req.Kind), http.StatusBadRequest)
+		return
+	}
+
+	queueJob(w, r, req.Kind, req.Language, req.Words, req.Strict)
+}
+
+// queueJob submits a job for words in the given language on behalf of the
+// caller and writes the response, honoring ?wait= as CreateJobHandler
+// describes.
+func queueJob(w http.ResponseWriter, r *http.Request, kind jobs.Kind, rawLanguage string, rawWords []string, strict bool) {
+	var wait time.Duration
+	if raw := r.URL.Query().Get("wait"); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil || d < 0 {
+			httpError(w, r, "Invalid wait parameter: must be a duration like 15s", http.StatusBadRequest)
+			return
+		}
+		wait = min(d, maxJobWait)
+	}
+
+	language, ok := langcode.Normalize(rawLanguage)
+	if !ok {
+		httpError(w, r, "Unsupported language: "+rawLanguage, http.StatusBadRequest)
+		return
+	}
+	if err := sources.Default.CheckEnabled(language); err != nil {
+		httpError(w, r, "Cannot scrape "+language+": "+err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	var words []string
+	for _, word := range rawWords {
+		if word = strings.TrimSpace(word); word == "" {
+			continue
+		}
+		if err := models.CheckWord(word); err != nil {
+			httpError(w, r, fmt.Sprintf("Invalid word %q: %s", word, err), http.StatusBadRequest)
+			return
+		}
+		words = append(words, word)
+	}
+
+	var owner int
+	if claims, ok := middleware.ClaimsFromContext(r.Context()); ok {
+		owner = claims.ID
+	}
+
+	if wantsStream(r) {
+		streamJob(w, r, kind, language, words, owner, strict)
+		return
+	}
+
+	job, err := jobScheduler.Submit(kind, language, words, owner, strict)
+	if err != nil {
+		httpError(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
+	auditJob(r, job)
+
+	if wait > 0 {
+		extendWriteDeadline(http.NewResponseController(w), wait)
+		ctx, cancel := context.WithTimeout(r.Context(), wait)
+		job, _ = jobScheduler.Wait(ctx, job.ID)
+		cancel()
+		if job.Finished() {
+			writeEncoded(w, r, http.StatusOK, job)
+			return
+		}
+	}
+
+	w.Header().Set("Location", "/api/jobs/"+job.ID)
+	writeEncoded(w, r, http.StatusAccepted, job)
+}
+
+// streamJob submits a job and writes its results as NDJSON, one line per
+// word in word order, as they are scraped, so a large batch's entries are
+// never all held in memory. The response ends when the job finishes; its
+// Location has the job's final status, whose results then carry only the
+// words and errors. Each result gets a write timeout of its own, as a job
+// can take far longer than one.
+func streamJob(w http.ResponseWriter, r *http.Request, kind jobs.Kind, language string, words []string, owner int, strict bool) {
+	rc := http.NewResponseController(w)
+	encoder := json.NewEncoder(w)
+	// Results wait until the headers are out, and are dropped once the
+	// handler has returned or the client has gone
+	var mu sync.Mutex
+	open := true
+	write := func(result jobs.Result) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !open {
+			return
+		}
+		extendWriteDeadline(rc, 0)
+		if err := encoder.Encode(result); err != nil {
+			open = false
+			return
+		}
+		rc.Flush()
+	}
+
+	mu.Lock()
+	job, err := jobScheduler.SubmitStreaming(kind, language, words, owner, strict, write)
+	if err != nil {
+		open = false
+		mu.Unlock()
+		httpError(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
+	auditJob(r, job)
+	w.Header().Set("Content-Type", contentTypeNDJSON)
+	w.Header().Set("Location", "/api/jobs/"+job.ID)
+	w.WriteHeader(http.StatusOK)
+	extendWriteDeadline(rc, 0)
+	rc.Flush()
+	mu.Unlock()
+
+	jobScheduler.Wait(r.Context(), job.ID)
+	mu.Lock()
+	open = false
+	mu.Unlock()
+	extendWriteDeadline(rc, 0)
+}
+
+func auditJob(r *http.Request, job *jobs.Job) {
+	audit(r, "create", "job", job.ID, map[string]interface{}{
+		"kind":     job.Kind,
+		"language": job.Language,
+		"words":    len(job.Words),
+		"strict":   job.Strict,
+	})
+}
+
+// GetJobHandler returns the current state of a job. Only the user who
+// submitted it and admins can see a job; anonymous jobs are seen only by
+// anonymous callers. Anyone else gets a 404, as for a job that doesn't
+// exist, so job IDs can't be probed.
+func GetJobHandler(w http.ResponseWriter, r *http.Request) {
+	job, ok := jobScheduler.Get(r.PathValue("id"))
+	if !ok || !canSeeJob(r, job) {
+		httpError(w, r, "Job not found", http.StatusNotFound)
+		return
+	}
+
+	writeEncoded(w, r, http.StatusOK, job)
+}
+
+// canSeeJob reports whether the caller submitted job or is an admin.
+func canSeeJob(r *http.Request, job *jobs.Job) bool {
+	claims, ok := middleware.ClaimsFromContext(r.Context())
+	if !ok {
+		return job.OwnerID == 0
+	}
+	return claims.ID == job.OwnerID || claims.Role == middleware.RoleAdmin
+}
+
+// SourcesHandler lists the configured source profiles.
+func SourcesHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"sources": sources.Default.All(),
+	})
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/scrapers/wordforms"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/sources"
+
+	"vocabulary-app/backend/go-service/metrics"
+)
+
+// scrapeMetrics times the stages of every scrape, per language.
+var scrapeMetrics = metrics.NewRecorder()
+
+// EnableMetrics records the duration and outcome of every scrape stage.
+func EnableMetrics() {
+	sources.Default.SetRecorder(scrapeMetrics)
+}
+
+// MetricsHandler reports latency percentiles and error rates per language
+// and scrape stage.
+func MetricsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"stages": scrapeMetrics.Stages(),
+	})
+}
+
+// UnrecognizedLabelsHandler lists the inflection row labels scrapers found
+// no metadata in, most frequent first, so they can be mapped with
+// SCRAPER_LABEL_MAPPINGS.
+func UnrecognizedLabelsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"unrecognized": wordforms.Unrecognized(),
+	})
+}
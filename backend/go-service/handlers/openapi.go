@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+	"sync"
+
+	"vocabulary-app/backend/go-service/openapi"
+)
+
+// openAPISpec builds the OpenAPI document once, on first request.
+var openAPISpec = sync.OnceValues(openapi.Spec)
+
+// OpenAPIHandler serves the OpenAPI description of the API.
+func OpenAPIHandler(w http.ResponseWriter, r *http.Request) {
+	spec, err := openAPISpec()
+	if err != nil {
+		httpError(w, r, "Failed to build the OpenAPI document: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(spec)
+}
+
+// swaggerUIPage loads Swagger UI from a CDN and points it at
+// /api/openapi.json.
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Vocabulary App Go Service API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5.17.14/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5.17.14/swagger-ui-bundle.js"></script>
+  <script>
+    SwaggerUIBundle({url: "/api/openapi.json", dom_id: "#swagger-ui"});
+  </script>
+</body>
+</html>
+`
+
+// APIDocsHandler serves Swagger UI for browsing and trying out the API.
+func APIDocsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write([]byte(swaggerUIPage))
+}
@@ -1,9 +1,18 @@
 package handlers
 
 import (
+    "context"
     "encoding/json"
+    "errors"
     "net/http"
+    "strconv"
+    "strings"
 
+    "github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+    "github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/sources"
+
+    "vocabulary-app/backend/go-service/cache"
+    "vocabulary-app/backend/go-service/config"
     "vocabulary-app/backend/go-service/routes"
 )
 
@@ -12,24 +21,221 @@ var languageRouter = routes.NewLanguageRouter()
 func ScrapeHandler(w http.ResponseWriter, r *http.Request) {
     word := r.URL.Query().Get("word")
     if word == "" {
-        http.Error(w, "Missing word parameter", http.StatusBadRequest)
+        httpError(w, r, "Missing word parameter", http.StatusBadRequest)
+        return
+    }
+    if err := models.CheckWord(word); err != nil {
+        httpError(w, r, "Invalid word parameter: "+err.Error(), http.StatusBadRequest)
+        return
+    }
+
+    // view=full (default) returns the nested WordEntry, view=compact a flat card shape
+    view := r.URL.Query().Get("view")
+    if view != "" && view != "full" && view != "compact" {
+        httpError(w, r, "Invalid view parameter: must be 'full' or 'compact'", http.StatusBadRequest)
+        return
+    }
+    dryRun, ok := parseBool(w, r, "dry_run")
+    if !ok {
+        return
+    }
+    strict, ok := parseBool(w, r, "strict")
+    if !ok {
+        return
+    }
+    limits, ok := parseLimits(w, r)
+    if !ok {
         return
     }
 
-    // Get language parameter (defaults to Norwegian Bokmål if not specified)
+    // Without a language, detect it: from the word's letters, then by trying
+    // ?languages= (the user's configured languages) or the configured defaults
     language := r.URL.Query().Get("language")
+    var entry models.WordEntry
+    var err error
     if language == "" {
-        language = "no-bm" // default to Norwegian Bokmål for backwards compatibility
+        entry, err = languageRouter.ScrapeWordDetectingLanguage(r.Context(), word, splitList(r.URL.Query().Get("languages")))
+        language = entry.Language
+        if language == "" {
+            language = "auto"
+        }
+    } else {
+        entry, err = languageRouter.ScrapeWordByLanguage(r.Context(), word, language)
     }
+    respondScrape(w, r, word, language, view, dryRun, strict, limits, entry, err)
+}
 
-    entry, err := languageRouter.ScrapeWordByLanguage(word, language)
+// ScrapeURLHandler scrapes the dictionary article at the body's "url",
+// for homographs and other words whose lookup finds the wrong article.
+// ?view=, ?dry_run=, ?strict= and the limits work as for ScrapeHandler.
+func ScrapeURLHandler(w http.ResponseWriter, r *http.Request) {
+    var req struct {
+        URL string `json:"url"`
+    }
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        httpError(w, r, "Invalid JSON body: "+err.Error(), http.StatusBadRequest)
+        return
+    }
+    if req.URL == "" {
+        httpError(w, r, "Missing url", http.StatusBadRequest)
+        return
+    }
+    view := r.URL.Query().Get("view")
+    if view != "" && view != "full" && view != "compact" {
+        httpError(w, r, "Invalid view parameter: must be 'full' or 'compact'", http.StatusBadRequest)
+        return
+    }
+    dryRun, ok := parseBool(w, r, "dry_run")
+    if !ok {
+        return
+    }
+    strict, ok := parseBool(w, r, "strict")
+    if !ok {
+        return
+    }
+    limits, ok := parseLimits(w, r)
+    if !ok {
+        return
+    }
+
+    entry, err := languageRouter.ScrapeURL(r.Context(), req.URL)
+    word, language := entry.Word, entry.Language
+    if word == "" {
+        word = req.URL
+    }
+    if language == "" {
+        language = "url"
+    }
+    respondScrape(w, r, word, language, view, dryRun, strict, limits, entry, err)
+}
+
+// parseBool reads the boolean query parameter name, writing a 400 and
+// returning false when it isn't a boolean.
+func parseBool(w http.ResponseWriter, r *http.Request, name string) (value bool, ok bool) {
+    v := r.URL.Query().Get(name)
+    if v == "" {
+        return false, true
+    }
+    value, err := strconv.ParseBool(v)
     if err != nil {
-        http.Error(w, "Failed to scrape word: "+err.Error(), http.StatusInternalServerError)
+        httpError(w, r, "Invalid "+name+" parameter: must be 'true' or 'false'", http.StatusBadRequest)
+        return false, false
+    }
+    return value, true
+}
+
+// parseLimits reads ?max_senses=, ?max_examples_per_meaning= and
+// ?max_forms=, writing a 400 and returning false when one isn't a
+// non-negative integer.
+func parseLimits(w http.ResponseWriter, r *http.Request) (limits models.Limits, ok bool) {
+    for name, limit := range map[string]*int{
+        "max_senses":               &limits.MaxSenses,
+        "max_examples_per_meaning": &limits.MaxExamplesPerMeaning,
+        "max_forms":                &limits.MaxForms,
+    } {
+        v := r.URL.Query().Get(name)
+        if v == "" {
+            continue
+        }
+        n, err := strconv.Atoi(v)
+        if err != nil || n < 0 {
+            httpError(w, r, "Invalid "+name+" parameter: must be a non-negative integer", http.StatusBadRequest)
+            return limits, false
+        }
+        *limit = n
+    }
+    return limits, true
+}
+
+// respondScrape reports a finished scrape (events, audit, delivery) and
+// writes the entry, or the error with a status that tells retryable
+// failures apart. A dry run is only audited: the entry is returned as it
+// would have been delivered, without events, the Python forward or the
+// message queue. In strict mode an entry with warnings is a failed scrape.
+// The request's limits only shorten the response; what is delivered is
+// cut to the configured limits alone.
+func respondScrape(w http.ResponseWriter, r *http.Request, word, language, view string, dryRun, strict bool, limits models.Limits, entry models.WordEntry, err error) {
+    if strict && err == nil {
+        err = entry.CheckComplete()
+    }
+    if !dryRun {
+        emitScrapeEvent(r.Context(), word, language, entry, err)
+    }
+    if err != nil {
+        audit(r, "scrape", "word", word+"@"+language, map[string]interface{}{"error": err.Error(), "dry_run": dryRun})
+        status := http.StatusInternalServerError
+        var throttled *sources.ThrottledError
+        var invalid *models.ValidationError
+        var incomplete *models.IncompleteError
+        if errors.As(err, &throttled) {
+            w.Header().Set("Retry-After", strconv.Itoa(int(throttled.RetryAfter().Seconds())))
+            status = http.StatusServiceUnavailable
+        } else if errors.Is(err, sources.ErrDisabled) || errors.Is(err, sources.ErrDisallowed) {
+            status = http.StatusServiceUnavailable
+        } else if errors.Is(err, routes.ErrUnsupportedURL) {
+            status = http.StatusBadRequest
+        } else if errors.Is(err, models.ErrNotFound) {
+            httpError(w, r, "Word not found: "+err.Error(), http.StatusNotFound)
+            return
+        } else if errors.As(err, &invalid) || errors.As(err, &incomplete) {
+            // The source sent something the scraper couldn't make a (complete) entry of
+            status = http.StatusBadGateway
+        }
+        httpError(w, r, "Failed to scrape word: "+err.Error(), status)
         return
     }
+    audit(r, "scrape", "word", word+"@"+language, map[string]interface{}{"senses": len(entry.Senses), "dry_run": dryRun})
+    if dryRun {
+        w.Header().Set("X-Dry-Run", "true")
+    } else {
+        deliver(r.Context(), entry)
+    }
+    entry.ApplyLimits(limits)
 
-    w.Header().Set("Content-Type", "application/json")
-    json.NewEncoder(w).Encode(entry)
+    if view == "compact" {
+        writeEncoded(w, r, http.StatusOK, models.ToCompact(entry))
+        return
+    }
+    writeEncoded(w, r, http.StatusOK, entry)
+}
+
+// ScrapeWord scrapes a word as ScrapeHandler does, detecting the language
+// when language is empty, for running the binary as a one-shot scraper.
+func ScrapeWord(ctx context.Context, word, language string) (models.WordEntry, error) {
+    if language == "" {
+        return languageRouter.ScrapeWordDetectingLanguage(ctx, word, nil)
+    }
+    return languageRouter.ScrapeWordByLanguage(ctx, word, language)
+}
+
+// splitList splits a comma-separated query parameter, skipping empty items.
+func splitList(s string) []string {
+    var items []string
+    for _, item := range strings.Split(s, ",") {
+        if item = strings.TrimSpace(item); item != "" {
+            items = append(items, item)
+        }
+    }
+    return items
+}
+
+// ConfigureLanguageDetection sets the languages tried, in order, for scrapes
+// that name no language and no ?languages=.
+func ConfigureLanguageDetection(languages []string) {
+    languageRouter.SetDetectLanguages(languages)
+}
+
+// ConfigureLimits cuts every scraped entry down to limits.
+func ConfigureLimits(limits models.Limits) {
+    languageRouter.SetLimits(limits)
+}
+
+// ConfigureEntryCache keeps up to cfg.Size complete entries in memory, so
+// words looked up repeatedly aren't scraped each time.
+func ConfigureEntryCache(cfg config.EntryCacheConfig) {
+    if cfg.Size > 0 {
+        languageRouter.SetEntryCache(cache.New[string, models.WordEntry](cfg.Size, cfg.TTL))
+    }
 }
 
 // LanguagesHandler returns supported languages
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/langcode"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/sources"
+
+	"vocabulary-app/backend/go-service/config"
+)
+
+// suggestions caches autocomplete results per language and prefix, so
+// typing doesn't send a request upstream for every keystroke of every user.
+var suggestions = newSuggestCache(config.SuggestConfig{Limit: 10, CacheTTL: time.Hour, CacheSize: 10000})
+
+type suggestCache struct {
+	mu      sync.Mutex
+	limit   int
+	ttl     time.Duration
+	size    int
+	entries map[string]cachedSuggestions
+}
+
+type cachedSuggestions struct {
+	words   []string
+	expires time.Time
+}
+
+func newSuggestCache(cfg config.SuggestConfig) *suggestCache {
+	return &suggestCache{limit: cfg.Limit, ttl: cfg.CacheTTL, size: cfg.CacheSize, entries: map[string]cachedSuggestions{}}
+}
+
+// ConfigureSuggest applies cfg to the autocomplete endpoint, emptying its cache.
+func ConfigureSuggest(cfg config.SuggestConfig) {
+	suggestions = newSuggestCache(cfg)
+}
+
+func (c *suggestCache) get(key string, now time.Time) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, ok := c.entries[key]
+	if !ok || now.After(cached.expires) {
+		return nil, false
+	}
+	return cached.words, true
+}
+
+func (c *suggestCache) put(key string, words []string, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= c.size {
+		for k, cached := range c.entries {
+			if now.After(cached.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= c.size {
+			// Still full of live entries; start over rather than track recency
+			c.entries = map[string]cachedSuggestions{}
+		}
+	}
+	c.entries[key] = cachedSuggestions{words: words, expires: now.Add(c.ttl)}
+}
+
+// SuggestHandler returns words starting with ?q= in ?language= (default
+// no-bm), for autocomplete as the user types.
+func SuggestHandler(w http.ResponseWriter, r *http.Request) {
+	prefix := r.URL.Query().Get("q")
+	if strings.TrimSpace(prefix) == "" {
+		httpError(w, r, "Missing q parameter", http.StatusBadRequest)
+		return
+	}
+	if err := models.CheckWord(prefix); err != nil {
+		httpError(w, r, "Invalid q parameter: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	language := r.URL.Query().Get("language")
+	if language == "" {
+		language = langcode.Bokmal
+	}
+	canonical, ok := langcode.Normalize(language)
+	if !ok {
+		httpError(w, r, "Unsupported language: "+language, http.StatusBadRequest)
+		return
+	}
+	prefix = models.NormalizeWord(canonical, prefix)
+
+	c := suggestions
+	key := canonical + "\x00" + prefix
+	words, ok := c.get(key, time.Now())
+	if !ok {
+		var err error
+		words, err = languageRouter.Suggest(r.Context(), prefix, canonical, c.limit)
+		if err != nil {
+			status := http.StatusBadGateway
+			if errors.Is(err, sources.ErrDisabled) {
+				status = http.StatusServiceUnavailable
+			}
+			httpError(w, r, "Failed to get suggestions: "+err.Error(), status)
+			return
+		}
+		c.put(key, words, time.Now())
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	writeEncoded(w, r, http.StatusOK, map[string]interface{}{
+		"query":       prefix,
+		"language":    canonical,
+		"suggestions": words,
+	})
+}
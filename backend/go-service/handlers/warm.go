@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+
+	"vocabulary-app/backend/go-service/config"
+	"vocabulary-app/backend/go-service/warmer"
+)
+
+// cacheWarmer pre-scrapes frequent words into the entry cache; nil when
+// disabled.
+var cacheWarmer *warmer.Warmer
+
+// EnableCacheWarming starts warming the entry cache with the most frequent
+// words of each enabled language until ctx is cancelled. It needs the
+// entry cache and frequency lists, so call it after ConfigureEntryCache
+// and EnableFrequency; without either it does nothing.
+func EnableCacheWarming(ctx context.Context, cfg config.EntryCacheConfig) {
+	if cfg.Size == 0 || frequencyLists == nil {
+		slog.Warn("cache warming needs the entry cache and frequency lists, not warming", "entry_cache_size", cfg.Size, "frequency_lists", frequencyLists != nil)
+		return
+	}
+	if cfg.WarmWords > cfg.Size {
+		slog.Warn("more words are warmed per language than the entry cache holds", "warm_words", cfg.WarmWords, "entry_cache_size", cfg.Size)
+	}
+	cacheWarmer = warmer.New(warmer.Config{
+		Warm:      languageRouter.Warm,
+		Languages: warmLanguages,
+		Top:       frequencyLists.Top,
+		Idle:      func() bool { return languageRouter.Idle(cfg.WarmIdle) },
+		Words:     cfg.WarmWords,
+		Interval:  cfg.WarmInterval,
+		Delay:     cfg.WarmDelay,
+	})
+	go cacheWarmer.Run(ctx)
+}
+
+// warmLanguages lists the enabled languages that have a frequency list.
+func warmLanguages() []string {
+	var languages []string
+	for _, language := range languageRouter.GetSupportedLanguages() {
+		if frequencyLists.Ranked(language) {
+			languages = append(languages, language)
+		}
+	}
+	return languages
+}
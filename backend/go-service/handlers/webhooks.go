@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"vocabulary-app/backend/go-service/config"
+	"vocabulary-app/backend/go-service/deadletter"
+	"vocabulary-app/backend/go-service/middleware"
+	"vocabulary-app/backend/go-service/webhooks"
+)
+
+var webhookStore *webhooks.Store
+
+// allowPrivateWebhooks lets webhooks point at private addresses.
+var allowPrivateWebhooks bool
+
+// EnableWebhooks stores webhooks in store and notifies them when jobs
+// finish. Notifications that fail every attempt go to the dead letters.
+func EnableWebhooks(store *webhooks.Store, cfg config.WebhooksConfig) {
+	webhookStore = store
+	allowPrivateWebhooks = cfg.AllowPrivateNetworks
+	dispatcher := webhooks.NewDispatcher(store, deadLetters, cfg)
+	jobScheduler.OnFinish(dispatcher.JobFinished)
+	deadLetters.Handle(deadletter.KindWebhook, dispatcher.Redrive)
+}
+
+type createWebhookRequest struct {
+	URL    string `json:"url"`
+	Global bool   `json:"global"`
+}
+
+// CreateWebhookHandler registers a webhook for the authenticated user. The
+// response includes the secret used to sign deliveries; it is not shown again.
+func CreateWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	claims, _ := middleware.ClaimsFromContext(r.Context())
+
+	var req createWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpError(w, r, "Invalid JSON body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	u, err := webhooks.CheckURL(r.Context(), req.URL, allowPrivateWebhooks)
+	if err != nil {
+		httpError(w, r, "Invalid webhook URL: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Global && claims.Role != middleware.RoleAdmin {
+		httpError(w, r, "Only admins can register global webhooks", http.StatusForbidden)
+		return
+	}
+
+	hook, err := webhookStore.Create(claims.ID, u.String(), req.Global)
+	if err != nil {
+		httpError(w, r, "Failed to save webhook: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	audit(r, "create", "webhook", hook.ID, map[string]interface{}{"url": hook.URL, "global": hook.Global})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(hook)
+}
+
+// ListWebhooksHandler lists the authenticated user's webhooks.
+func ListWebhooksHandler(w http.ResponseWriter, r *http.Request) {
+	claims, _ := middleware.ClaimsFromContext(r.Context())
+	hooks, err := webhookStore.List(claims.ID)
+	if err != nil {
+		httpError(w, r, "Failed to load webhooks: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"webhooks": hooks})
+}
+
+// DeleteWebhookHandler removes one of the user's webhooks (admins may remove any).
+func DeleteWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	claims, _ := middleware.ClaimsFromContext(r.Context())
+	id := r.PathValue("id")
+	err := webhookStore.Delete(id, claims.ID, claims.Role == middleware.RoleAdmin)
+	if errors.Is(err, webhooks.ErrNotFound) {
+		httpError(w, r, "Webhook not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		httpError(w, r, "Failed to delete webhook: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	audit(r, "delete", "webhook", id, nil)
+	w.WriteHeader(http.StatusNoContent)
+}
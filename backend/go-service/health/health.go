@@ -0,0 +1,161 @@
+// Package health runs the dependency checks behind the /healthz and /readyz
+// probes. Each check reports its own status, so an operator can see which
+// dependency is failing, and only critical checks fail the probe as a whole.
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Overall and per-check statuses.
+const (
+	StatusOK          = "ok"
+	StatusDegraded    = "degraded"
+	StatusUnavailable = "unavailable"
+	StatusFail        = "fail"
+)
+
+// checkTimeout bounds each check, so a hung dependency can't stall the probe.
+const checkTimeout = 2 * time.Second
+
+// Check is one dependency check.
+type Check struct {
+	Name string
+	// Critical checks make the probe unavailable when they fail; others
+	// only mark it degraded (e.g. Chrome, which has a static fallback).
+	Critical bool
+	Run      func(ctx context.Context) error
+}
+
+// Result is the outcome of one check.
+type Result struct {
+	Status     string `json:"status"`
+	Critical   bool   `json:"critical"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// Report is the body of a probe response.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Checker runs a fixed set of checks.
+type Checker struct {
+	checks []Check
+}
+
+// NewChecker creates a checker for checks.
+func NewChecker(checks ...Check) *Checker {
+	return &Checker{checks: checks}
+}
+
+// With returns a checker running c's checks plus checks.
+func (c *Checker) With(checks ...Check) *Checker {
+	return &Checker{checks: append(append([]Check{}, c.checks...), checks...)}
+}
+
+// Run runs all checks concurrently and summarises them.
+func (c *Checker) Run(ctx context.Context) Report {
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(c.checks))}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := run(ctx, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[check.Name] = result
+			if result.Status == StatusFail {
+				if check.Critical {
+					report.Status = StatusUnavailable
+				} else if report.Status == StatusOK {
+					report.Status = StatusDegraded
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return report
+}
+
+func run(ctx context.Context, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Run(ctx)
+	result := Result{Status: StatusOK, Critical: check.Critical, DurationMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Database checks that the local bolt database is open and readable.
+func Database(db *bolt.DB) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		return db.View(func(tx *bolt.Tx) error { return nil })
+	}
+}
+
+// chromeExecutables are the names chromedp looks for on the PATH.
+var chromeExecutables = []string{
+	"headless_shell", "headless-shell", "chromium", "chromium-browser",
+	"google-chrome", "google-chrome-stable", "google-chrome-beta", "google-chrome-unstable",
+}
+
+// RemoteChrome checks that the Chrome at a DevTools URL (ws:// or http://,
+// as CHROME_WS_URL takes it) answers on its debugging port.
+func RemoteChrome(devtoolsURL string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		u, err := url.Parse(devtoolsURL)
+		if err != nil {
+			return err
+		}
+		switch u.Scheme {
+		case "ws":
+			u.Scheme = "http"
+		case "wss":
+			u.Scheme = "https"
+		}
+		u.Path, u.RawQuery = "/json/version", ""
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("chrome at %s returned %s", u.Host, resp.Status)
+		}
+		return nil
+	}
+}
+
+// Chrome checks that a Chrome binary is available for inflection scraping.
+func Chrome(ctx context.Context) error {
+	for _, name := range chromeExecutables {
+		if _, err := exec.LookPath(name); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("no Chrome executable found on PATH")
+}
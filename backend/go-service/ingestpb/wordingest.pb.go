@@ -0,0 +1,359 @@
+// WordIngest is the contract for delivering scraped dictionary entries from
+// the Go service to the Python service. Generated code lives in
+// backend/go-service/ingestpb and backend/python-service/wordingest_pb2*.py;
+// regenerate both with backend/proto/generate.sh after editing this file.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: wordingest.proto
+
+package ingestpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+	wordentrypb "vocabulary-app/backend/go-service/wordentrypb"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type IngestStatus int32
+
+const (
+	IngestStatus_INGEST_STATUS_UNSPECIFIED IngestStatus = 0
+	IngestStatus_INGEST_STATUS_CREATED     IngestStatus = 1
+	IngestStatus_INGEST_STATUS_EXISTS      IngestStatus = 2
+	IngestStatus_INGEST_STATUS_REJECTED    IngestStatus = 3
+)
+
+// Enum value maps for IngestStatus.
+var (
+	IngestStatus_name = map[int32]string{
+		0: "INGEST_STATUS_UNSPECIFIED",
+		1: "INGEST_STATUS_CREATED",
+		2: "INGEST_STATUS_EXISTS",
+		3: "INGEST_STATUS_REJECTED",
+	}
+	IngestStatus_value = map[string]int32{
+		"INGEST_STATUS_UNSPECIFIED": 0,
+		"INGEST_STATUS_CREATED":     1,
+		"INGEST_STATUS_EXISTS":      2,
+		"INGEST_STATUS_REJECTED":    3,
+	}
+)
+
+func (x IngestStatus) Enum() *IngestStatus {
+	p := new(IngestStatus)
+	*p = x
+	return p
+}
+
+func (x IngestStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IngestStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_wordingest_proto_enumTypes[0].Descriptor()
+}
+
+func (IngestStatus) Type() protoreflect.EnumType {
+	return &file_wordingest_proto_enumTypes[0]
+}
+
+func (x IngestStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IngestStatus.Descriptor instead.
+func (IngestStatus) EnumDescriptor() ([]byte, []int) {
+	return file_wordingest_proto_rawDescGZIP(), []int{0}
+}
+
+type IngestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entry         *wordentrypb.WordEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestRequest) Reset() {
+	*x = IngestRequest{}
+	mi := &file_wordingest_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestRequest) ProtoMessage() {}
+
+func (x *IngestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wordingest_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestRequest.ProtoReflect.Descriptor instead.
+func (*IngestRequest) Descriptor() ([]byte, []int) {
+	return file_wordingest_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *IngestRequest) GetEntry() *wordentrypb.WordEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+type IngestResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Word     string                 `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	Language string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Status   IngestStatus           `protobuf:"varint,3,opt,name=status,proto3,enum=wordingest.v1.IngestStatus" json:"status,omitempty"`
+	// Why the entry was rejected.
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// ID of the stored word, when created.
+	WordId        int64 `protobuf:"varint,5,opt,name=word_id,json=wordId,proto3" json:"word_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestResponse) Reset() {
+	*x = IngestResponse{}
+	mi := &file_wordingest_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestResponse) ProtoMessage() {}
+
+func (x *IngestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wordingest_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestResponse.ProtoReflect.Descriptor instead.
+func (*IngestResponse) Descriptor() ([]byte, []int) {
+	return file_wordingest_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *IngestResponse) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *IngestResponse) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *IngestResponse) GetStatus() IngestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return IngestStatus_INGEST_STATUS_UNSPECIFIED
+}
+
+func (x *IngestResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *IngestResponse) GetWordId() int64 {
+	if x != nil {
+		return x.WordId
+	}
+	return 0
+}
+
+type IngestSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*IngestResponse      `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Created       int32                  `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Exists        int32                  `protobuf:"varint,3,opt,name=exists,proto3" json:"exists,omitempty"`
+	Rejected      int32                  `protobuf:"varint,4,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestSummary) Reset() {
+	*x = IngestSummary{}
+	mi := &file_wordingest_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestSummary) ProtoMessage() {}
+
+func (x *IngestSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_wordingest_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestSummary.ProtoReflect.Descriptor instead.
+func (*IngestSummary) Descriptor() ([]byte, []int) {
+	return file_wordingest_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *IngestSummary) GetResults() []*IngestResponse {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *IngestSummary) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *IngestSummary) GetExists() int32 {
+	if x != nil {
+		return x.Exists
+	}
+	return 0
+}
+
+func (x *IngestSummary) GetRejected() int32 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+var File_wordingest_proto protoreflect.FileDescriptor
+
+const file_wordingest_proto_rawDesc = "" +
+	"\n" +
+	"\x10wordingest.proto\x12\rwordingest.v1\x1a\x0fwordentry.proto\">\n" +
+	"\rIngestRequest\x12-\n" +
+	"\x05entry\x18\x01 \x01(\v2\x17.wordentry.v1.WordEntryR\x05entry\"\xa4\x01\n" +
+	"\x0eIngestResponse\x12\x12\n" +
+	"\x04word\x18\x01 \x01(\tR\x04word\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x123\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1b.wordingest.v1.IngestStatusR\x06status\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x17\n" +
+	"\aword_id\x18\x05 \x01(\x03R\x06wordId\"\x96\x01\n" +
+	"\rIngestSummary\x127\n" +
+	"\aresults\x18\x01 \x03(\v2\x1d.wordingest.v1.IngestResponseR\aresults\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\x05R\acreated\x12\x16\n" +
+	"\x06exists\x18\x03 \x01(\x05R\x06exists\x12\x1a\n" +
+	"\brejected\x18\x04 \x01(\x05R\brejected*~\n" +
+	"\fIngestStatus\x12\x1d\n" +
+	"\x19INGEST_STATUS_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15INGEST_STATUS_CREATED\x10\x01\x12\x18\n" +
+	"\x14INGEST_STATUS_EXISTS\x10\x02\x12\x1a\n" +
+	"\x16INGEST_STATUS_REJECTED\x10\x032\xa1\x01\n" +
+	"\n" +
+	"WordIngest\x12E\n" +
+	"\x06Ingest\x12\x1c.wordingest.v1.IngestRequest\x1a\x1d.wordingest.v1.IngestResponse\x12L\n" +
+	"\fIngestStream\x12\x1c.wordingest.v1.IngestRequest\x1a\x1c.wordingest.v1.IngestSummary(\x01B,Z*vocabulary-app/backend/go-service/ingestpbb\x06proto3"
+
+var (
+	file_wordingest_proto_rawDescOnce sync.Once
+	file_wordingest_proto_rawDescData []byte
+)
+
+func file_wordingest_proto_rawDescGZIP() []byte {
+	file_wordingest_proto_rawDescOnce.Do(func() {
+		file_wordingest_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_wordingest_proto_rawDesc), len(file_wordingest_proto_rawDesc)))
+	})
+	return file_wordingest_proto_rawDescData
+}
+
+var file_wordingest_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_wordingest_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_wordingest_proto_goTypes = []any{
+	(IngestStatus)(0),             // 0: wordingest.v1.IngestStatus
+	(*IngestRequest)(nil),         // 1: wordingest.v1.IngestRequest
+	(*IngestResponse)(nil),        // 2: wordingest.v1.IngestResponse
+	(*IngestSummary)(nil),         // 3: wordingest.v1.IngestSummary
+	(*wordentrypb.WordEntry)(nil), // 4: wordentry.v1.WordEntry
+}
+var file_wordingest_proto_depIdxs = []int32{
+	4, // 0: wordingest.v1.IngestRequest.entry:type_name -> wordentry.v1.WordEntry
+	0, // 1: wordingest.v1.IngestResponse.status:type_name -> wordingest.v1.IngestStatus
+	2, // 2: wordingest.v1.IngestSummary.results:type_name -> wordingest.v1.IngestResponse
+	1, // 3: wordingest.v1.WordIngest.Ingest:input_type -> wordingest.v1.IngestRequest
+	1, // 4: wordingest.v1.WordIngest.IngestStream:input_type -> wordingest.v1.IngestRequest
+	2, // 5: wordingest.v1.WordIngest.Ingest:output_type -> wordingest.v1.IngestResponse
+	3, // 6: wordingest.v1.WordIngest.IngestStream:output_type -> wordingest.v1.IngestSummary
+	5, // [5:7] is the sub-list for method output_type
+	3, // [3:5] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_wordingest_proto_init() }
+func file_wordingest_proto_init() {
+	if File_wordingest_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_wordingest_proto_rawDesc), len(file_wordingest_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_wordingest_proto_goTypes,
+		DependencyIndexes: file_wordingest_proto_depIdxs,
+		EnumInfos:         file_wordingest_proto_enumTypes,
+		MessageInfos:      file_wordingest_proto_msgTypes,
+	}.Build()
+	File_wordingest_proto = out.File
+	file_wordingest_proto_goTypes = nil
+	file_wordingest_proto_depIdxs = nil
+}
@@ -0,0 +1,164 @@
+// WordIngest is the contract for delivering scraped dictionary entries from
+// the Go service to the Python service. Generated code lives in
+// backend/go-service/ingestpb and backend/python-service/wordingest_pb2*.py;
+// regenerate both with backend/proto/generate.sh after editing this file.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: wordingest.proto
+
+package ingestpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	WordIngest_Ingest_FullMethodName       = "/wordingest.v1.WordIngest/Ingest"
+	WordIngest_IngestStream_FullMethodName = "/wordingest.v1.WordIngest/IngestStream"
+)
+
+// WordIngestClient is the client API for WordIngest service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WordIngestClient interface {
+	// Ingest stores a single entry.
+	Ingest(ctx context.Context, in *IngestRequest, opts ...grpc.CallOption) (*IngestResponse, error)
+	// IngestStream stores a stream of entries in one transaction and reports
+	// the outcome of each once the client closes the stream.
+	IngestStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[IngestRequest, IngestSummary], error)
+}
+
+type wordIngestClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWordIngestClient(cc grpc.ClientConnInterface) WordIngestClient {
+	return &wordIngestClient{cc}
+}
+
+func (c *wordIngestClient) Ingest(ctx context.Context, in *IngestRequest, opts ...grpc.CallOption) (*IngestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IngestResponse)
+	err := c.cc.Invoke(ctx, WordIngest_Ingest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wordIngestClient) IngestStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[IngestRequest, IngestSummary], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &WordIngest_ServiceDesc.Streams[0], WordIngest_IngestStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[IngestRequest, IngestSummary]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WordIngest_IngestStreamClient = grpc.ClientStreamingClient[IngestRequest, IngestSummary]
+
+// WordIngestServer is the server API for WordIngest service.
+// All implementations must embed UnimplementedWordIngestServer
+// for forward compatibility.
+type WordIngestServer interface {
+	// Ingest stores a single entry.
+	Ingest(context.Context, *IngestRequest) (*IngestResponse, error)
+	// IngestStream stores a stream of entries in one transaction and reports
+	// the outcome of each once the client closes the stream.
+	IngestStream(grpc.ClientStreamingServer[IngestRequest, IngestSummary]) error
+	mustEmbedUnimplementedWordIngestServer()
+}
+
+// UnimplementedWordIngestServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWordIngestServer struct{}
+
+func (UnimplementedWordIngestServer) Ingest(context.Context, *IngestRequest) (*IngestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ingest not implemented")
+}
+func (UnimplementedWordIngestServer) IngestStream(grpc.ClientStreamingServer[IngestRequest, IngestSummary]) error {
+	return status.Errorf(codes.Unimplemented, "method IngestStream not implemented")
+}
+func (UnimplementedWordIngestServer) mustEmbedUnimplementedWordIngestServer() {}
+func (UnimplementedWordIngestServer) testEmbeddedByValue()                    {}
+
+// UnsafeWordIngestServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WordIngestServer will
+// result in compilation errors.
+type UnsafeWordIngestServer interface {
+	mustEmbedUnimplementedWordIngestServer()
+}
+
+func RegisterWordIngestServer(s grpc.ServiceRegistrar, srv WordIngestServer) {
+	// If the following call pancis, it indicates UnimplementedWordIngestServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&WordIngest_ServiceDesc, srv)
+}
+
+func _WordIngest_Ingest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WordIngestServer).Ingest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WordIngest_Ingest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WordIngestServer).Ingest(ctx, req.(*IngestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WordIngest_IngestStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WordIngestServer).IngestStream(&grpc.GenericServerStream[IngestRequest, IngestSummary]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WordIngest_IngestStreamServer = grpc.ClientStreamingServer[IngestRequest, IngestSummary]
+
+// WordIngest_ServiceDesc is the grpc.ServiceDesc for WordIngest service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WordIngest_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "wordingest.v1.WordIngest",
+	HandlerType: (*WordIngestServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Ingest",
+			Handler:    _WordIngest_Ingest_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "IngestStream",
+			Handler:       _WordIngest_IngestStream_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "wordingest.proto",
+}
@@ -0,0 +1,640 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/langcode"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/sources"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Kind distinguishes user-facing jobs from background work.
+type Kind string
+
+const (
+	// KindBatch scrapes a list of words, e.g. from an import.
+	KindBatch Kind = "batch"
+	// KindWarm pre-scrapes words to warm caches.
+	KindWarm Kind = "warm"
+)
+
+// Status is the lifecycle state of a job.
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusDeferred  Status = "deferred"  // waiting for the source's batch window
+	StatusThrottled Status = "throttled" // waiting for a source that rate limited us
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+)
+
+// maxThrottleRetries is how many times a job waits out a source's rate
+// limiting before giving up on its remaining words.
+const maxThrottleRetries = 3
+
+// DefaultRetention is how long finished jobs are kept unless SetLimits says
+// otherwise.
+const DefaultRetention = 24 * time.Hour
+
+// evictInterval is how often Run drops finished jobs past their retention.
+const evictInterval = time.Minute
+
+// Result is the outcome of scraping one word within a job.
+type Result struct {
+	Word  string            `json:"word"`
+	Entry *models.WordEntry `json:"entry,omitempty"`
+	Error string            `json:"error,omitempty"`
+	// Throttled marks words that failed because the source rate limited us.
+	Throttled bool `json:"throttled,omitempty"`
+}
+
+// Progress counts a job's words as they are scraped, so long imports can be
+// followed while they run.
+type Progress struct {
+	Done   int `json:"done"`
+	Failed int `json:"failed"`
+	Total  int `json:"total"`
+}
+
+// Job is a unit of scraping work processed by the Scheduler.
+type Job struct {
+	ID         string     `json:"id"`
+	Kind       Kind       `json:"kind"`
+	Language   string     `json:"language"`
+	Words      []string   `json:"words"`
+	OwnerID    int        `json:"owner_id,omitempty"` // user who submitted the job, 0 if anonymous
+	Strict     bool       `json:"strict,omitempty"`   // words whose entries have warnings fail
+	Status     Status     `json:"status"`
+	Progress   Progress   `json:"progress"`
+	Results    []Result   `json:"results,omitempty"` // in word order, up to the first word still being scraped
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	NotBefore  *time.Time `json:"not_before,omitempty"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+
+	done      chan struct{}  // closed when the job completes or fails
+	throttles int            // times the job has waited for a rate-limiting source
+	pending   map[int]Result // results scraped ahead of an earlier word, by word index
+	each      func(Result)   // receives results of streamed jobs, in word order
+}
+
+// Background reports whether jobs of this kind are subject to source batch windows.
+func (k Kind) Background() bool {
+	return k == KindBatch || k == KindWarm
+}
+
+// ScrapeFunc scrapes a single word in a canonical language. In strict mode
+// an entry with warnings is returned as an error.
+type ScrapeFunc func(word, language string, strict bool) (models.WordEntry, error)
+
+// Scheduler queues jobs and runs one job per language at a time, each
+// scraping a few words at once, holding background jobs until their
+// source's batch window opens. Jobs of different languages run side by
+// side; jobs of the same language wait their turn.
+type Scheduler struct {
+	scrape  ScrapeFunc
+	sources *sources.Registry
+
+	mu          sync.Mutex
+	jobs        map[string]*Job
+	queue       []*Job
+	running     map[string]bool // languages with a job running
+	concurrency int             // words of a job scraped at once
+	perLanguage map[string]int  // concurrency for particular languages
+	maxWords    int             // words allowed in one job, 0 for any number
+	retention   time.Duration   // how long finished jobs are kept
+	wake        chan struct{}
+	onFinish    []func(*Job)
+	// db stores jobs so they survive restarts; nil until Persist is called.
+	db *bolt.DB
+}
+
+// NewScheduler creates a scheduler using scrape to process words.
+func NewScheduler(scrape ScrapeFunc, registry *sources.Registry) *Scheduler {
+	return &Scheduler{
+		scrape:      scrape,
+		sources:     registry,
+		jobs:        make(map[string]*Job),
+		running:     make(map[string]bool),
+		concurrency: 1,
+		retention:   DefaultRetention,
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// QueueStats counts the jobs waiting and running, for the admin stats.
+type QueueStats struct {
+	// Queued counts jobs waiting to run, deferred and throttled ones included.
+	Queued  int `json:"queued"`
+	Running int `json:"running"`
+	// Words counts the words the queued jobs have left to scrape.
+	Words int `json:"words"`
+}
+
+// Stats counts the jobs waiting and running.
+func (s *Scheduler) Stats() QueueStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := QueueStats{Queued: len(s.queue)}
+	for _, job := range s.queue {
+		stats.Words += len(job.Words) - job.Progress.Done
+	}
+	for _, running := range s.running {
+		if running {
+			stats.Running++
+		}
+	}
+	return stats
+}
+
+// SetConcurrency scrapes up to n words of a job at once, or as many as
+// perLanguage gives for the job's canonical language. Sources still rate
+// limit their own requests, so this mostly overlaps the waits of slow
+// pages and Chrome sessions.
+func (s *Scheduler) SetConcurrency(n int, perLanguage map[string]int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.concurrency = max(n, 1)
+	s.perLanguage = perLanguage
+}
+
+// SetLimits caps the words of a submitted job at maxWords (0 for no cap)
+// and keeps finished jobs, in memory and in the database, for retention
+// after they finish; after that they can no longer be polled. Call it
+// before Persist.
+func (s *Scheduler) SetLimits(maxWords int, retention time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxWords = maxWords
+	s.retention = retention
+}
+
+// ParseConcurrency reads per-language concurrency from specs of the form
+// "language:n", e.g. "no-bm:4".
+func ParseConcurrency(specs []string) (map[string]int, error) {
+	perLanguage := map[string]int{}
+	for _, spec := range specs {
+		rawLanguage, rawN, ok := strings.Cut(spec, ":")
+		language, known := langcode.Normalize(rawLanguage)
+		n, err := strconv.Atoi(rawN)
+		if !ok || !known || err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid concurrency %q, want language:n with n at least 1", spec)
+		}
+		perLanguage[language] = n
+	}
+	return perLanguage, nil
+}
+
+// concurrencyFor returns how many words of a job in language are scraped
+// at once.
+func (s *Scheduler) concurrencyFor(language string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n, ok := s.perLanguage[language]; ok {
+		return n
+	}
+	return s.concurrency
+}
+
+// OnFinish registers fn to be called with a copy of each job once it has
+// completed or failed. Callbacks run on the worker goroutine and should
+// hand off slow work.
+func (s *Scheduler) OnFinish(fn func(*Job)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onFinish = append(s.onFinish, fn)
+}
+
+// Submit queues a new job for the given canonical language on behalf of
+// owner (a user ID, or 0 for anonymous callers). A strict job fails the
+// words whose entries are incomplete instead of keeping partial entries.
+func (s *Scheduler) Submit(kind Kind, language string, words []string, owner int, strict bool) (*Job, error) {
+	return s.SubmitStreaming(kind, language, words, owner, strict, nil)
+}
+
+// SubmitStreaming is Submit for callers that take results as they are
+// scraped instead of polling for them: each is called with every result,
+// in word order, before the job finishes. The job itself then keeps only
+// each result's word and error, so a large batch's entries aren't all held
+// in memory. Calls to each hold up the job's workers, which bounds how far
+// scraping runs ahead of a slow reader.
+func (s *Scheduler) SubmitStreaming(kind Kind, language string, words []string, owner int, strict bool, each func(Result)) (*Job, error) {
+	if len(words) == 0 {
+		return nil, fmt.Errorf("job has no words")
+	}
+	s.mu.Lock()
+	maxWords := s.maxWords
+	s.mu.Unlock()
+	if maxWords > 0 && len(words) > maxWords {
+		return nil, fmt.Errorf("job has %d words, at most %d are allowed", len(words), maxWords)
+	}
+
+	job := &Job{
+		ID:        newID(),
+		Kind:      kind,
+		Language:  language,
+		Words:     words,
+		OwnerID:   owner,
+		Strict:    strict,
+		Status:    StatusQueued,
+		Progress:  Progress{Total: len(words)},
+		CreatedAt: time.Now(),
+		done:      make(chan struct{}),
+		each:      each,
+	}
+
+	s.mu.Lock()
+	s.jobs[job.ID] = job
+	s.queue = append(s.queue, job)
+	data := s.marshalJob(job)
+	s.mu.Unlock()
+
+	s.saveJob(job.ID, data)
+	s.signal()
+	return s.snapshot(job), nil
+}
+
+// Get returns a copy of the job with the given ID.
+func (s *Scheduler) Get(id string) (*Job, bool) {
+	s.mu.Lock()
+	job, ok := s.jobs[id]
+	s.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+	return s.snapshot(job), true
+}
+
+// Wait blocks until the job finishes or ctx is done, and returns the job's
+// state at that point. Callers can check Finished to tell the two apart.
+func (s *Scheduler) Wait(ctx context.Context, id string) (*Job, bool) {
+	s.mu.Lock()
+	job, ok := s.jobs[id]
+	s.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+
+	select {
+	case <-job.done:
+	case <-ctx.Done():
+	}
+	return s.snapshot(job), true
+}
+
+// Finished reports whether the job has completed or failed.
+func (j *Job) Finished() bool {
+	return j.Status == StatusCompleted || j.Status == StatusFailed
+}
+
+// Run processes the queue until ctx is cancelled, and drops finished jobs
+// once they are past their retention.
+func (s *Scheduler) Run(ctx context.Context) {
+	evict := time.NewTicker(evictInterval)
+	defer evict.Stop()
+	for {
+		job, wait := s.next()
+		if job != nil {
+			go s.process(job)
+			continue
+		}
+
+		var timer *time.Timer
+		var fire <-chan time.Time
+		if wait > 0 {
+			timer = time.NewTimer(wait)
+			fire = timer.C
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-fire:
+		case <-evict.C:
+			s.evictFinished(time.Now())
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// evictFinished drops the jobs that finished more than the retention
+// before now.
+func (s *Scheduler) evictFinished(now time.Time) {
+	s.mu.Lock()
+	cutoff := now.Add(-s.retention)
+	var expired []string
+	for id, job := range s.jobs {
+		if job.Finished() && job.FinishedAt != nil && job.FinishedAt.Before(cutoff) {
+			expired = append(expired, id)
+			delete(s.jobs, id)
+		}
+	}
+	s.mu.Unlock()
+
+	if len(expired) > 0 {
+		s.deleteJobs(expired)
+		slog.Info("finished jobs evicted", "jobs", len(expired))
+	}
+}
+
+// next pops the first runnable job whose language has no job running. If
+// none is runnable it returns how long to wait for the earliest batch
+// window to open or source backoff to end (0 means wait for a submit or a
+// job to finish).
+func (s *Scheduler) next() (*Job, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var earliest time.Time
+	for i, job := range s.queue {
+		if s.running[job.Language] {
+			continue
+		}
+		open, waiting := s.opensAt(job, now)
+		if !open.After(now) {
+			s.queue = append(s.queue[:i], s.queue[i+1:]...)
+			s.running[job.Language] = true
+			job.Status = StatusRunning
+			job.NotBefore = nil
+			if job.StartedAt == nil {
+				started := now
+				job.StartedAt = &started
+			}
+			return job, 0
+		}
+
+		if job.Status != waiting {
+			reason := "source batch window opens"
+			if waiting == StatusThrottled {
+				reason = "source stops rate limiting"
+			}
+			slog.Info("job deferred until "+reason, "job_id", job.ID, "language", job.Language, "not_before", open.Format(time.RFC3339))
+		}
+		job.Status = waiting
+		notBefore := open
+		job.NotBefore = &notBefore
+		if earliest.IsZero() || open.Before(earliest) {
+			earliest = open
+		}
+	}
+
+	if earliest.IsZero() {
+		return nil, 0
+	}
+	return nil, earliest.Sub(now)
+}
+
+// opensAt returns when the job is allowed to run, and the status it waits
+// in until then: StatusThrottled while its source is backing off,
+// otherwise StatusDeferred.
+func (s *Scheduler) opensAt(job *Job, now time.Time) (time.Time, Status) {
+	if until := s.sources.BackoffUntil(job.Language); until.After(now) {
+		return until, StatusThrottled
+	}
+	if !job.Kind.Background() {
+		return now, StatusDeferred
+	}
+	profile, ok := s.sources.ForLanguage(job.Language)
+	if !ok || profile.BatchWindow == nil {
+		return now, StatusDeferred
+	}
+	return profile.BatchWindow.NextOpen(now), StatusDeferred
+}
+
+// batch is what the workers scraping a job's words share.
+type batch struct {
+	mu          sync.Mutex
+	budgetErr   error                   // the source's budget ran out
+	throttleErr error                   // the source rate limits us, and the job has waited enough
+	paused      *sources.ThrottledError // the job waits out the source's backoff
+
+	streamMu sync.Mutex // keeps streamed results in word order
+}
+
+func (s *Scheduler) process(job *Job) {
+	defer s.release(job.Language)
+
+	// A job resumed after a source's backoff picks up where it stopped
+	s.mu.Lock()
+	var todo []int
+	for i := len(job.Results); i < len(job.Words); i++ {
+		if _, ok := job.pending[i]; !ok {
+			todo = append(todo, i)
+		}
+	}
+	data := s.marshalJob(job)
+	s.mu.Unlock()
+	s.saveJob(job.ID, data)
+
+	workers := min(s.concurrencyFor(job.Language), len(todo))
+	slog.Info("job started", "job_id", job.ID, "kind", job.Kind, "words", len(job.Words), "remaining", len(todo), "language", job.Language, "workers", workers)
+
+	var b batch
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				s.scrapeWord(job, i, &b)
+			}
+		}()
+	}
+	for _, i := range todo {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	if b.paused != nil {
+		s.requeueThrottled(job, b.paused)
+		return
+	}
+
+	s.mu.Lock()
+	finished := time.Now()
+	job.FinishedAt = &finished
+	failures := job.Progress.Failed
+	if b.budgetErr != nil {
+		job.Status = StatusFailed
+		job.Error = b.budgetErr.Error()
+	} else if b.throttleErr != nil {
+		job.Status = StatusFailed
+		job.Error = b.throttleErr.Error()
+	} else if failures == len(job.Words) {
+		job.Status = StatusFailed
+		job.Error = "all words failed"
+	} else {
+		job.Status = StatusCompleted
+	}
+	close(job.done)
+	callbacks := s.onFinish
+	data = s.marshalJob(job)
+	s.mu.Unlock()
+	s.saveJob(job.ID, data)
+
+	finishedJob := s.snapshot(job)
+	for _, fn := range callbacks {
+		fn(finishedJob)
+	}
+
+	slog.Info("job finished",
+		"job_id", job.ID,
+		"status", finishedJob.Status,
+		"succeeded", len(job.Words)-failures,
+		"words", len(job.Words),
+		"duration", finishedJob.FinishedAt.Sub(*finishedJob.StartedAt).Round(time.Millisecond),
+	)
+}
+
+// scrapeWord scrapes the job's i-th word and records the result, unless the
+// job is pausing for a rate-limiting source, in which case the word is left
+// for when it resumes.
+func (s *Scheduler) scrapeWord(job *Job, i int, b *batch) {
+	word := job.Words[i]
+	result := Result{Word: word}
+
+	b.mu.Lock()
+	paused, budgetErr, throttleErr := b.paused != nil, b.budgetErr, b.throttleErr
+	b.mu.Unlock()
+	if paused {
+		return
+	}
+
+	if budgetErr != nil || throttleErr != nil {
+		// Don't keep hitting an exhausted or rate-limiting source; report the remaining words as skipped
+		err := budgetErr
+		if err == nil {
+			err = throttleErr
+			result.Throttled = true
+		}
+		result.Error = "skipped: " + err.Error()
+	} else if entry, err := s.scrape(word, job.Language, job.Strict); err != nil {
+		var throttled *sources.ThrottledError
+		b.mu.Lock()
+		switch {
+		case errors.As(err, &throttled) && job.throttles < maxThrottleRetries:
+			if b.paused == nil {
+				b.paused = throttled
+			}
+			b.mu.Unlock()
+			return
+		case errors.Is(err, sources.ErrBudgetExhausted):
+			b.budgetErr = err
+		case errors.Is(err, sources.ErrThrottled):
+			result.Throttled = true
+			b.throttleErr = err
+		}
+		b.mu.Unlock()
+		result.Error = err.Error()
+	} else {
+		result.Entry = &entry
+	}
+	s.record(job, i, result, b)
+}
+
+// record stores the result for the job's i-th word, and streams it when
+// the job is streamed. Results scraped ahead of an earlier word wait in
+// pending, so Results stays in word order.
+func (s *Scheduler) record(job *Job, i int, result Result, b *batch) {
+	b.streamMu.Lock()
+	defer b.streamMu.Unlock()
+
+	s.mu.Lock()
+	var ready []Result
+	first := len(job.Results)
+	job.Progress.Done++
+	if result.Error != "" {
+		job.Progress.Failed++
+	}
+	if job.pending == nil {
+		job.pending = map[int]Result{}
+	}
+	job.pending[i] = result
+	for {
+		next, ok := job.pending[len(job.Results)]
+		if !ok {
+			break
+		}
+		delete(job.pending, len(job.Results))
+		ready = append(ready, next)
+		if job.each != nil {
+			next.Entry = nil
+		}
+		job.Results = append(job.Results, next)
+	}
+	stored := job.Results[first:]
+	s.mu.Unlock()
+
+	s.saveResults(job.ID, first, stored)
+
+	if job.each != nil {
+		for _, result := range ready {
+			job.each(result)
+		}
+	}
+}
+
+// release lets the next job of a language run.
+func (s *Scheduler) release(language string) {
+	s.mu.Lock()
+	delete(s.running, language)
+	s.mu.Unlock()
+	s.signal()
+}
+
+// requeueThrottled puts a job whose source started rate limiting us back at
+// the front of the queue, to resume once the source's backoff ends.
+func (s *Scheduler) requeueThrottled(job *Job, throttled *sources.ThrottledError) {
+	s.mu.Lock()
+	job.throttles++
+	job.Status = StatusThrottled
+	notBefore := throttled.RetryAt
+	job.NotBefore = &notBefore
+	s.queue = append([]*Job{job}, s.queue...)
+	s.mu.Unlock()
+
+	slog.Warn("job paused while source rate limits us", "job_id", job.ID, "language", job.Language, "done", job.Progress.Done, "words", len(job.Words), "not_before", notBefore.Format(time.RFC3339))
+}
+
+func (s *Scheduler) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// snapshot copies a job under the lock so callers can read it safely.
+func (s *Scheduler) snapshot(job *Job) *Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cp := *job
+	cp.Words = append([]string(nil), job.Words...)
+	cp.Results = append([]Result(nil), job.Results...)
+	cp.pending = nil
+	cp.each = nil
+	return &cp
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,196 @@
+package jobs
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	jobsBucket    = []byte("jobs")
+	resultsBucket = []byte("job_results") // one nested bucket per job, keyed by word index
+)
+
+// Persist keeps the scheduler's jobs in db, so they survive restarts and
+// deploys, and restores the jobs stored earlier. Jobs that hadn't finished
+// are queued again and resume at their first word without a result; a
+// streamed job resumes as a plain one, its reader being gone. Finished jobs
+// past their retention are dropped, as Run drops them later on. Call it
+// before Run and before submitting jobs.
+func (s *Scheduler) Persist(db *bolt.DB) error {
+	var restored []*Job
+	s.mu.Lock()
+	cutoff := time.Now().Add(-s.retention)
+	s.mu.Unlock()
+	err := db.Update(func(tx *bolt.Tx) error {
+		jobsB, err := tx.CreateBucketIfNotExists(jobsBucket)
+		if err != nil {
+			return err
+		}
+		resultsB, err := tx.CreateBucketIfNotExists(resultsBucket)
+		if err != nil {
+			return err
+		}
+
+		var expired [][]byte
+		err = jobsB.ForEach(func(k, v []byte) error {
+			var job Job
+			if err := json.Unmarshal(v, &job); err != nil {
+				return fmt.Errorf("job %s: %w", k, err)
+			}
+			if job.Finished() && job.FinishedAt != nil && job.FinishedAt.Before(cutoff) {
+				expired = append(expired, k)
+				return nil
+			}
+			if results := resultsB.Bucket(k); results != nil {
+				err := results.ForEach(func(_, v []byte) error {
+					var result Result
+					if err := json.Unmarshal(v, &result); err != nil {
+						return err
+					}
+					job.Results = append(job.Results, result)
+					return nil
+				})
+				if err != nil {
+					return fmt.Errorf("job %s results: %w", k, err)
+				}
+			}
+			restored = append(restored, &job)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		return deleteStored(jobsB, resultsB, expired)
+	})
+	if err != nil {
+		return fmt.Errorf("loading jobs: %w", err)
+	}
+
+	sort.Slice(restored, func(i, j int) bool { return restored[i].CreatedAt.Before(restored[j].CreatedAt) })
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resumed := 0
+	for _, job := range restored {
+		job.done = make(chan struct{})
+		if job.Finished() {
+			close(job.done)
+		} else {
+			// Results scraped ahead of an earlier word were lost with the
+			// process; count only the ones stored
+			job.Status = StatusQueued
+			job.NotBefore = nil
+			job.Progress = Progress{Done: len(job.Results), Total: len(job.Words)}
+			for _, result := range job.Results {
+				if result.Error != "" {
+					job.Progress.Failed++
+				}
+			}
+			s.queue = append(s.queue, job)
+			resumed++
+		}
+		s.jobs[job.ID] = job
+	}
+	s.db = db
+	if resumed > 0 {
+		slog.Info("resuming jobs stored before restart", "jobs", resumed)
+	}
+	return nil
+}
+
+// marshalJob encodes a job for saveJob, without its results, which are
+// stored one by one as they are scraped. s.mu must be held.
+func (s *Scheduler) marshalJob(job *Job) []byte {
+	if s.db == nil {
+		return nil
+	}
+	cp := *job
+	cp.Results = nil
+	data, err := json.Marshal(cp)
+	if err != nil {
+		slog.Error("encoding job", "job_id", job.ID, "error", err)
+		return nil
+	}
+	return data
+}
+
+// saveJob stores a job encoded by marshalJob. A job that can't be stored
+// still runs; it just won't survive a restart.
+func (s *Scheduler) saveJob(id string, data []byte) {
+	if data == nil {
+		return
+	}
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(jobsBucket).Put([]byte(id), data)
+	})
+	if err != nil {
+		slog.Error("saving job", "job_id", id, "error", err)
+	}
+}
+
+// saveResults stores the job's results from index first on.
+func (s *Scheduler) saveResults(id string, first int, results []Result) {
+	if s.db == nil || len(results) == 0 {
+		return
+	}
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.Bucket(resultsBucket).CreateBucketIfNotExists([]byte(id))
+		if err != nil {
+			return err
+		}
+		for i, result := range results {
+			data, err := json.Marshal(result)
+			if err != nil {
+				return err
+			}
+			key := make([]byte, 8)
+			binary.BigEndian.PutUint64(key, uint64(first+i))
+			if err := b.Put(key, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		slog.Error("saving job results", "job_id", id, "error", err)
+	}
+}
+
+// deleteJobs removes evicted jobs and their results from the database.
+func (s *Scheduler) deleteJobs(ids []string) {
+	if s.db == nil {
+		return
+	}
+	keys := make([][]byte, len(ids))
+	for i, id := range ids {
+		keys[i] = []byte(id)
+	}
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		return deleteStored(tx.Bucket(jobsBucket), tx.Bucket(resultsBucket), keys)
+	})
+	if err != nil {
+		slog.Error("deleting evicted jobs", "jobs", len(ids), "error", err)
+	}
+}
+
+// deleteStored removes the jobs with the given keys, and their results.
+func deleteStored(jobsB, resultsB *bolt.Bucket, keys [][]byte) error {
+	for _, k := range keys {
+		if err := jobsB.Delete(k); err != nil {
+			return err
+		}
+		if resultsB.Bucket(k) != nil {
+			if err := resultsB.DeleteBucket(k); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -1,19 +1,318 @@
 package main
 
 import (
-    "fmt"
-    "log"
+    "context"
+    "encoding/json"
+    "log/slog"
+    "net"
     "net/http"
+    "os"
+    _ "time/tzdata" // batch windows use named time zones; the runtime image has no zoneinfo
     
+    "github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/dictionary"
+    "github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/scrapers/wordforms"
+    "github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/sources"
+
+    "vocabulary-app/backend/go-service/archive"
+    "vocabulary-app/backend/go-service/client"
+    "vocabulary-app/backend/go-service/config"
+    "vocabulary-app/backend/go-service/deadletter"
+    "vocabulary-app/backend/go-service/delivery"
+    "vocabulary-app/backend/go-service/dictionaries"
+    "vocabulary-app/backend/go-service/events"
     "vocabulary-app/backend/go-service/handlers"
+    "vocabulary-app/backend/go-service/health"
+    "vocabulary-app/backend/go-service/logging"
+    "vocabulary-app/backend/go-service/middleware"
+    "vocabulary-app/backend/go-service/queue"
+    "vocabulary-app/backend/go-service/storage"
+    "vocabulary-app/backend/go-service/webhooks"
 )
 
 func main() {
-    
-    fmt.Println("Go server running")
+    cfg, err := config.Parse(os.Args[1:])
+    if err != nil {
+        fatal("loading configuration", err)
+    }
+    if err := logging.Setup(cfg.Log, os.Stderr); err != nil {
+        fatal("invalid logging configuration", err)
+    }
+
+    dictionary.Configure(cfg.Scraper.Settings())
+    // Latency and failures per language and scrape stage, for the admin metrics
+    handlers.EnableMetrics()
+    sources.Default.SetBrowserLimit(cfg.Scraper.MaxBrowsers)
+    sources.Default.SetUserAgent(cfg.Scraper.UserAgent)
+    if err := sources.Default.SetProxies(cfg.Scraper.Proxies, cfg.Scraper.ProxyRotate, cfg.Scraper.ProxyCooldown); err != nil {
+        fatal("invalid SCRAPER_PROXIES", err)
+    }
+    // Scrapers switched off in the config
+    for _, name := range cfg.Scraper.DisabledSources {
+        if err := sources.Default.SetSourceEnabled(name, false); err != nil {
+            fatal("invalid SCRAPER_DISABLED_SOURCES", err)
+        }
+    }
+    for _, language := range cfg.Scraper.DisabledLanguages {
+        if err := sources.Default.SetLanguageEnabled(language, false); err != nil {
+            fatal("invalid SCRAPER_DISABLED_LANGUAGES", err)
+        }
+    }
+    if err := wordforms.Configure(cfg.Scraper.LabelMappings); err != nil {
+        fatal("invalid SCRAPER_LABEL_MAPPINGS", err)
+    }
+
+    // One-shot mode scrapes -word and exits, without the database or the
+    // Python service, so it can run next to a serving instance
+    if cfg.OneShot.Word != "" {
+        os.Exit(scrapeOnce(cfg))
+    }
+
+    handlers.SetMaxJobWait(cfg.Server.MaxJobWait)
+    handlers.SetWriteTimeout(cfg.Server.WriteTimeout)
+    if err := handlers.ConfigureJobs(cfg.Jobs); err != nil {
+        fatal("invalid JOB_LANGUAGE_CONCURRENCY", err)
+    }
+
+    var pythonClient client.PythonClient
+    switch cfg.PythonService.Transport {
+    case "grpc":
+        grpcClient, err := client.NewGRPCPythonClient(cfg.PythonService, nil)
+        if err != nil {
+            fatal("connecting to the Python service over gRPC", err)
+        }
+        defer grpcClient.Close()
+        pythonClient = grpcClient
+    case "http":
+        pythonClient = client.NewPythonClient(cfg.PythonService, nil)
+    default:
+        slog.Error("unknown Python service transport (want http or grpc)", "transport", cfg.PythonService.Transport)
+        os.Exit(1)
+    }
+    handlers.SetPythonClient(pythonClient)
+
+    // Local state (outbox, webhooks, feature flags, jobs, dead letters) lives in one bolt database
+    db, err := storage.Open(cfg.DataPath)
+    if err != nil {
+        fatal("opening local database", err)
+    }
+    defer db.Close()
+
+    // Runtime changes to switched-off scrapers made by admins
+    if err := sources.Default.PersistFlags(db); err != nil {
+        fatal("loading feature flags", err)
+    }
+
+    // Batch jobs queued or running before a restart or crash pick up where they stopped
+    if err := handlers.PersistJobs(db); err != nil {
+        fatal("loading jobs", err)
+    }
+
+    // Deliveries, webhook notifications and jobs that failed for good, kept for admins to redrive
+    deadLetters, err := deadletter.NewStore(db)
+    if err != nil {
+        fatal("opening dead letters", err)
+    }
+    handlers.EnableDeadLetters(deadLetters)
+
+    if cfg.Webhooks.Enabled {
+        webhookStore, err := webhooks.NewStore(db)
+        if err != nil {
+            fatal("opening webhook store", err)
+        }
+        handlers.EnableWebhooks(webhookStore, cfg.Webhooks)
+        http.HandleFunc("POST /api/webhooks", middleware.RequireRole(middleware.RoleUser, handlers.CreateWebhookHandler))
+        http.HandleFunc("GET /api/webhooks", middleware.RequireRole(middleware.RoleUser, handlers.ListWebhooksHandler))
+        http.HandleFunc("DELETE /api/webhooks/{id}", middleware.RequireRole(middleware.RoleUser, handlers.DeleteWebhookHandler))
+    }
+
+    // Scraped entries go to the Python service; failed deliveries wait in the outbox
+    if cfg.Delivery.Enabled {
+        outbox, err := delivery.NewOutbox(db)
+        if err != nil {
+            fatal("opening delivery outbox", err)
+        }
+        deliverer := delivery.NewDeliverer(pythonClient, outbox, deadLetters, cfg.Delivery)
+        go deliverer.Run(context.Background())
+        handlers.SetDeliverer(deliverer)
+    }
+
+    // Optionally (or instead) publish scraped entries to NATS for queue consumers
+    if cfg.Queue.NATSURL != "" {
+        publisher, err := queue.Connect(cfg.Queue)
+        if err != nil {
+            fatal("connecting to NATS", err)
+        }
+        defer publisher.Close()
+        handlers.SetPublisher(publisher)
+    }
+
+    // Dictionary events (word_scraped, word_updated, scrape_failed) for analytics consumers
+    if len(cfg.Events.KafkaBrokers) > 0 {
+        emitter := events.NewEmitter(cfg.Events)
+        defer emitter.Close()
+        handlers.SetEmitter(emitter)
+    }
+
+    // Raw HTML snapshots, so entries can be re-parsed after a parser fix
+    if cfg.Archive.Backend != "" {
+        snapshots, err := archive.New(cfg.Archive)
+        if err != nil {
+            fatal("opening snapshot archive", err)
+        }
+        sources.Default.SetArchiver(snapshots)
+    }
 
-    http.HandleFunc("/api/scrape", handlers.ScrapeHandler)
+    // Pronunciation recordings: the proxy for the frontend, Forvo lookups for scrapes
+    if err := handlers.EnableAudio(cfg.Audio); err != nil {
+        fatal("setting up audio", err)
+    }
+
+    // Autocomplete: results are cached per prefix to spare the source
+    handlers.ConfigureSuggest(cfg.Suggest)
+
+    // Words looked up again and again, as in review sessions, are served from memory
+    handlers.ConfigureEntryCache(cfg.EntryCache)
+
+    // Scrapes without a language try these, after any the word's letters point to
+    handlers.ConfigureLanguageDetection(cfg.Scraper.DetectLanguages)
+    handlers.ConfigureLimits(cfg.Scraper.Limits())
+
+    // Text analysis looks up every distinct word of a text, so it is capped
+    handlers.ConfigureAnalyze(cfg.Analyze)
+
+    // Frequency ranks and CEFR levels, so decks can be sorted and filtered
+    if err := handlers.EnableFrequency(cfg.Frequency); err != nil {
+        fatal("loading frequency and CEFR lists", err)
+    }
+
+    // Offline dictionaries imported by admins, for sources that are unreliable or stubs
+    dictionaryStore, err := dictionaries.NewStore(db)
+    if err != nil {
+        fatal("opening dictionary store", err)
+    }
+    if err := handlers.EnableDictionaries(dictionaryStore, cfg.Dictionaries); err != nil {
+        fatal("setting up offline dictionaries", err)
+    }
+
+    // Probes: /healthz covers the process itself, /readyz also its downstream dependencies
+    chrome := health.Chrome
+    if cfg.Scraper.BrowserURL != "" {
+        chrome = health.RemoteChrome(cfg.Scraper.BrowserURL)
+    }
+    liveness := health.NewChecker(
+        health.Check{Name: "database", Critical: true, Run: health.Database(db)},
+        health.Check{Name: "chrome", Run: chrome},
+    )
+    readiness := liveness.With(health.Check{Name: "python_service", Critical: true, Run: pythonClient.Ping})
+    handlers.SetHealthCheckers(liveness, readiness)
+
+    // Scrape endpoints share one rate limiter per caller
+    limit := func(h http.HandlerFunc) http.HandlerFunc { return h }
+    if cfg.RateLimit.Enabled {
+        limiter := middleware.NewRateLimiter(cfg.RateLimit.RequestsPerSecond, cfg.RateLimit.Burst, cfg.RateLimit.IdleTTL)
+        limiter.SetAPIKeys(cfg.RateLimit.APIKeys)
+        limit = limiter.Limit
+    }
+
+    http.HandleFunc("GET /healthz", handlers.HealthzHandler)
+    http.HandleFunc("GET /readyz", handlers.ReadyzHandler)
+    http.HandleFunc("/api/scrape", limit(handlers.ScrapeHandler))
+    http.HandleFunc("POST /api/scrape/url", limit(handlers.ScrapeURLHandler))
     http.HandleFunc("/api/languages", handlers.LanguagesHandler)
+    http.HandleFunc("GET /api/openapi.json", handlers.OpenAPIHandler)
+    http.HandleFunc("GET /api/docs", handlers.APIDocsHandler)
+    http.HandleFunc("GET /api/sources", handlers.SourcesHandler)
+    http.HandleFunc("POST /api/jobs", limit(handlers.CreateJobHandler))
+    http.HandleFunc("POST /api/jobs/import", limit(handlers.ImportJobHandler))
+    http.HandleFunc("GET /api/jobs/{id}", handlers.GetJobHandler)
+    http.HandleFunc("GET /api/audio", handlers.AudioHandler)
+    http.HandleFunc("GET /api/suggest", handlers.SuggestHandler)
+    http.HandleFunc("POST /api/analyze", limit(handlers.AnalyzeHandler))
+    http.HandleFunc("POST /api/analyze/upload", limit(handlers.AnalyzeUploadHandler))
+    http.HandleFunc("POST /api/analyze/profile", limit(handlers.AnalyzeProfileHandler))
+    http.HandleFunc("GET /api/admin/stats", middleware.RequireRole(middleware.RoleAdmin, handlers.AdminStatsHandler))
+    http.HandleFunc("DELETE /api/admin/cache", middleware.RequireRole(middleware.RoleAdmin, handlers.PurgeCacheHandler))
+    http.HandleFunc("GET /api/admin/flags", middleware.RequireRole(middleware.RoleAdmin, handlers.FlagsHandler))
+    http.HandleFunc("PUT /api/admin/flags/sources/{name}", middleware.RequireRole(middleware.RoleAdmin, handlers.SetSourceFlagHandler))
+    http.HandleFunc("PUT /api/admin/flags/languages/{language}", middleware.RequireRole(middleware.RoleAdmin, handlers.SetLanguageFlagHandler))
+    http.HandleFunc("GET /api/admin/sources", middleware.RequireRole(middleware.RoleAdmin, handlers.SourceStatusHandler))
+    http.HandleFunc("PUT /api/admin/sources/{name}", middleware.RequireRole(middleware.RoleAdmin, handlers.SetSourceStatusHandler))
+    http.HandleFunc("PUT /api/admin/sources/{name}/languages/{language}", middleware.RequireRole(middleware.RoleAdmin, handlers.SetSourceLanguageStatusHandler))
+    http.HandleFunc("GET /api/admin/metrics", middleware.RequireRole(middleware.RoleAdmin, handlers.MetricsHandler))
+    http.HandleFunc("GET /api/admin/labels", middleware.RequireRole(middleware.RoleAdmin, handlers.UnrecognizedLabelsHandler))
+    http.HandleFunc("GET /api/admin/dead-letters", middleware.RequireRole(middleware.RoleAdmin, handlers.DeadLettersHandler))
+    http.HandleFunc("POST /api/admin/dead-letters/redrive", middleware.RequireRole(middleware.RoleAdmin, handlers.RedriveDeadLettersHandler))
+    http.HandleFunc("GET /api/admin/dead-letters/{id}", middleware.RequireRole(middleware.RoleAdmin, handlers.DeadLetterHandler))
+    http.HandleFunc("POST /api/admin/dead-letters/{id}/redrive", middleware.RequireRole(middleware.RoleAdmin, handlers.RedriveDeadLetterHandler))
+    http.HandleFunc("DELETE /api/admin/dead-letters/{id}", middleware.RequireRole(middleware.RoleAdmin, handlers.DeleteDeadLetterHandler))
+    http.HandleFunc("GET /api/admin/canaries", middleware.RequireRole(middleware.RoleAdmin, handlers.CanaryStatusHandler))
+    http.HandleFunc("GET /api/admin/dictionaries", middleware.RequireRole(middleware.RoleAdmin, handlers.DictionariesHandler))
+    http.HandleFunc("POST /api/admin/dictionaries/{language}", middleware.RequireRole(middleware.RoleAdmin, handlers.ImportDictionaryHandler))
+    http.HandleFunc("DELETE /api/admin/dictionaries/{language}/{name}", middleware.RequireRole(middleware.RoleAdmin, handlers.DeleteDictionaryHandler))
+
+    handlers.StartJobScheduler(context.Background())
+    // Frequent words are scraped ahead of time, while no one is looking words up
+    if cfg.EntryCache.Warm {
+        handlers.EnableCacheWarming(context.Background(), cfg.EntryCache)
+    }
+    if cfg.Canary.Enabled {
+        if err := handlers.EnableCanaries(context.Background(), cfg.Canary); err != nil {
+            fatal("invalid CANARY_WORDS", err)
+        }
+    }
+
+    // Attach user claims when a token is present; admin routes enforce roles with middleware.RequireRole
+    server := &http.Server{
+        Addr:              cfg.Server.Addr,
+        Handler:           middleware.RequestID(middleware.Compress(middleware.OptionalAuth(http.DefaultServeMux))),
+        ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+        ReadTimeout:       cfg.Server.ReadTimeout,
+        WriteTimeout:      cfg.Server.WriteTimeout,
+        IdleTimeout:       cfg.Server.IdleTimeout,
+        MaxHeaderBytes:    cfg.Server.MaxHeaderBytes,
+    }
+    server.SetKeepAlivesEnabled(cfg.Server.KeepAlives)
+    listener, err := (&net.ListenConfig{KeepAlive: cfg.Server.TCPKeepAlive}).Listen(context.Background(), "tcp", cfg.Server.Addr)
+    if err != nil {
+        fatal("listening on "+cfg.Server.Addr, err)
+    }
+    if cfg.Server.TLS() {
+        slog.Info("Go server running", "addr", cfg.Server.Addr, "tls", true)
+        fatal("HTTPS server stopped", server.ServeTLS(listener, cfg.Server.TLSCertFile, cfg.Server.TLSKeyFile))
+    }
+    slog.Info("Go server running", "addr", cfg.Server.Addr, "tls", false)
+    fatal("HTTP server stopped", server.Serve(listener))
+}
+
+// scrapeOnce scrapes cfg.OneShot.Word, prints its entry as JSON and returns
+// the exit code: 1 if the scrape failed or found no senses.
+func scrapeOnce(cfg *config.Config) int {
+    handlers.ConfigureLanguageDetection(cfg.Scraper.DetectLanguages)
+    handlers.ConfigureLimits(cfg.Scraper.Limits())
+    if err := handlers.EnableFrequency(cfg.Frequency); err != nil {
+        slog.Error("loading frequency and CEFR lists", "error", err)
+        return 1
+    }
+    entry, err := handlers.ScrapeWord(context.Background(), cfg.OneShot.Word, cfg.OneShot.Language)
+    if err != nil {
+        slog.Error("scrape failed", "word", cfg.OneShot.Word, "language", cfg.OneShot.Language, "error", err)
+        return 1
+    }
+    encoder := json.NewEncoder(os.Stdout)
+    encoder.SetIndent("", "  ")
+    if err := encoder.Encode(entry); err != nil {
+        slog.Error("writing entry", "error", err)
+        return 1
+    }
+    if len(entry.Senses) == 0 {
+        slog.Error("no senses found", "word", cfg.OneShot.Word, "language", entry.Language)
+        return 1
+    }
+    return 0
+}
 
-    log.Fatal(http.ListenAndServe(":8080", nil))
+// fatal logs err and exits; deferred cleanups are skipped, as with log.Fatal.
+func fatal(msg string, err error) {
+    slog.Error(msg, "error", err)
+    os.Exit(1)
 }
@@ -0,0 +1,151 @@
+// Package metrics keeps latency histograms and failure counts of scrape
+// stages per language, so slow or flaky scrapers show up at a glance in
+// the admin API. Counts cover the life of the process; word scrapes are
+// also counted per minute for the last 24 hours, with failure reasons.
+package metrics
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/sources"
+)
+
+// bucketBounds are the upper bounds of the histogram buckets; durations
+// above the last go into an overflow bucket.
+var bucketBounds = []time.Duration{
+	5 * time.Millisecond, 10 * time.Millisecond, 25 * time.Millisecond,
+	50 * time.Millisecond, 100 * time.Millisecond, 250 * time.Millisecond,
+	500 * time.Millisecond, time.Second, 2500 * time.Millisecond,
+	5 * time.Second, 10 * time.Second, 25 * time.Second, time.Minute,
+}
+
+// Stage summarizes the recorded durations of one stage in one language.
+// Percentiles are estimated from the histogram buckets.
+type Stage struct {
+	Language  string  `json:"language"`
+	Stage     string  `json:"stage"`
+	Count     uint64  `json:"count"`
+	Failures  uint64  `json:"failures"`
+	ErrorRate float64 `json:"error_rate"`
+	MeanMs    float64 `json:"mean_ms"`
+	P50Ms     float64 `json:"p50_ms"`
+	P90Ms     float64 `json:"p90_ms"`
+	P99Ms     float64 `json:"p99_ms"`
+	MaxMs     float64 `json:"max_ms"`
+}
+
+type key struct {
+	language, stage string
+}
+
+type histogram struct {
+	buckets  []uint64 // one per bound, and the overflow bucket
+	count    uint64
+	failures uint64
+	sum      time.Duration
+	max      time.Duration
+}
+
+// Recorder records stage durations. It implements sources.Recorder and is
+// safe for concurrent use.
+type Recorder struct {
+	mu     sync.Mutex
+	stages map[key]*histogram
+	totals map[string]*counts  // word scrapes by language
+	recent map[string][]counts // word scrapes by language and minute
+}
+
+// NewRecorder creates an empty recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{
+		stages: make(map[key]*histogram),
+		totals: make(map[string]*counts),
+		recent: make(map[string][]counts),
+	}
+}
+
+// Record adds a stage of a canonical language that took d and ended with
+// err.
+func (r *Recorder) Record(language, stage string, d time.Duration, err error) {
+	i := sort.Search(len(bucketBounds), func(i int) bool { return d <= bucketBounds[i] })
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	h, ok := r.stages[key{language, stage}]
+	if !ok {
+		h = &histogram{buckets: make([]uint64, len(bucketBounds)+1)}
+		r.stages[key{language, stage}] = h
+	}
+	h.buckets[i]++
+	h.count++
+	h.sum += d
+	h.max = max(h.max, d)
+	if err != nil {
+		h.failures++
+	}
+	if stage == sources.StageWord {
+		r.recordScrape(language, d, err, time.Now())
+	}
+}
+
+// Stages summarizes every recorded stage, sorted by language and stage.
+func (r *Recorder) Stages() []Stage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stages := make([]Stage, 0, len(r.stages))
+	for k, h := range r.stages {
+		stages = append(stages, Stage{
+			Language:  k.language,
+			Stage:     k.stage,
+			Count:     h.count,
+			Failures:  h.failures,
+			ErrorRate: float64(h.failures) / float64(h.count),
+			MeanMs:    ms(h.sum / time.Duration(h.count)),
+			P50Ms:     ms(h.quantile(0.5)),
+			P90Ms:     ms(h.quantile(0.9)),
+			P99Ms:     ms(h.quantile(0.99)),
+			MaxMs:     ms(h.max),
+		})
+	}
+	sort.Slice(stages, func(i, j int) bool {
+		if stages[i].Language != stages[j].Language {
+			return stages[i].Language < stages[j].Language
+		}
+		return stages[i].Stage < stages[j].Stage
+	})
+	return stages
+}
+
+// quantile estimates the q-th quantile by interpolating within the bucket
+// it falls in. The overflow bucket has no upper bound, so quantiles in it
+// are interpolated up to the largest duration seen.
+func (h *histogram) quantile(q float64) time.Duration {
+	rank := q * float64(h.count)
+	var seen uint64
+	for i, n := range h.buckets {
+		if n == 0 || float64(seen+n) < rank {
+			seen += n
+			continue
+		}
+		var lower, upper time.Duration
+		if i > 0 {
+			lower = bucketBounds[i-1]
+		}
+		if i < len(bucketBounds) {
+			upper = bucketBounds[i]
+		} else {
+			upper = h.max
+		}
+		// Nothing recorded is longer than the longest duration seen
+		upper = min(upper, h.max)
+		fraction := (rank - float64(seen)) / float64(n)
+		return lower + time.Duration(fraction*float64(upper-lower))
+	}
+	return h.max
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
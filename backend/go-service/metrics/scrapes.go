@@ -0,0 +1,138 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/sources"
+)
+
+// recentMinutes is how far back recent scrape counts reach, in the
+// one-minute buckets they are kept in.
+const recentMinutes = 24 * 60
+
+// Scrapes summarizes the word scrapes of one language over a span of time.
+type Scrapes struct {
+	Language string  `json:"language"`
+	Count    uint64  `json:"count"`
+	Failures uint64  `json:"failures"`
+	MeanMs   float64 `json:"mean_ms"`
+	// FailureReasons counts failures by FailureReason.
+	FailureReasons map[string]uint64 `json:"failure_reasons,omitempty"`
+}
+
+// counts are the word scrapes of one language in one minute, or in total.
+type counts struct {
+	minute   int64 // Unix minute of the bucket; unused for totals
+	count    uint64
+	failures uint64
+	sum      time.Duration
+	reasons  map[string]uint64
+}
+
+func (c *counts) add(d time.Duration, err error) {
+	c.count++
+	c.sum += d
+	if err != nil {
+		c.failures++
+		if c.reasons == nil {
+			c.reasons = map[string]uint64{}
+		}
+		c.reasons[FailureReason(err)]++
+	}
+}
+
+// FailureReason sorts a failed scrape into a few reasons worth telling
+// apart on a dashboard: "throttled", "budget", "disabled", "robots",
+// "invalid" (the entry failed validation), "timeout" and "other".
+func FailureReason(err error) string {
+	var invalid *models.ValidationError
+	switch {
+	case errors.Is(err, sources.ErrThrottled):
+		return "throttled"
+	case errors.Is(err, sources.ErrBudgetExhausted):
+		return "budget"
+	case errors.Is(err, sources.ErrDisabled):
+		return "disabled"
+	case errors.Is(err, sources.ErrDisallowed):
+		return "robots"
+	case errors.As(err, &invalid):
+		return "invalid"
+	case errors.Is(err, context.DeadlineExceeded) || isTimeout(err):
+		return "timeout"
+	}
+	return "other"
+}
+
+func isTimeout(err error) bool {
+	var timeout interface{ Timeout() bool }
+	return errors.As(err, &timeout) && timeout.Timeout()
+}
+
+// recordScrape adds a word scrape to the language's totals and to the
+// bucket of the current minute. r.mu must be held.
+func (r *Recorder) recordScrape(language string, d time.Duration, err error, now time.Time) {
+	total, ok := r.totals[language]
+	if !ok {
+		total = &counts{}
+		r.totals[language] = total
+	}
+	total.add(d, err)
+
+	recent, ok := r.recent[language]
+	if !ok {
+		recent = make([]counts, recentMinutes)
+		r.recent[language] = recent
+	}
+	minute := now.Unix() / 60
+	bucket := &recent[minute%recentMinutes]
+	if bucket.minute != minute {
+		*bucket = counts{minute: minute}
+	}
+	bucket.add(d, err)
+}
+
+// Scrapes summarizes the word scrapes of each language over the last
+// window, or since the process started when window is 0, sorted by
+// language. Windows are counted in whole minutes, up to 24 hours.
+func (r *Recorder) Scrapes(window time.Duration) []Scrapes {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	since := time.Now().Unix()/60 - int64(window/time.Minute)
+	summaries := make([]Scrapes, 0, len(r.totals))
+	for language, total := range r.totals {
+		sum := *total
+		if window > 0 {
+			sum = counts{}
+			for _, bucket := range r.recent[language] {
+				if bucket.count > 0 && bucket.minute > since {
+					sum.count += bucket.count
+					sum.failures += bucket.failures
+					sum.sum += bucket.sum
+					for reason, n := range bucket.reasons {
+						if sum.reasons == nil {
+							sum.reasons = map[string]uint64{}
+						}
+						sum.reasons[reason] += n
+					}
+				}
+			}
+		}
+		s := Scrapes{Language: language, Count: sum.count, Failures: sum.failures}
+		if sum.count > 0 {
+			s.MeanMs = ms(sum.sum / time.Duration(sum.count))
+		}
+		if len(sum.reasons) > 0 {
+			s.FailureReasons = make(map[string]uint64, len(sum.reasons))
+			for reason, n := range sum.reasons {
+				s.FailureReasons[reason] = n
+			}
+		}
+		summaries = append(summaries, s)
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Language < summaries[j].Language })
+	return summaries
+}
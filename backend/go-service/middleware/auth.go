@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"vocabulary-app/backend/go-service/requestid"
+)
+
+// Claims mirrors the payload of the JWTs issued by the Python service.
+type Claims struct {
+	ID    int    `json:"id"`
+	Email string `json:"email"`
+	Type  string `json:"type"`
+	Role  string `json:"role"`
+	Exp   int64  `json:"exp"`
+}
+
+// Roles from least to most privileged.
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+var roleRank = map[string]int{RoleUser: 0, RoleAdmin: 1}
+
+type claimsKey struct{}
+
+// secretKey is shared with the Python service, which signs the tokens.
+var secretKey = []byte(os.Getenv("SECRET_KEY"))
+
+// ClaimsFromContext returns the authenticated user's claims, if any.
+func ClaimsFromContext(ctx context.Context) (Claims, bool) {
+	c, ok := ctx.Value(claimsKey{}).(Claims)
+	return c, ok
+}
+
+// RequireRole only lets requests through that carry a valid token with at
+// least the given role. Responds 401 for missing/invalid tokens and 403 for
+// insufficient roles.
+func RequireRole(role string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, err := claimsFromRequest(r)
+		if err != nil {
+			writeJSONError(w, r, http.StatusUnauthorized, err.Error())
+			return
+		}
+		if roleRank[claims.Role] < roleRank[role] {
+			writeJSONError(w, r, http.StatusForbidden, "insufficient permissions")
+			return
+		}
+		next(w, r.WithContext(context.WithValue(r.Context(), claimsKey{}, claims)))
+	}
+}
+
+// OptionalAuth attaches claims to the request context when a valid token is
+// present, and lets anonymous requests through unchanged.
+func OptionalAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if claims, err := claimsFromRequest(r); err == nil {
+			r = r.WithContext(context.WithValue(r.Context(), claimsKey{}, claims))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func claimsFromRequest(r *http.Request) (Claims, error) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return Claims{}, errors.New("authorization header missing")
+	}
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return Claims{}, errors.New("invalid authorization header format")
+	}
+	return ParseToken(token)
+}
+
+// ParseToken verifies an HS256 token against SECRET_KEY and returns its claims.
+func ParseToken(token string) (Claims, error) {
+	var claims Claims
+	if len(secretKey) == 0 {
+		return claims, errors.New("token verification not configured")
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return claims, errors.New("invalid token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil || header.Alg != "HS256" {
+		return claims, errors.New("invalid token")
+	}
+
+	mac := hmac.New(sha256.New, secretKey)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(sig, mac.Sum(nil)) {
+		return claims, errors.New("invalid token")
+	}
+
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return claims, errors.New("invalid token")
+	}
+	if claims.Exp != 0 && time.Now().Unix() >= claims.Exp {
+		return claims, errors.New("token has expired")
+	}
+	// Tokens issued before roles existed count as regular users
+	if claims.Role == "" {
+		claims.Role = RoleUser
+	}
+	return claims, nil
+}
+
+func decodeSegment(seg string, v interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
+
+func writeJSONError(w http.ResponseWriter, r *http.Request, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":      msg,
+		"request_id": requestid.FromContext(r.Context()),
+	})
+}
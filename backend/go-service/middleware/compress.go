@@ -0,0 +1,159 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// minCompressSize is the smallest body worth gzipping; below it the gzip
+// header and checksum outweigh the savings.
+const minCompressSize = 1024
+
+var gzipWriters = sync.Pool{
+	New: func() any { return gzip.NewWriter(nil) },
+}
+
+// Compress buffers each response, tags successful GET and HEAD responses
+// with an ETag (answering a matching If-None-Match with 304 Not Modified),
+// and gzips bodies for clients that accept it. The ETag is weak because
+// it identifies the content regardless of encoding. Handlers that flush
+// (streamed NDJSON) stop the buffering: what they wrote so far and
+// everything after is passed through as is.
+func Compress(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		buf := &bufferedResponse{w: w, status: http.StatusOK}
+		next.ServeHTTP(buf, r)
+		if buf.streaming {
+			return
+		}
+
+		h := w.Header()
+		body := buf.body.Bytes()
+
+		if (r.Method == http.MethodGet || r.Method == http.MethodHead) && buf.status == http.StatusOK {
+			sum := sha256.Sum256(body)
+			etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+			h.Set("ETag", etag)
+			if etagMatches(r.Header.Get("If-None-Match"), etag) {
+				h.Del("Content-Type")
+				h.Del("Content-Length")
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+
+		if len(body) < minCompressSize || h.Get("Content-Encoding") != "" || precompressed(h.Get("Content-Type")) || !acceptsGzip(r) {
+			w.WriteHeader(buf.status)
+			w.Write(body)
+			return
+		}
+
+		h.Set("Content-Encoding", "gzip")
+		h.Del("Content-Length")
+		w.WriteHeader(buf.status)
+		gz := gzipWriters.Get().(*gzip.Writer)
+		defer gzipWriters.Put(gz)
+		gz.Reset(w)
+		gz.Write(body)
+		gz.Close()
+	})
+}
+
+// precompressed reports whether a content type is already compressed (e.g.
+// proxied audio), so gzip would only cost CPU.
+func precompressed(contentType string) bool {
+	for _, prefix := range []string{"audio/", "video/", "image/", "application/ogg"} {
+		if strings.HasPrefix(contentType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// bufferedResponse collects a handler's response so it can be hashed and
+// compressed as a whole, until the handler flushes. Headers go straight to
+// the real writer.
+type bufferedResponse struct {
+	w           http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+	streaming   bool // flushed: writes go straight to w
+}
+
+func (b *bufferedResponse) Header() http.Header {
+	return b.w.Header()
+}
+
+func (b *bufferedResponse) WriteHeader(status int) {
+	if b.wroteHeader {
+		return
+	}
+	b.wroteHeader = true
+	b.status = status
+}
+
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	b.wroteHeader = true
+	if b.streaming {
+		return b.w.Write(p)
+	}
+	return b.body.Write(p)
+}
+
+// FlushError sends what was buffered and switches to passing writes
+// through, so a streamed response reaches the client as it is written.
+func (b *bufferedResponse) FlushError() error {
+	if !b.streaming {
+		b.streaming = true
+		b.w.WriteHeader(b.status)
+		if _, err := b.w.Write(b.body.Bytes()); err != nil {
+			return err
+		}
+		b.body = bytes.Buffer{}
+	}
+	return http.NewResponseController(b.w).Flush()
+}
+
+// Unwrap gives http.ResponseController the real writer, so handlers can
+// extend their write deadline.
+func (b *bufferedResponse) Unwrap() http.ResponseWriter {
+	return b.w
+}
+
+// acceptsGzip reports whether the client listed gzip in Accept-Encoding
+// without refusing it (q=0).
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+			continue
+		}
+		q := strings.ReplaceAll(params, " ", "")
+		return q != "q=0" && q != "q=0.0" && q != "q=0.00" && q != "q=0.000"
+	}
+	return false
+}
+
+// etagMatches implements If-None-Match's weak comparison against etag.
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"vocabulary-app/backend/go-service/requestid"
+)
+
+// RateLimiter is a token-bucket limiter keyed by user, API key, or client IP.
+type RateLimiter struct {
+	rate    float64       // tokens added per second
+	burst   float64       // bucket capacity
+	idleTTL time.Duration // how long an untouched bucket is kept before being pruned
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	apiKeys map[string]bool // SHA-256 of the API keys with their own bucket
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a limiter allowing rps requests per second per key,
+// with bursts up to burst requests. Buckets untouched for idleTTL are pruned.
+func NewRateLimiter(rps float64, burst int, idleTTL time.Duration) *RateLimiter {
+	return &RateLimiter{
+		rate:    rps,
+		burst:   float64(burst),
+		idleTTL: idleTTL,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// SetAPIKeys gives requests carrying one of keys in X-API-Key a bucket per
+// key. Other keys are ignored, so a client can't get a fresh bucket by
+// making one up. Call it before serving requests.
+func (rl *RateLimiter) SetAPIKeys(keys []string) {
+	rl.apiKeys = make(map[string]bool, len(keys))
+	for _, key := range keys {
+		rl.apiKeys[hashKey(key)] = true
+	}
+}
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Allow reports whether a request for key may proceed, and if not, how long
+// until the next token is available.
+func (rl *RateLimiter) Allow(key string) (bool, time.Duration) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := time.Now()
+	b, ok := rl.buckets[key]
+	if !ok {
+		rl.prune(now)
+		b = &bucket{tokens: rl.burst, last: now}
+		rl.buckets[key] = b
+	}
+
+	b.tokens = math.Min(rl.burst, b.tokens+now.Sub(b.last).Seconds()*rl.rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - b.tokens) / rl.rate * float64(time.Second))
+	return false, wait
+}
+
+// prune drops buckets that have been idle long enough to be full again.
+func (rl *RateLimiter) prune(now time.Time) {
+	for k, b := range rl.buckets {
+		if now.Sub(b.last) > rl.idleTTL {
+			delete(rl.buckets, k)
+		}
+	}
+}
+
+// Limit wraps a handler with the rate limiter, responding 429 with a JSON
+// error when the caller's bucket is empty.
+func (rl *RateLimiter) Limit(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ok, wait := rl.Allow(rl.key(r))
+		if !ok {
+			retryAfter := int(math.Ceil(wait.Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error":       "rate limit exceeded",
+				"retry_after": retryAfter,
+				"request_id":  requestid.FromContext(r.Context()),
+			})
+			return
+		}
+		next(w, r)
+	}
+}
+
+// key identifies the caller: authenticated user first, then a configured
+// API key, then client IP.
+func (rl *RateLimiter) key(r *http.Request) string {
+	if claims, ok := ClaimsFromContext(r.Context()); ok {
+		return fmt.Sprintf("user:%d", claims.ID)
+	}
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		if hashed := hashKey(key); rl.apiKeys[hashed] {
+			return "key:" + hashed
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
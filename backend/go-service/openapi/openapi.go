@@ -0,0 +1,48 @@
+// Package openapi holds the OpenAPI description of the service's HTTP API,
+// the contract the frontend and the Python service are written against.
+// openapi.json is maintained by hand alongside the handlers.
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"vocabulary-app/backend/go-service/events"
+)
+
+//go:embed openapi.json
+var document []byte
+
+// Spec returns the OpenAPI document as JSON. Its WordEntry schema is taken
+// from the word_entry event schema, so scrape responses and events are
+// described once.
+func Spec() ([]byte, error) {
+	var spec map[string]interface{}
+	if err := json.Unmarshal(document, &spec); err != nil {
+		return nil, fmt.Errorf("parsing openapi.json: %w", err)
+	}
+	raw, err := events.Schemas.ReadFile("schemas/word_entry.v1.json")
+	if err != nil {
+		return nil, err
+	}
+	// The event schema's $defs become component schemas of their own
+	raw = []byte(strings.ReplaceAll(string(raw), `"#/$defs/`, `"#/components/schemas/`))
+	var entry map[string]interface{}
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return nil, fmt.Errorf("parsing word_entry.v1.json: %w", err)
+	}
+
+	schemas := spec["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	if defs, ok := entry["$defs"].(map[string]interface{}); ok {
+		for name, def := range defs {
+			schemas[name] = def
+		}
+	}
+	delete(entry, "$defs")
+	delete(entry, "$schema")
+	delete(entry, "$id")
+	schemas["WordEntry"] = entry
+	return json.Marshal(spec)
+}
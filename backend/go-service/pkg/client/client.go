@@ -0,0 +1,160 @@
+// Package client calls the vocabulary app's HTTP APIs from Go programs and
+// integration tests: the Go service for scraping, and the Python service
+// (the app's API) for reviewing a user's learning queue.
+//
+//	c := client.New(client.Config{ScraperURL: "http://localhost:8080"}, nil)
+//	entry, err := c.Scrape(ctx, "hunder", "no-bm")
+//
+// Failed requests return an *Error with the status code and message.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+)
+
+// Config locates the services and authenticates to them.
+type Config struct {
+	// ScraperURL is the base URL of the Go service, e.g.
+	// http://localhost:8080.
+	ScraperURL string
+	// APIURL is the base URL of the Python service, e.g.
+	// http://localhost:8000. Only the review methods use it.
+	APIURL string
+	// Token is a user's bearer token from the Python service's login. The
+	// review methods need it; scraping works without.
+	Token string
+	// Timeout bounds each request; 0 leaves it to ctx and the HTTP client.
+	Timeout time.Duration
+}
+
+// Client calls the services. It is safe for concurrent use.
+type Client struct {
+	scraperURL string
+	apiURL     string
+	token      string
+	timeout    time.Duration
+	httpClient *http.Client
+}
+
+// New creates a client. httpClient may be nil to use http.DefaultClient.
+func New(cfg Config, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		scraperURL: strings.TrimRight(cfg.ScraperURL, "/"),
+		apiURL:     strings.TrimRight(cfg.APIURL, "/"),
+		token:      cfg.Token,
+		timeout:    cfg.Timeout,
+		httpClient: httpClient,
+	}
+}
+
+// Error is a request the service answered with an error status.
+type Error struct {
+	StatusCode int
+	// Message is the service's error message: the plain-text body of the
+	// Go service, or the "detail" of the Python service.
+	Message string
+	// RetryAfter is how long the service asked us to wait, for 429 and 503.
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// Scrape scrapes a word, detecting its language when language is empty.
+func (c *Client) Scrape(ctx context.Context, word, language string) (models.WordEntry, error) {
+	query := url.Values{"word": {word}}
+	if language != "" {
+		query.Set("language", language)
+	}
+	var entry models.WordEntry
+	err := c.do(ctx, http.MethodGet, c.scraperURL+"/api/scrape?"+query.Encode(), nil, &entry)
+	return entry, err
+}
+
+// Languages lists the canonical codes of the languages that can be scraped.
+func (c *Client) Languages(ctx context.Context) ([]string, error) {
+	var resp struct {
+		Languages []string `json:"languages"`
+	}
+	err := c.do(ctx, http.MethodGet, c.scraperURL+"/api/languages", nil, &resp)
+	return resp.Languages, err
+}
+
+// do sends a request with body encoded as JSON, if not nil, and decodes the
+// JSON response into v.
+func (c *Client) do(ctx context.Context, method, rawURL string, body, v interface{}) error {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encoding request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return responseError(resp)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
+
+// responseError reads the error message of a failed response.
+func responseError(resp *http.Response) error {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	e := &Error{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(data))}
+	var detail struct {
+		Detail interface{} `json:"detail"`
+	}
+	if json.Unmarshal(data, &detail) == nil && detail.Detail != nil {
+		if s, ok := detail.Detail.(string); ok {
+			e.Message = s
+		}
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		e.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	return e
+}
+
+// errNoAPIURL is returned by the review methods when Config.APIURL is unset.
+var errNoAPIURL = errors.New("client: APIURL is not configured")
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Difficulty is how hard a word was to recall, for scheduling its next review.
+type Difficulty string
+
+const (
+	Easy   Difficulty = "easy"
+	Medium Difficulty = "medium"
+	Hard   Difficulty = "hard"
+)
+
+// DueWord is a word in the user's learning queue that is due for review.
+type DueWord struct {
+	WordID        int     `json:"word_id"`
+	Word          string  `json:"word"`
+	Pronunciation string  `json:"pronunciation,omitempty"`
+	WordType      string  `json:"wordtype_name,omitempty"`
+	Language      string  `json:"language_name,omitempty"`
+	Status        string  `json:"status"`
+	EaseFactor    float64 `json:"ease_factor"`
+	IntervalDays  int     `json:"interval_days"`
+	Repetitions   int     `json:"repetitions"`
+	// NextReview is the scheduled time in the Python service's local time,
+	// e.g. "2026-10-15T09:30:00"; empty for words not reviewed yet.
+	NextReview string    `json:"next_review,omitempty"`
+	Meanings   []Meaning `json:"meanings"`
+}
+
+// Meaning is one stored meaning of a word.
+type Meaning struct {
+	ID         int    `json:"id"`
+	Definition string `json:"definition"`
+	Note       string `json:"note,omitempty"`
+}
+
+// ReviewResult is the schedule of a word after a review.
+type ReviewResult struct {
+	// NextReview is when the word is due again, as for DueWord.
+	NextReview   string  `json:"next_review"`
+	IntervalDays int     `json:"interval_days"`
+	Status       string  `json:"status"`
+	Accuracy     float64 `json:"accuracy"`
+	StreakInfo   struct {
+		CurrentStreak int `json:"current_streak"`
+	} `json:"streak_info"`
+}
+
+// Due lists up to limit words due for review, most overdue first.
+func (c *Client) Due(ctx context.Context, limit int) ([]DueWord, error) {
+	if c.apiURL == "" {
+		return nil, errNoAPIURL
+	}
+	query := url.Values{"limit": {strconv.Itoa(limit)}}
+	var resp struct {
+		Words []DueWord `json:"words"`
+	}
+	err := c.do(ctx, http.MethodGet, c.apiURL+"/review/due?"+query.Encode(), nil, &resp)
+	return resp.Words, err
+}
+
+// Review records whether the user recalled a word and how hard it was, and
+// returns when it is due again.
+func (c *Client) Review(ctx context.Context, wordID int, correct bool, difficulty Difficulty) (ReviewResult, error) {
+	if c.apiURL == "" {
+		return ReviewResult{}, errNoAPIURL
+	}
+	body := map[string]interface{}{
+		"word_id":    wordID,
+		"correct":    correct,
+		"difficulty": difficulty,
+	}
+	var result ReviewResult
+	err := c.do(ctx, http.MethodPost, c.apiURL+"/review/submit", body, &result)
+	return result, err
+}
@@ -0,0 +1,108 @@
+// Package dictionary scrapes dictionary entries for every supported
+// language, picking the scraper by language code. It is the entry point
+// for programs that use the scrapers as a library:
+//
+//	dictionary.Configure(scrapers.DefaultSettings())
+//	entry, err := dictionary.Scrape("hunder", "nb")
+//
+// Languages may be given as any code langcode.Normalize accepts. Requests
+// to the sources go through sources.Default, which rate-limits them and
+// can switch sources off.
+package dictionary
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/langcode"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/scrapers"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/scrapers/bokmal_scraper"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/scrapers/english_scraper"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/scrapers/german_scraper"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/scrapers/nynorsk_scraper"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/scrapers/spanish_scraper"
+)
+
+// Configure applies s to the scrapers that take settings. Call it before
+// scraping starts; until then the scrapers use scrapers.DefaultSettings.
+func Configure(s scrapers.Settings) {
+	bokmal_scraper.Configure(s)
+	nynorsk_scraper.Configure(s)
+}
+
+// Scrape scrapes a word from the source for a language and returns its
+// entry, with Language set to the canonical code and its text cleaned by
+// WordEntry.NormalizeText. Multi-word input is scraped as a phrase. The
+// word is scraped as given: use ResolveLemma first for inflected forms.
+func Scrape(word, language string) (models.WordEntry, error) {
+	canonical, ok := langcode.Normalize(language)
+	if !ok {
+		return models.WordEntry{}, fmt.Errorf("unsupported language: %s", language)
+	}
+	phrase := strings.Contains(word, " ")
+	var entry models.WordEntry
+	var err error
+	switch canonical {
+	case langcode.Bokmal:
+		if phrase {
+			entry, err = bokmal_scraper.ScrapePhrase(word)
+		} else {
+			entry, err = bokmal_scraper.ScrapeWord(word)
+		}
+	case langcode.Nynorsk:
+		if phrase {
+			entry, err = nynorsk_scraper.ScrapePhrase(word)
+		} else {
+			entry, err = nynorsk_scraper.ScrapeWord(word)
+		}
+	case langcode.English:
+		entry, err = english_scraper.ScrapeWord(word)
+	case langcode.Spanish:
+		entry, err = spanish_scraper.ScrapeWord(word)
+	case langcode.German:
+		entry, err = german_scraper.ScrapeWord(word)
+	}
+	entry.NormalizeText()
+	entry.Language = canonical
+	return entry, err
+}
+
+// ResolveLemma maps an inflected form to its lemma ("hunder" -> "hund")
+// for languages whose source can look forms up. Lemmas, phrases, unknown
+// words and words in other languages are returned unchanged.
+func ResolveLemma(word, language string) (string, error) {
+	canonical, ok := langcode.Normalize(language)
+	if !ok {
+		return "", fmt.Errorf("unsupported language: %s", language)
+	}
+	if strings.Contains(word, " ") {
+		// Phrases have no inflected forms to resolve
+		return word, nil
+	}
+	switch canonical {
+	case langcode.Bokmal:
+		return bokmal_scraper.ResolveLemma(word)
+	case langcode.Nynorsk:
+		return nynorsk_scraper.ResolveLemma(word)
+	default:
+		return word, nil
+	}
+}
+
+// Suggest returns up to n words starting with prefix, from the source's
+// autocomplete. Languages whose source has none return no words.
+func Suggest(prefix, language string, n int) ([]string, error) {
+	canonical, ok := langcode.Normalize(language)
+	if !ok {
+		return nil, fmt.Errorf("unsupported language: %s", language)
+	}
+	switch canonical {
+	case langcode.Bokmal:
+		return bokmal_scraper.Suggest(prefix, n)
+	case langcode.Nynorsk:
+		return nynorsk_scraper.Suggest(prefix, n)
+	default:
+		return []string{}, nil
+	}
+}
@@ -0,0 +1,57 @@
+// Package langcode normalizes the language codes and names requests use
+// (BCP-47 tags such as "nb-NO", ISO 639 codes such as "nob", names such as
+// "norwegian bokmål") to the canonical codes used throughout the service.
+// Normalize input once where it enters the service and compare against the
+// constants below everywhere else.
+package langcode
+
+import "strings"
+
+// Canonical language codes.
+const (
+	Bokmal  = "no-bm"
+	Nynorsk = "no-nn"
+	English = "en"
+	Spanish = "es"
+	German  = "de"
+)
+
+// Supported lists the canonical codes in display order.
+var Supported = []string{Bokmal, Nynorsk, English, Spanish, German}
+
+// aliases maps lowercased codes and names to canonical codes. Plain "no"
+// (Norwegian) and "nor" mean Bokmål, the standard most learners use.
+var aliases = map[string]string{
+	// Norwegian Bokmål
+	"no-bm": Bokmal, "nb": Bokmal, "nob": Bokmal, "no": Bokmal, "nor": Bokmal,
+	"no-nb": Bokmal, "bokmal": Bokmal, "bokmål": Bokmal,
+	"norwegian": Bokmal, "norwegian bokmal": Bokmal, "norwegian bokmål": Bokmal,
+	"norsk": Bokmal, "norsk bokmål": Bokmal,
+	// Norwegian Nynorsk
+	"no-nn": Nynorsk, "nn": Nynorsk, "nno": Nynorsk, "nynorsk": Nynorsk,
+	"norwegian nynorsk": Nynorsk, "norsk nynorsk": Nynorsk,
+	// English
+	"en": English, "eng": English, "english": English,
+	// Spanish
+	"es": Spanish, "spa": Spanish, "spanish": Spanish, "español": Spanish, "espanol": Spanish,
+	// German
+	"de": German, "deu": German, "ger": German, "german": German, "deutsch": German,
+}
+
+// Normalize returns the canonical code for a language code, BCP-47 tag or
+// name, and false when it names no supported language. Case, surrounding
+// spaces and "_" for "-" don't matter, and region, script and other
+// subtags of a tag are dropped ("nb-NO", "en_GB", "de-AT"); the service's
+// own "no-bm" and "no-nn" are codes of their own.
+func Normalize(code string) (string, bool) {
+	code = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(code)), "_", "-")
+	if canonical, ok := aliases[code]; ok {
+		return canonical, true
+	}
+	primary, _, found := strings.Cut(code, "-")
+	if !found {
+		return "", false
+	}
+	canonical, ok := aliases[primary]
+	return canonical, ok
+}
@@ -0,0 +1,73 @@
+package models
+
+// CompactEntry is the flattened, card-oriented view of a WordEntry
+// (?view=compact), sized for mobile clients.
+type CompactEntry struct {
+	Word     string          `json:"word"`
+	Cards    []CompactCard   `json:"cards"`
+	Warnings []ScrapeWarning `json:"warnings,omitempty"`
+}
+
+// CompactCard summarises one sense: what a flashcard needs and nothing more.
+type CompactCard struct {
+	SenseID       string       `json:"sense_id"`
+	POS           string       `json:"pos"`
+	PartOfSpeech  PartOfSpeech `json:"part_of_speech,omitempty"`
+	Gender        string       `json:"gender,omitempty"`
+	Pronunciation string       `json:"pronunciation,omitempty"`
+	Definition    string       `json:"definition,omitempty"`
+	Examples      []string     `json:"examples,omitempty"`
+	Forms         []string     `json:"forms,omitempty"`
+}
+
+const (
+	compactMaxExamples = 2
+	compactMaxForms    = 4
+)
+
+// ToCompact flattens a WordEntry into one card per sense, keeping the top
+// definition, the first pronunciation, up to two examples, and the first
+// form of the leading inflection rows.
+func ToCompact(entry WordEntry) CompactEntry {
+	compact := CompactEntry{Word: entry.Word, Cards: []CompactCard{}, Warnings: entry.Warnings}
+
+	for _, sense := range entry.Senses {
+		card := CompactCard{
+			SenseID:      sense.ID,
+			POS:          sense.Category,
+			PartOfSpeech: sense.PartOfSpeech,
+			Gender:       sense.Gender,
+		}
+
+		if len(sense.Pronunciations) > 0 {
+			card.Pronunciation = sense.Pronunciations[0].Text
+		} else if len(entry.Pronunciations) > 0 {
+			card.Pronunciation = entry.Pronunciations[0].Text
+		}
+		if len(sense.Meanings) > 0 {
+			card.Definition = sense.Meanings[0].Description
+		}
+		// Examples may be spread over meanings; take the first ones found
+		for _, m := range sense.Meanings {
+			for _, ex := range m.Examples {
+				if len(card.Examples) < compactMaxExamples {
+					card.Examples = append(card.Examples, ex)
+				}
+			}
+		}
+
+		seen := map[string]bool{}
+		for _, wf := range sense.WordForms {
+			if len(card.Forms) == compactMaxForms {
+				break
+			}
+			if len(wf.Forms) > 0 && !seen[wf.Forms[0]] {
+				seen[wf.Forms[0]] = true
+				card.Forms = append(card.Forms, wf.Forms[0])
+			}
+		}
+
+		compact.Cards = append(compact.Cards, card)
+	}
+	return compact
+}
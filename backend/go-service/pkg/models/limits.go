@@ -0,0 +1,61 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Limits caps the size of an entry, so a pathological one (hundreds of
+// senses, examples or inflection rows) doesn't blow up responses. Zero
+// means no limit.
+type Limits struct {
+	MaxSenses             int
+	MaxExamplesPerMeaning int
+	// MaxForms caps the inflection rows of each sense.
+	MaxForms int
+}
+
+// ApplyLimits cuts the entry down to l, keeping the first senses, examples
+// and inflection rows, and adds a truncated warning saying what was left
+// out. What it changes is copied first, so an entry that shares its slices
+// with a cached one can be limited without changing the cached one.
+func (e *WordEntry) ApplyLimits(l Limits) {
+	var cut []string
+	if l.MaxSenses > 0 && len(e.Senses) > l.MaxSenses {
+		cut = append(cut, fmt.Sprintf("%d of %d senses", len(e.Senses)-l.MaxSenses, len(e.Senses)))
+		e.Senses = e.Senses[:l.MaxSenses]
+	}
+	examples, forms := 0, 0
+	senses := slices.Clone(e.Senses)
+	for i := range senses {
+		s := &senses[i]
+		if l.MaxForms > 0 && len(s.WordForms) > l.MaxForms {
+			forms += len(s.WordForms) - l.MaxForms
+			s.WordForms = s.WordForms[:l.MaxForms:l.MaxForms]
+		}
+		if l.MaxExamplesPerMeaning == 0 {
+			continue
+		}
+		s.Meanings = slices.Clone(s.Meanings)
+		for j := range s.Meanings {
+			m := &s.Meanings[j]
+			if len(m.Examples) > l.MaxExamplesPerMeaning {
+				examples += len(m.Examples) - l.MaxExamplesPerMeaning
+				m.Examples = m.Examples[:l.MaxExamplesPerMeaning:l.MaxExamplesPerMeaning]
+			}
+		}
+	}
+	e.Senses = senses
+	if examples > 0 {
+		cut = append(cut, fmt.Sprintf("%d example(s)", examples))
+	}
+	if forms > 0 {
+		cut = append(cut, fmt.Sprintf("%d inflection row(s)", forms))
+	}
+	if len(cut) > 0 {
+		// Appending must not write into a backing array shared with the cached entry
+		e.Warnings = slices.Clip(e.Warnings)
+		e.Warn(WarningTruncated, "", fmt.Errorf("left out %s", strings.Join(cut, ", ")))
+	}
+}
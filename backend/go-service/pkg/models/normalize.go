@@ -0,0 +1,76 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/langcode"
+)
+
+// apostrophes are typographic apostrophes that keyboards and autocorrect
+// put in place of "'", which is what the sources spell words with.
+var apostrophes = strings.NewReplacer("’", "'", "‘", "'", "ʼ", "'", "´", "'", "`", "'")
+
+// norwegianLetters replaces the Swedish and German letters people type for
+// æ and ø on keyboards without them.
+var norwegianLetters = strings.NewReplacer("ä", "æ", "ö", "ø")
+
+// NormalizeWord prepares user input in a canonical language for lookup:
+// surrounding and repeated spaces are dropped, letters are composed (NFC),
+// so a decomposed "å" matches the source's, and typographic apostrophes
+// become "'". Words are lowercased except in German, where the case of a
+// word's first letter tells nouns from other words ("Essen", "essen").
+// Norwegian input also gets ä and ö read as æ and ø.
+func NormalizeWord(language, word string) string {
+	word = norm.NFC.String(strings.Join(strings.Fields(word), " "))
+	word = apostrophes.Replace(word)
+	switch language {
+	case langcode.German:
+		return word
+	case langcode.Bokmal, langcode.Nynorsk:
+		return norwegianLetters.Replace(strings.ToLower(word))
+	default:
+		return strings.ToLower(word)
+	}
+}
+
+// MaxWordLength is the most characters a word or phrase to look up may have;
+// the longest headwords and fixed expressions are well under it.
+const MaxWordLength = 100
+
+// wordPunctuation is the punctuation headwords are spelled with: hyphens,
+// apostrophes (typographic ones too), and the dots and commas of
+// abbreviations and phrases.
+const wordPunctuation = "-'.,’‘ʼ´`"
+
+// CheckWord reports why input can't be a word or phrase to look up: it is
+// empty, longer than MaxWordLength, not UTF-8, or has a character no
+// headword has, such as a control character, markup or URL and path syntax.
+// Letters, combining marks, digits, spaces and wordPunctuation are allowed.
+func CheckWord(word string) error {
+	if strings.TrimSpace(word) == "" {
+		return fmt.Errorf("word is empty")
+	}
+	if !utf8.ValidString(word) {
+		return fmt.Errorf("word is not valid UTF-8")
+	}
+	if n := utf8.RuneCountInString(word); n > MaxWordLength {
+		return fmt.Errorf("word is %d characters long, at most %d are allowed", n, MaxWordLength)
+	}
+	for _, r := range word {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r):
+		case r == ' ' || (unicode.IsSpace(r) && !unicode.IsControl(r)):
+		case strings.ContainsRune(wordPunctuation, r):
+		case unicode.IsControl(r) || !unicode.IsPrint(r):
+			return fmt.Errorf("word contains the control or invisible character %U", r)
+		default:
+			return fmt.Errorf("word contains %q, which words are not spelled with", r)
+		}
+	}
+	return nil
+}
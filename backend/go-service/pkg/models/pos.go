@@ -0,0 +1,135 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/langcode"
+)
+
+// PartOfSpeech is a word class shared by all languages, so entries can be
+// filtered and quizzed across languages whatever the source calls it.
+type PartOfSpeech string
+
+const (
+	Noun         PartOfSpeech = "noun"
+	ProperNoun   PartOfSpeech = "proper_noun"
+	Verb         PartOfSpeech = "verb"
+	Adjective    PartOfSpeech = "adjective"
+	Adverb       PartOfSpeech = "adverb"
+	Pronoun      PartOfSpeech = "pronoun"
+	Determiner   PartOfSpeech = "determiner"
+	Preposition  PartOfSpeech = "preposition"
+	Conjunction  PartOfSpeech = "conjunction"
+	Interjection PartOfSpeech = "interjection"
+	Numeral      PartOfSpeech = "numeral"
+	Particle     PartOfSpeech = "particle"
+	Abbreviation PartOfSpeech = "abbreviation"
+	// Phrase is a multi-word expression looked up as an entry of its own.
+	Phrase PartOfSpeech = "phrase"
+	// OtherPartOfSpeech is a category the language's table doesn't know.
+	OtherPartOfSpeech PartOfSpeech = "other"
+)
+
+var norwegianPartsOfSpeech = map[string]PartOfSpeech{
+	"substantiv":      Noun,
+	"egennavn":        ProperNoun,
+	"eigennamn":       ProperNoun,
+	"verb":            Verb,
+	"adjektiv":        Adjective,
+	"adverb":          Adverb,
+	"pronomen":        Pronoun,
+	"determinativ":    Determiner,
+	"preposisjon":     Preposition,
+	"konjunksjon":     Conjunction,
+	"subjunksjon":     Conjunction,
+	"interjeksjon":    Interjection,
+	"tallord":         Numeral,
+	"infinitivsmerke": Particle,
+	"forkortelse":     Abbreviation,
+	"forkorting":      Abbreviation,
+	"uttrykk":         Phrase,
+}
+
+// partsOfSpeech maps the words each language's sources use for a category
+// to a PartOfSpeech, keyed by canonical language code.
+var partsOfSpeech = map[string]map[string]PartOfSpeech{
+	langcode.Bokmal:  norwegianPartsOfSpeech,
+	langcode.Nynorsk: norwegianPartsOfSpeech,
+	langcode.English: {
+		"noun":         Noun,
+		"proper":       ProperNoun,
+		"verb":         Verb,
+		"adjective":    Adjective,
+		"adverb":       Adverb,
+		"pronoun":      Pronoun,
+		"determiner":   Determiner,
+		"article":      Determiner,
+		"preposition":  Preposition,
+		"conjunction":  Conjunction,
+		"interjection": Interjection,
+		"exclamation":  Interjection,
+		"numeral":      Numeral,
+		"number":       Numeral,
+		"particle":     Particle,
+		"abbreviation": Abbreviation,
+		"phrase":       Phrase,
+		"idiom":        Phrase,
+	},
+	langcode.German: {
+		"substantiv":   Noun,
+		"eigenname":    ProperNoun,
+		"verb":         Verb,
+		"adjektiv":     Adjective,
+		"adverb":       Adverb,
+		"pronomen":     Pronoun,
+		"artikel":      Determiner,
+		"präposition":  Preposition,
+		"konjunktion":  Conjunction,
+		"subjunktion":  Conjunction,
+		"interjektion": Interjection,
+		"numerale":     Numeral,
+		"zahlwort":     Numeral,
+		"partikel":     Particle,
+		"abkürzung":    Abbreviation,
+		"redewendung":  Phrase,
+	},
+	langcode.Spanish: {
+		"sustantivo":   Noun,
+		"nombre":       Noun,
+		"propio":       ProperNoun,
+		"verbo":        Verb,
+		"adjetivo":     Adjective,
+		"adverbio":     Adverb,
+		"pronombre":    Pronoun,
+		"artículo":     Determiner,
+		"determinante": Determiner,
+		"preposición":  Preposition,
+		"conjunción":   Conjunction,
+		"interjección": Interjection,
+		"numeral":      Numeral,
+		"partícula":    Particle,
+		"abreviatura":  Abbreviation,
+		"locución":     Phrase,
+	},
+}
+
+// NormalizePartOfSpeech maps a source's category text (e.g. "substantiv
+// hankjønn") in a canonical language to a PartOfSpeech. The first word the
+// language's table knows decides; an empty category gives "", and one the
+// table doesn't know gives OtherPartOfSpeech.
+func NormalizePartOfSpeech(language, category string) PartOfSpeech {
+	words := strings.FieldsFunc(strings.ToLower(category), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	if len(words) == 0 {
+		return ""
+	}
+	table := partsOfSpeech[language]
+	for _, w := range words {
+		if pos, ok := table[w]; ok {
+			return pos
+		}
+	}
+	return OtherPartOfSpeech
+}
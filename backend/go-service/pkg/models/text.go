@@ -0,0 +1,144 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/unicode/norm"
+)
+
+// smartQuotes are the typographic quotes sources and their editors use
+// inconsistently; text gets plain ones so it reads and searches the same
+// whichever source it came from. Guillemets («») are left alone: they are
+// how Norwegian and German quote.
+var smartQuotes = strings.NewReplacer("“", `"`, "”", `"`, "„", `"`, "‟", `"`, "’", "'", "‘", "'", "‚", "'", "‛", "'")
+
+// cp1252High maps the runes Windows-1252 has at 0x80-0x9F back to their
+// bytes ("€" to 0x80); everything else it shares with Latin-1.
+var cp1252High = func() map[rune]byte {
+	m := make(map[rune]byte)
+	for b := 0x80; b <= 0x9F; b++ {
+		if r := charmap.Windows1252.DecodeByte(byte(b)); r != utf8.RuneError {
+			m[r] = byte(b)
+		}
+	}
+	return m
+}()
+
+// NormalizeText cleans a piece of scraped text: UTF-8 that was decoded as
+// Windows-1252 or Latin-1 on the way ("posiciÃ³n") is repaired, letters are
+// composed (NFC), smart quotes become plain ones, invisible characters
+// (zero-width spaces, soft hyphens, byte order marks, control characters)
+// are dropped, and whitespace is collapsed to single spaces.
+func NormalizeText(s string) string {
+	if s == "" {
+		return s
+	}
+	s = repairMojibake(s)
+	s = norm.NFC.String(s)
+	s = smartQuotes.Replace(s)
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r == '\u200b' || r == '\u2060' || r == '\ufeff' || r == '\u00ad':
+			return -1
+		case unicode.IsSpace(r):
+			return ' '
+		case unicode.IsControl(r):
+			return -1
+		}
+		return r
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// repairMojibake undoes UTF-8 that was decoded one byte per character,
+// twice at most for text that went through it twice. A string is only
+// replaced when turning its characters back into bytes gives valid UTF-8,
+// which correctly decoded accented text practically never does ("på" is
+// 0x70 0xE5, not a UTF-8 sequence).
+func repairMojibake(s string) string {
+	for range 2 {
+		repaired, ok := reencode(s)
+		if !ok {
+			break
+		}
+		s = repaired
+	}
+	return s
+}
+
+// reencode turns each character of s into the byte Windows-1252 or Latin-1
+// has for it, and reports whether that changed anything and is UTF-8.
+func reencode(s string) (string, bool) {
+	b := make([]byte, 0, len(s))
+	high := false
+	for _, r := range s {
+		switch {
+		case r < 0x80:
+			b = append(b, byte(r))
+			continue
+		case r <= 0xFF:
+			b = append(b, byte(r))
+		default:
+			c, ok := cp1252High[r]
+			if !ok {
+				return "", false
+			}
+			b = append(b, c)
+		}
+		high = true
+	}
+	if !high || !utf8.Valid(b) {
+		return "", false
+	}
+	return string(b), true
+}
+
+// NormalizeText runs NormalizeText over every piece of text in the entry,
+// so what reaches clients and storage is clean whichever source it came
+// from. URLs, codes and IDs are left as they are.
+func (e *WordEntry) NormalizeText() {
+	e.Word = NormalizeText(e.Word)
+	e.Query = NormalizeText(e.Query)
+	for i := range e.Senses {
+		s := &e.Senses[i]
+		s.Category = NormalizeText(s.Category)
+		s.Gender = NormalizeText(s.Gender)
+		s.Article = NormalizeText(s.Article)
+		s.Etymology = NormalizeText(s.Etymology)
+		normalizeAll(s.Synonyms)
+		normalizeAll(s.Antonyms)
+		for j := range s.Meanings {
+			s.Meanings[j].Description = NormalizeText(s.Meanings[j].Description)
+			normalizeAll(s.Meanings[j].Examples)
+		}
+		for j := range s.Expressions {
+			s.Expressions[j].Phrase = NormalizeText(s.Expressions[j].Phrase)
+			s.Expressions[j].Explanation = NormalizeText(s.Expressions[j].Explanation)
+		}
+		for j := range s.WordForms {
+			s.WordForms[j].Label = NormalizeText(s.WordForms[j].Label)
+			normalizeAll(s.WordForms[j].Forms)
+		}
+		for j := range s.Pronunciations {
+			s.Pronunciations[j].Text = NormalizeText(s.Pronunciations[j].Text)
+		}
+		for j := range s.References {
+			s.References[j].Word = NormalizeText(s.References[j].Word)
+		}
+	}
+	for i := range e.Pronunciations {
+		e.Pronunciations[i].Text = NormalizeText(e.Pronunciations[i].Text)
+	}
+	for i := range e.Warnings {
+		e.Warnings[i].Message = NormalizeText(e.Warnings[i].Message)
+	}
+}
+
+func normalizeAll(texts []string) {
+	for i := range texts {
+		texts[i] = NormalizeText(texts[i])
+	}
+}
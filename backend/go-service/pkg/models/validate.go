@@ -0,0 +1,162 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxFormLabelLength is the longest inflection row label taken as a label;
+// longer ones are page text that a changed table layout put in its place.
+const maxFormLabelLength = 80
+
+// ValidationError is returned by Validate for an entry that can't be
+// returned or delivered at all.
+type ValidationError struct {
+	Word     string
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid entry for %q: %s", e.Word, strings.Join(e.Problems, "; "))
+}
+
+var (
+	knownPartsOfSpeech = map[PartOfSpeech]bool{
+		Noun: true, ProperNoun: true, Verb: true, Adjective: true, Adverb: true,
+		Pronoun: true, Determiner: true, Preposition: true, Conjunction: true,
+		Interjection: true, Numeral: true, Particle: true, Abbreviation: true,
+		Phrase: true, OtherPartOfSpeech: true,
+	}
+	relations = map[string]bool{RelationSee: true, RelationCompare: true, RelationOrigin: true, RelationRelated: true}
+	notations = map[string]bool{"": true, NotationIPA: true, NotationRespelling: true}
+)
+
+// Validate checks an entry against the WordEntry schema
+// (events/schemas/word_entry.v1.json) before it is returned, stored or
+// forwarded. Malformed parts are dropped, with an invalid_content warning
+// on their sense: meanings with neither text nor examples, inflection rows
+// without forms or without a sane label, references with an unknown
+// relation, pronunciations without text or with an unknown notation, and
+// senses left without an ID or any content. An entry without a word or
+// language gets a *ValidationError, as does one left without senses; an
+// entry that had none to begin with gets a *NotFoundError.
+func (e *WordEntry) Validate() error {
+	var problems []string
+	if strings.TrimSpace(e.Word) == "" {
+		problems = append(problems, "word is empty")
+	}
+	if e.Language == "" {
+		problems = append(problems, "language is empty")
+	}
+	if len(problems) > 0 {
+		return &ValidationError{Word: e.Word, Problems: problems}
+	}
+
+	if len(e.Senses) == 0 {
+		return &NotFoundError{Word: e.Word, Language: e.Language}
+	}
+	senses := e.Senses[:0]
+	for _, sense := range e.Senses {
+		dropped := sense.validate()
+		keep := sense.ID != "" && (len(sense.Meanings) > 0 || len(sense.Expressions) > 0 || len(sense.WordForms) > 0)
+		if !keep {
+			dropped = append(dropped, "sense without an ID or content")
+		}
+		if len(dropped) > 0 {
+			e.Warn(WarningInvalidContent, sense.ID, errors.New("dropped "+strings.Join(dropped, ", ")))
+		}
+		if keep {
+			senses = append(senses, sense)
+		}
+	}
+	e.Senses = senses
+	pronunciations, dropped := validPronunciations(e.Pronunciations)
+	e.Pronunciations = pronunciations
+	if dropped > 0 {
+		e.Warn(WarningInvalidContent, "", fmt.Errorf("dropped %d malformed pronunciation(s)", dropped))
+	}
+	if len(e.Senses) == 0 {
+		return &ValidationError{Word: e.Word, Problems: []string{"no valid senses"}}
+	}
+	return nil
+}
+
+// validate drops the malformed parts of a sense and describes them.
+func (s *SenseEntry) validate() (dropped []string) {
+	if s.PartOfSpeech != "" && !knownPartsOfSpeech[s.PartOfSpeech] {
+		s.PartOfSpeech = OtherPartOfSpeech
+	}
+
+	meanings := s.Meanings[:0]
+	for _, m := range s.Meanings {
+		if strings.TrimSpace(m.Description) == "" && len(m.Examples) == 0 {
+			dropped = append(dropped, "a meaning without text")
+			continue
+		}
+		meanings = append(meanings, m)
+	}
+	s.Meanings = meanings
+
+	rows := s.WordForms[:0]
+	for _, row := range s.WordForms {
+		row.Forms = nonEmpty(row.Forms)
+		switch {
+		case len(row.Forms) == 0:
+			dropped = append(dropped, fmt.Sprintf("inflection row %q without forms", row.Label))
+		case !saneLabel(row.Label):
+			dropped = append(dropped, fmt.Sprintf("inflection row with label %q", row.Label))
+		default:
+			rows = append(rows, row)
+		}
+	}
+	s.WordForms = rows
+
+	references := s.References[:0]
+	for _, ref := range s.References {
+		if strings.TrimSpace(ref.Word) == "" || !relations[ref.Relation] {
+			dropped = append(dropped, fmt.Sprintf("reference to %q (%q)", ref.Word, ref.Relation))
+			continue
+		}
+		references = append(references, ref)
+	}
+	s.References = references
+
+	pronunciations, n := validPronunciations(s.Pronunciations)
+	s.Pronunciations = pronunciations
+	if n > 0 {
+		dropped = append(dropped, fmt.Sprintf("%d malformed pronunciation(s)", n))
+	}
+	return dropped
+}
+
+// saneLabel reports whether an inflection row label looks like one: some
+// letters, and not so long that it must be page text.
+func saneLabel(label string) bool {
+	label = strings.TrimSpace(label)
+	return label != "" &&
+		utf8.RuneCountInString(label) <= maxFormLabelLength &&
+		strings.IndexFunc(label, unicode.IsLetter) >= 0
+}
+
+func validPronunciations(prons []Pronunciation) ([]Pronunciation, int) {
+	valid := prons[:0]
+	for _, p := range prons {
+		if strings.TrimSpace(p.Text) != "" && notations[p.Notation] {
+			valid = append(valid, p)
+		}
+	}
+	return valid, len(prons) - len(valid)
+}
+
+func nonEmpty(texts []string) []string {
+	kept := texts[:0]
+	for _, t := range texts {
+		if strings.TrimSpace(t) != "" {
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,245 @@
+// Package models defines the dictionary entries the scrapers produce and
+// the service stores: a WordEntry with its senses, meanings, examples and
+// inflected forms, as serialized to JSON.
+package models
+
+import (
+    "crypto/sha256"
+    "encoding/hex"
+    "errors"
+    "fmt"
+    "strings"
+    "time"
+)
+
+// MeaningEntry: A single meaning, optionally with examples.
+type MeaningEntry struct {
+    Description string   `json:"description"`
+    Examples    []string `json:"examples,omitempty"` // Flattened for simplicity
+}
+
+// ExpressionEntry: Idioms/fixed expressions for a sense.
+type ExpressionEntry struct {
+    Phrase      string `json:"phrase"`
+    Explanation string `json:"explanation"`
+}
+
+// WordFormEntry: One row of inflection data.
+type WordFormEntry struct {
+    Label        string   `json:"label"`
+    Forms        []string `json:"forms"`
+    Number       string   `json:"number,omitempty"`
+    Definiteness string   `json:"definiteness,omitempty"`
+    Gender       string   `json:"gender,omitempty"`
+    Degree       string   `json:"degree,omitempty"`
+    Tense        string   `json:"tense,omitempty"`
+    Person       string   `json:"person,omitempty"` // first, second or third
+    Mood         string   `json:"mood,omitempty"`   // indicative, subjunctive, imperative, conditional
+    Voice        string   `json:"voice,omitempty"`  // active or passive
+    Case         string   `json:"case,omitempty"`   // nominative, accusative, dative, genitive
+}
+
+// Pronunciation notations.
+const (
+    NotationIPA        = "ipa"        // International Phonetic Alphabet
+    NotationRespelling = "respelling" // the dictionary's own spelling-based guide
+)
+
+// Pronunciation: A phonetic transcription, optionally with audio.
+type Pronunciation struct {
+    Text     string `json:"text"`
+    Notation string `json:"notation,omitempty"`
+    Audio    string `json:"audio,omitempty"`   // URL of a recording
+    Variant  string `json:"variant,omitempty"` // regional variant, e.g. "uk" or "us"
+}
+
+// AudioClip: A recording of the word that isn't tied to a transcription.
+type AudioClip struct {
+    URL     string `json:"url"`
+    Source  string `json:"source"`            // e.g. "forvo"
+    Variant string `json:"variant,omitempty"` // regional variant or speaker's country
+    Speaker string `json:"speaker,omitempty"`
+}
+
+// Frequency: How common the word is, from the language's frequency list.
+type Frequency struct {
+    Rank int `json:"rank"` // 1 is the most frequent word
+    Band int `json:"band"` // 1 (top 1,000) to 5 (beyond the top 30,000)
+}
+
+// CEFRLevel: The word's estimated CEFR level (A1–C2).
+type CEFRLevel struct {
+    Level string `json:"level"`
+    Basis string `json:"basis"` // CEFRBasisList or CEFRBasisFrequency
+}
+
+// What a CEFR level is based on.
+const (
+    CEFRBasisList      = "list"      // a curated CEFR word list
+    CEFRBasisFrequency = "frequency" // estimated from the frequency rank
+)
+
+// Reference: A link from a sense to another entry ("se", "jf.").
+type Reference struct {
+    Word     string `json:"word"`
+    Relation string `json:"relation"` // see Relation* constants
+    // ArticleID is the target's article id in the source, when it links one.
+    ArticleID string `json:"article_id,omitempty"`
+}
+
+// Reference relations.
+const (
+    RelationSee     = "see"     // "se", "sjå": the target explains this word
+    RelationCompare = "compare" // "jf.", "sammenlign": a related word worth comparing
+    RelationOrigin  = "origin"  // linked from the etymology
+    RelationRelated = "related" // any other link
+)
+
+// SenseEntry: A single dictionary sense (noun, verb, etc.)
+type SenseEntry struct {
+    ID          string            `json:"id"`
+    Category    string            `json:"category"`
+    // PartOfSpeech is Category normalized across languages.
+    PartOfSpeech PartOfSpeech     `json:"part_of_speech,omitempty"`
+    Gender      string            `json:"gender,omitempty"`
+    Article     string            `json:"article,omitempty"`
+    Meanings    []MeaningEntry     `json:"meanings"`
+    Expressions []ExpressionEntry  `json:"expressions,omitempty"`
+    WordForms   []WordFormEntry    `json:"word_forms,omitempty"`
+    Pronunciations []Pronunciation `json:"pronunciations,omitempty"`
+    // Etymology is the word's origin as the source words it.
+    Etymology   string             `json:"etymology,omitempty"`
+    Synonyms    []string           `json:"synonyms,omitempty"`
+    Antonyms    []string           `json:"antonyms,omitempty"`
+    // References link to other entries the source points to.
+    References  []Reference        `json:"references,omitempty"`
+}
+
+// WordEntry: The top-level word container (multi-sense support).
+type WordEntry struct {
+    Word    string       `json:"word"`
+    // Query is the word as looked up, when it was an inflected form
+    // resolved to the lemma in Word ("hunder" for "hund").
+    Query   string       `json:"query,omitempty"`
+    // Language is the canonical code of the dictionary the entry came from (e.g. "no-bm").
+    Language string      `json:"language,omitempty"`
+    // LanguageDetected is set when the request gave no language and
+    // Language was detected from the word.
+    LanguageDetected bool `json:"language_detected,omitempty"`
+    Senses  []SenseEntry `json:"senses"`
+    // InflectionsPartial is set when inflections came from the static
+    // fallback (or are missing) because the browser scrape failed.
+    InflectionsPartial bool `json:"inflections_partial,omitempty"`
+    // IdempotencyKey identifies the entry across retries and repeated
+    // scrapes; see IdempotencyKey.
+    IdempotencyKey string `json:"idempotency_key,omitempty"`
+    // Warnings describe parts of the scrape that failed, so a partial
+    // entry can be told apart from a complete one.
+    Warnings []ScrapeWarning `json:"warnings,omitempty"`
+    // Pronunciations that apply to the word as a whole; sources that
+    // transcribe per sense put them on the sense instead.
+    Pronunciations []Pronunciation `json:"pronunciations,omitempty"`
+    // Audio holds recordings from audio-only sources such as Forvo;
+    // recordings from the dictionary sit on their pronunciation.
+    Audio []AudioClip `json:"audio,omitempty"`
+    // Source names the dictionary the entry was scraped from (a
+    // sources.Profile name) and SourceURL the page, for attribution.
+    Source    string `json:"source,omitempty"`
+    SourceURL string `json:"source_url,omitempty"`
+    // ScrapedAt is when the source was read; cached entries keep it.
+    ScrapedAt time.Time `json:"scraped_at,omitzero"`
+    // License is the source's content license, e.g. "CC BY 4.0".
+    License string `json:"license,omitempty"`
+    // Frequency is set when the word is on its language's frequency list.
+    Frequency *Frequency `json:"frequency,omitempty"`
+    // CEFR is set when a curated list or the frequency rank gives a level.
+    CEFR *CEFRLevel `json:"cefr,omitempty"`
+}
+
+// Scrape warning codes.
+const (
+    WarningSenseFailed        = "sense_failed"        // a sense could not be scraped and is missing
+    WarningInflectionFallback = "inflection_fallback" // the browser scrape failed; forms came from the static fallback
+    WarningInflectionFailed   = "inflection_failed"   // no inflections could be scraped for a sense
+    WarningAudioFailed        = "audio_failed"        // recordings could not be looked up
+    WarningLemmaFailed        = "lemma_failed"        // the input could not be resolved to a lemma and was scraped as given
+    WarningOfflineFallback    = "offline_fallback"    // the scrape failed or found nothing; senses came from an offline dictionary
+    WarningInvalidContent     = "invalid_content"     // malformed parts of the entry failed validation and were dropped
+    WarningTruncated          = "truncated"           // senses, examples or inflection rows beyond the configured limits were left out
+)
+
+// ScrapeWarning: One part of a scrape that failed without failing the whole entry.
+type ScrapeWarning struct {
+    Code    string `json:"code"`
+    SenseID string `json:"sense_id,omitempty"`
+    Message string `json:"message"`
+}
+
+// Warn records a warning on the entry.
+func (e *WordEntry) Warn(code, senseID string, err error) {
+    e.Warnings = append(e.Warnings, ScrapeWarning{Code: code, SenseID: senseID, Message: err.Error()})
+}
+
+// ErrNotFound matches a *NotFoundError with errors.Is.
+var ErrNotFound = errors.New("word not found")
+
+// NotFoundError is returned for a word its language's source has no entry
+// for.
+type NotFoundError struct {
+    Word     string
+    Language string
+}
+
+func (e *NotFoundError) Error() string {
+    return fmt.Sprintf("no entry for %q in %s", e.Word, e.Language)
+}
+
+func (e *NotFoundError) Unwrap() error {
+    return ErrNotFound
+}
+
+// IncompleteError is returned by CheckComplete for an entry with warnings.
+type IncompleteError struct {
+    Word     string
+    Warnings []ScrapeWarning
+}
+
+func (e *IncompleteError) Error() string {
+    problems := make([]string, len(e.Warnings))
+    for i, w := range e.Warnings {
+        problems[i] = w.Code
+        if w.SenseID != "" {
+            problems[i] += " (" + w.SenseID + ")"
+        }
+        problems[i] += ": " + w.Message
+    }
+    return fmt.Sprintf("incomplete entry for %q: %s", e.Word, strings.Join(problems, "; "))
+}
+
+// CheckComplete returns an *IncompleteError listing the entry's warnings,
+// if any part of its scrape failed. Strict callers use it to fail a scrape
+// rather than store a partial entry. Truncation by ApplyLimits was asked
+// for, so its warnings don't count.
+func (e *WordEntry) CheckComplete() error {
+    var failed []ScrapeWarning
+    for _, w := range e.Warnings {
+        if w.Code != WarningTruncated {
+            failed = append(failed, w)
+        }
+    }
+    if len(failed) == 0 {
+        return nil
+    }
+    return &IncompleteError{Word: e.Word, Warnings: failed}
+}
+
+// IdempotencyKey derives the key stored with an entry from the word, its
+// canonical language and the version of the source that produced it. The
+// same word scraped twice from the same source version gets the same key,
+// so downstream stores can drop duplicates; bumping the source version
+// lets a re-scrape with a changed parser through.
+func IdempotencyKey(word, language, sourceVersion string) string {
+    normalized := strings.ToLower(strings.TrimSpace(word))
+    sum := sha256.Sum256([]byte(normalized + "\x00" + language + "\x00" + sourceVersion))
+    return hex.EncodeToString(sum[:])
+}
\ No newline at end of file
@@ -0,0 +1,63 @@
+package bokmal_scraper
+
+import (
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/scrapers"
+)
+
+// std is the Scraper behind the package-level functions; programs replace
+// its settings through Configure.
+var std = New()
+
+// Configure applies s to subsequent scrapes by the package-level functions.
+// Call it before scraping starts.
+func Configure(s scrapers.Settings) {
+	std = New(WithSettings(s))
+}
+
+// ScrapeWord scrapes word with the default Scraper.
+func ScrapeWord(word string) (models.WordEntry, error) {
+	return std.ScrapeWord(word)
+}
+
+// ScrapeURL scrapes the article page at url with the default Scraper.
+func ScrapeURL(word, url string) (models.WordEntry, error) {
+	return std.ScrapeURL(word, url)
+}
+
+// ScrapePhrase looks up a multi-word phrase with the default Scraper.
+func ScrapePhrase(phrase string) (models.WordEntry, error) {
+	return std.ScrapePhrase(phrase)
+}
+
+// ResolveLemma resolves an inflected form with the default Scraper.
+func ResolveLemma(word string) (string, error) {
+	return std.ResolveLemma(word)
+}
+
+// Suggest completes prefix with the default Scraper.
+func Suggest(prefix string, n int) ([]string, error) {
+	return std.Suggest(prefix, n)
+}
+
+// ExtractSenseIDs lists the senses on a page with the default Scraper.
+func ExtractSenseIDs(url string) ([]string, error) {
+	return std.ExtractSenseIDs(url)
+}
+
+// ScrapeSense scrapes one sense block with the default Scraper.
+func ScrapeSense(url, senseID string) (models.SenseEntry, error) {
+	return std.ScrapeSense(url, senseID)
+}
+
+// ScrapeInflection renders a sense's inflection table with the default
+// Scraper.
+func ScrapeInflection(url, senseID string) ([]models.WordFormEntry, error) {
+	return std.ScrapeInflection(url, senseID)
+}
+
+// ScrapeInflectionStatic reads a sense's inflections without Chrome, with
+// the default Scraper.
+func ScrapeInflectionStatic(url, senseID string) ([]models.WordFormEntry, error) {
+	return std.ScrapeInflectionStatic(url, senseID)
+}
@@ -0,0 +1,158 @@
+package bokmal_scraper
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/langcode"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/scrapers/wordforms"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly"
+)
+
+// articleAPIPath is the article JSON (including full paradigms) under
+// Settings.ArticleAPIURL.
+const articleAPIPath = "/bm/article/%s.json"
+
+var articleIDPattern = regexp.MustCompile(`(\d+)$`)
+
+// ScrapeInflectionStatic is the fallback used when Chrome is unavailable.
+// It reads the paradigm from the article API, and failing that, any
+// inflection table already present in the static HTML. The result may be
+// less complete than the interactive table.
+func (sc *Scraper) ScrapeInflectionStatic(url, senseID string) ([]models.WordFormEntry, error) {
+	forms, apiErr := sc.inflectionFromAPI(senseID)
+	if apiErr == nil && len(forms) > 0 {
+		slog.Debug("static inflection fallback used article API", "sense_id", senseID, "rows", len(forms))
+		return forms, nil
+	}
+
+	forms, htmlErr := sc.inflectionFromStaticHTML(url, senseID)
+	if htmlErr != nil {
+		return nil, fmt.Errorf("article API: %v; static HTML: %w", apiErr, htmlErr)
+	}
+	slog.Debug("static inflection fallback used static HTML", "sense_id", senseID, "rows", len(forms))
+	return forms, nil
+}
+
+type apiArticle struct {
+	Lemmas []struct {
+		ParadigmInfo []struct {
+			Inflection []struct {
+				Tags     []string `json:"tags"`
+				WordForm string   `json:"word_form"`
+			} `json:"inflection"`
+		} `json:"paradigm_info"`
+	} `json:"lemmas"`
+}
+
+// apiTagLabels translates paradigm tags into the labels used by the
+// interactive table, so the Bokmal label vocabulary understands them.
+var apiTagLabels = map[string]string{
+	"Sing":       "entall",
+	"Plur":       "flertall",
+	"Ind":        "ubestemt form",
+	"Def":        "bestemt form",
+	"Masc":       "hankjønn",
+	"Fem":        "hunkjønn",
+	"Neuter":     "intetkjønn",
+	"Pos":        "positiv",
+	"Cmp":        "komparativ",
+	"Sup":        "superlativ",
+	"Inf":        "infinitiv",
+	"Pres":       "presens",
+	"Past":       "preteritum",
+	"Imp":        "imperativ",
+	"Pass":       "passiv",
+	"<PerfPart>": "perfektum partisipp",
+	"<PresPart>": "presens partisipp",
+}
+
+func (sc *Scraper) inflectionFromAPI(senseID string) ([]models.WordFormEntry, error) {
+	m := articleIDPattern.FindStringSubmatch(senseID)
+	if m == nil {
+		return nil, fmt.Errorf("no article ID in sense %q", senseID)
+	}
+
+	resp, err := sc.httpClient.Get(fmt.Sprintf(sc.settings.ArticleAPIURL+articleAPIPath, m[1]))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("article API returned %s", resp.Status)
+	}
+
+	var article apiArticle
+	if err := json.NewDecoder(resp.Body).Decode(&article); err != nil {
+		return nil, fmt.Errorf("decoding article: %w", err)
+	}
+
+	var forms []models.WordFormEntry
+	index := make(map[string]int)
+	for _, lemma := range article.Lemmas {
+		for _, paradigm := range lemma.ParadigmInfo {
+			for _, infl := range paradigm.Inflection {
+				if infl.WordForm == "" {
+					continue
+				}
+				var parts []string
+				for _, tag := range infl.Tags {
+					if label, ok := apiTagLabels[tag]; ok {
+						parts = append(parts, label)
+					}
+				}
+				if len(parts) == 0 {
+					continue
+				}
+				label := strings.Join(parts, " / ")
+
+				// Alternative paradigms repeat labels; merge their forms into one row
+				if i, ok := index[label]; ok {
+					if !contains(forms[i].Forms, infl.WordForm) {
+						forms[i].Forms = append(forms[i].Forms, infl.WordForm)
+					}
+					continue
+				}
+				index[label] = len(forms)
+				forms = append(forms, wordforms.Entry(langcode.Bokmal, label, []string{infl.WordForm}))
+			}
+		}
+	}
+	return forms, nil
+}
+
+func (sc *Scraper) inflectionFromStaticHTML(url, senseID string) ([]models.WordFormEntry, error) {
+	var forms []models.WordFormEntry
+	c := sc.newCollector()
+
+	c.OnHTML(fmt.Sprintf("div#%s table[class*='infl-table']", senseID), func(e *colly.HTMLElement) {
+		tableHTML, err := goquery.OuterHtml(e.DOM)
+		if err == nil {
+			forms = append(forms, parseInflectionTable(tableHTML)...)
+		}
+	})
+
+	if err := c.Visit(url); err != nil {
+		return nil, err
+	}
+	if len(forms) == 0 {
+		return nil, fmt.Errorf("no inflection table in static HTML")
+	}
+	return forms, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package bokmal_scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/langcode"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/scrapers"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/scrapers/wordforms"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/sources"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// errBrowserDisabled is returned by ScrapeInflections when Chrome is turned
+// off in the configuration.
+var errBrowserDisabled = errors.New("browser scraping disabled")
+
+// ScrapeInflection renders the inflection table of one sense with Chrome.
+// Use ScrapeInflections for several senses of the same page.
+func (sc *Scraper) ScrapeInflection(url, senseID string) ([]models.WordFormEntry, error) {
+	forms, failed, err := sc.ScrapeInflections(url, []string{senseID})
+	if err != nil {
+		return nil, err
+	}
+	if err := failed[senseID]; err != nil {
+		return nil, err
+	}
+	return forms[senseID], nil
+}
+
+// ScrapeInflections renders the inflection tables of the senses on a page
+// in one Chrome session: the page is loaded once, then each
+// sense's inflection button is clicked in the same tab and its table read.
+// Tables that couldn't be read are missing from forms, with their errors
+// in failed; err is returned when the session as a whole failed, and
+// applies to every sense.
+func (sc *Scraper) ScrapeInflections(url string, senseIDs []string) (forms map[string][]models.WordFormEntry, failed map[string]error, err error) {
+	slog.Debug("inflection scrape started", "url", url, "senses", senseIDs)
+	if !sc.settings.BrowserEnabled {
+		return nil, nil, errBrowserDisabled
+	}
+
+	// Chrome is heavy; wait for a slot rather than starting one per request
+	waitCtx, cancelWait := context.WithTimeout(context.Background(), sc.settings.BrowserTimeout)
+	release, err := sc.browsers.AcquireBrowser(waitCtx)
+	cancelWait()
+	if err != nil {
+		return nil, nil, fmt.Errorf("waiting for a browser slot: %w", err)
+	}
+	defer release()
+
+	headers := sources.Default.HeadersForURL(url)
+	extraHeaders := network.Headers{}
+	for name := range headers {
+		if name != "User-Agent" {
+			extraHeaders[name] = headers.Get(name)
+		}
+	}
+	proxyURL, proxyDone := sources.Default.PickProxy()
+
+	// Allow each sense as long as a session of its own would have
+	ctx, cancel := context.WithTimeout(context.Background(), sc.settings.BrowserTimeout*time.Duration(max(len(senseIDs), 1)))
+	defer cancel()
+	ctx, closeBrowser, err := scrapers.NewBrowserContext(ctx, sc.settings.BrowserURL, headers.Get("User-Agent"), proxyURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeBrowser()
+
+	// The browser fetches outside our HTTP transport, so count the navigation here
+	if err := sources.Default.ReserveURL(url); err != nil {
+		return nil, nil, err
+	}
+	if err := sources.Default.WaitURL(ctx, url); err != nil {
+		return nil, nil, err
+	}
+
+	// Navigate on its own first, so only load failures count against the proxy
+	err = chromedp.Run(ctx,
+		network.SetExtraHTTPHeaders(extraHeaders),
+		chromedp.Navigate(url),
+	)
+	proxyDone(err)
+	if err != nil {
+		return nil, nil, fmt.Errorf("chromedp failed: %w", err)
+	}
+	if err := chromedp.Run(ctx, chromedp.Sleep(2*time.Second)); err != nil {
+		return nil, nil, fmt.Errorf("chromedp failed: %w", err)
+	}
+
+	forms = make(map[string][]models.WordFormEntry)
+	failed = make(map[string]error)
+	for _, senseID := range senseIDs {
+		var inflectionHTML string
+		btnXPath := fmt.Sprintf(`//div[@id='%s']//button[contains(@class, 'btn-primary')]`, senseID)
+		err := chromedp.Run(ctx,
+			chromedp.ActionFunc(func(ctx context.Context) error {
+				slog.Debug("clicking inflection button", "sense_id", senseID)
+				chromedp.ScrollIntoView(btnXPath, chromedp.BySearch).Do(ctx)
+				return chromedp.Click(btnXPath, chromedp.BySearch).Do(ctx)
+			}),
+			chromedp.Sleep(2*time.Second),
+			chromedp.OuterHTML(fmt.Sprintf(`div#%s div[id$='_inflection']`, senseID), &inflectionHTML, chromedp.BySearch),
+		)
+		if err != nil {
+			failed[senseID] = fmt.Errorf("chromedp failed: %w", err)
+			continue
+		}
+		// The table only exists in the rendered DOM, so keep it alongside the fetched pages
+		sources.Default.Archive(url+"#"+senseID+"_inflection", []byte(inflectionHTML))
+		forms[senseID] = parseInflectionTable(inflectionHTML)
+		slog.Debug("inflection table parsed", "sense_id", senseID, "bytes", len(inflectionHTML), "rows", len(forms[senseID]))
+	}
+	return forms, failed, nil
+}
+
+// parseInflectionTable extracts word form rows from inflection table HTML.
+func parseInflectionTable(inflectionHTML string) []models.WordFormEntry {
+	doc, _ := goquery.NewDocumentFromReader(strings.NewReader(inflectionHTML))
+	var forms []models.WordFormEntry
+	var currentGroup string
+
+	doc.Find("table[class*='infl-table'] tr").Each(func(rowIdx int, row *goquery.Selection) {
+		// Detect group headers
+		if row.Find("th.infl-group").Length() > 0 {
+			currentGroup = strings.TrimSpace(row.Find("th.infl-group").Text())
+			slog.Debug("inflection group", "group", currentGroup)
+			return
+		}
+
+		// Extract label
+		label := strings.TrimSpace(row.Find("th.infl-label").Text())
+		var fullLabel string
+		if label != "" && currentGroup != "" {
+			fullLabel = currentGroup + " / " + label
+		} else if label != "" {
+			fullLabel = label
+		} else if currentGroup != "" {
+			fullLabel = currentGroup
+		} else {
+			return
+		}
+
+		// Extract forms
+		var formList []string
+		row.Find("td span.comma").Each(func(j int, span *goquery.Selection) {
+			form := strings.TrimSpace(span.Text())
+			if form != "" {
+				parts := strings.Fields(form)
+				formList = append(formList, parts...)
+			}
+		})
+
+		// Only append valid rows
+		if len(formList) > 0 {
+			forms = append(forms, wordforms.Entry(langcode.Bokmal, fullLabel, formList))
+			slog.Debug("inflection row", "label", fullLabel, "forms", formList)
+		}
+	})
+
+	return forms
+}
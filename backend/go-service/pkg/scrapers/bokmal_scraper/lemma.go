@@ -0,0 +1,25 @@
+package bokmal_scraper
+
+// ResolveLemma returns the lemma of an inflected form ("hunder" -> "hund").
+// A word that is a lemma itself, or that the dictionary doesn't know, is
+// returned unchanged, as is every word when lemma resolution is off.
+func (sc *Scraper) ResolveLemma(word string) (string, error) {
+	if !sc.settings.ResolveLemmas {
+		return word, nil
+	}
+	suggestions, err := sc.fetchSuggestions(word, 5, "ei")
+	if err != nil {
+		return word, err
+	}
+	for _, match := range suggestions.A.Exact {
+		if suggestionWord(match) == word {
+			return word, nil
+		}
+	}
+	for _, match := range suggestions.A.Inflect {
+		if lemma := suggestionWord(match); lemma != "" {
+			return lemma, nil
+		}
+	}
+	return word, nil
+}
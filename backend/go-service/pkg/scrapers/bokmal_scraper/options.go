@@ -0,0 +1,107 @@
+package bokmal_scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"time"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/langcode"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/scrapers"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/sources"
+
+	"github.com/gocolly/colly"
+)
+
+// Scraper scrapes Bokmål entries with its own settings, HTTP client and
+// browser pool, so a program can run differently tuned scrapers side by
+// side or point one at a test server. Create one with New; it is safe for
+// concurrent use.
+type Scraper struct {
+	settings   scrapers.Settings
+	httpClient *http.Client
+	browsers   BrowserPool
+	// jar holds the cookies ordbokene.no sets, for all of the page fetches
+	jar *cookiejar.Jar
+}
+
+// BrowserPool limits how many Chrome instances run at once.
+// *sources.Registry is one; the default is sources.Default.
+type BrowserPool interface {
+	// AcquireBrowser waits until a Chrome instance may be started, or ctx
+	// is done. Call release once the browser has exited.
+	AcquireBrowser(ctx context.Context) (release func(), err error)
+}
+
+// Option configures a Scraper.
+type Option func(*Scraper)
+
+// WithSettings replaces the default settings.
+func WithSettings(s scrapers.Settings) Option {
+	return func(sc *Scraper) {
+		sc.settings = s
+	}
+}
+
+// WithTimeout bounds each page and article API request; it is ignored
+// when WithHTTPClient is given, whose client's timeout applies instead.
+func WithTimeout(d time.Duration) Option {
+	return func(sc *Scraper) {
+		sc.settings.RequestTimeout = d
+	}
+}
+
+// WithHTTPClient makes API requests with c, and fetches article pages
+// through its transport. The default client goes through sources.Default,
+// which rate-limits requests and can switch sources off; a client given
+// here bypasses that unless its transport is sources.Default.Transport.
+func WithHTTPClient(c *http.Client) Option {
+	return func(sc *Scraper) {
+		sc.httpClient = c
+	}
+}
+
+// WithBrowserPool waits for a slot in p before starting Chrome.
+func WithBrowserPool(p BrowserPool) Option {
+	return func(sc *Scraper) {
+		sc.browsers = p
+	}
+}
+
+// New creates a Scraper with scrapers.DefaultSettings, changed by opts.
+func New(opts ...Option) *Scraper {
+	jar, _ := cookiejar.New(nil)
+	sc := &Scraper{
+		settings: scrapers.DefaultSettings(),
+		browsers: sources.Default,
+		jar:      jar,
+	}
+	for _, opt := range opts {
+		opt(sc)
+	}
+	if sc.httpClient == nil {
+		sc.httpClient = &http.Client{
+			Timeout:   sc.settings.RequestTimeout,
+			Transport: sources.Default.Transport(nil),
+		}
+	}
+	// Time every request, on a copy so a client given by the caller is left as is
+	client := *sc.httpClient
+	client.Transport = scrapers.TimeRequests(langcode.Bokmal, sc.transport())
+	sc.httpClient = &client
+	return sc
+}
+
+// newCollector creates a collector for one page, sharing the connections
+// and cookies of the Scraper's other fetches.
+func (sc *Scraper) newCollector() *colly.Collector {
+	return scrapers.NewCollector(sc.transport(), sc.jar, sc.httpClient.Timeout)
+}
+
+// transport is the round tripper colly fetches article pages with.
+func (sc *Scraper) transport() http.RoundTripper {
+	if sc.httpClient.Transport != nil {
+		return sc.httpClient.Transport
+	}
+	return http.DefaultTransport
+}
@@ -0,0 +1,60 @@
+package bokmal_scraper
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// suggestAPIPath is the search suggestion endpoint under
+// Settings.ArticleAPIURL, taking the number of matches, the kinds of
+// match to include ("e" exact, "i" inflected) and the query.
+const suggestAPIPath = "/api/suggest?dict=bm&n=%d&include=%s&q=%s"
+
+// suggestResponse lists matches as [word, [dictionaries]] pairs.
+type suggestResponse struct {
+	A struct {
+		Exact   [][]json.RawMessage `json:"exact"`
+		Inflect [][]json.RawMessage `json:"inflect"`
+	} `json:"a"`
+}
+
+func (sc *Scraper) fetchSuggestions(query string, n int, include string) (suggestResponse, error) {
+	var suggestions suggestResponse
+	resp, err := sc.httpClient.Get(fmt.Sprintf(sc.settings.ArticleAPIURL+suggestAPIPath, n, include, url.QueryEscape(query)))
+	if err != nil {
+		return suggestions, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return suggestions, fmt.Errorf("suggest API returned %s", resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&suggestions); err != nil {
+		return suggestions, fmt.Errorf("decoding suggestions: %w", err)
+	}
+	return suggestions, nil
+}
+
+// Suggest returns up to n lemmas starting with prefix, for autocomplete.
+func (sc *Scraper) Suggest(prefix string, n int) ([]string, error) {
+	suggestions, err := sc.fetchSuggestions(prefix, n, "e")
+	if err != nil {
+		return nil, err
+	}
+	words := []string{}
+	for _, match := range suggestions.A.Exact {
+		if word := suggestionWord(match); word != "" {
+			words = append(words, word)
+		}
+	}
+	return words, nil
+}
+
+func suggestionWord(match []json.RawMessage) string {
+	var word string
+	if len(match) > 0 {
+		json.Unmarshal(match[0], &word)
+	}
+	return word
+}
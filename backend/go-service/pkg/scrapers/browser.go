@@ -0,0 +1,61 @@
+package scrapers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chromedp/cdproto/emulation"
+	"github.com/chromedp/cdproto/target"
+	"github.com/chromedp/chromedp"
+)
+
+// NewBrowserContext returns a chromedp context for one scraping session,
+// and a function that ends it. With remoteURL set, the session is a tab in
+// the Chrome listening there (a DevTools WebSocket URL, or the http://
+// address of its debugging port), in a browser context of its own so
+// sessions don't share cookies; otherwise a local headless Chrome is
+// started for it. userAgent and proxyURL apply to the session when not
+// empty.
+func NewBrowserContext(parent context.Context, remoteURL, userAgent, proxyURL string) (context.Context, context.CancelFunc, error) {
+	if remoteURL == "" {
+		opts := append(chromedp.DefaultExecAllocatorOptions[:],
+			chromedp.Flag("headless", true),
+			chromedp.Flag("disable-gpu", true),
+			chromedp.Flag("disable-infobars", true),
+		)
+		if userAgent != "" {
+			opts = append(opts, chromedp.UserAgent(userAgent))
+		}
+		if proxyURL != "" {
+			opts = append(opts, chromedp.ProxyServer(proxyURL))
+		}
+		allocCtx, cancelAlloc := chromedp.NewExecAllocator(parent, opts...)
+		ctx, cancel := chromedp.NewContext(allocCtx)
+		return ctx, func() { cancel(); cancelAlloc() }, nil
+	}
+
+	allocCtx, cancelAlloc := chromedp.NewRemoteAllocator(parent, remoteURL)
+	browserCtx, cancelBrowser := chromedp.NewContext(allocCtx)
+	// Connect first: a new browser context needs the browser
+	if err := chromedp.Run(browserCtx); err != nil {
+		cancelBrowser()
+		cancelAlloc()
+		return nil, nil, fmt.Errorf("connecting to Chrome at %s: %w", remoteURL, err)
+	}
+	ctx, cancel := chromedp.NewContext(browserCtx, chromedp.WithNewBrowserContext(
+		func(p *target.CreateBrowserContextParams) *target.CreateBrowserContextParams {
+			if proxyURL != "" {
+				p = p.WithProxyServer(proxyURL)
+			}
+			return p
+		},
+	))
+	closeAll := func() { cancel(); cancelBrowser(); cancelAlloc() }
+	if userAgent != "" {
+		if err := chromedp.Run(ctx, emulation.SetUserAgentOverride(userAgent)); err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("setting user agent: %w", err)
+		}
+	}
+	return ctx, closeAll, nil
+}
@@ -0,0 +1,82 @@
+package scrapers
+
+import (
+	"html"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+)
+
+// footnoteMarker matches footnote and citation markers left in text, such
+// as "[1]", "[a]" or "[note 2]".
+var footnoteMarker = regexp.MustCompile(`\s*\[(?:(?i:note|fn)\s*)?(?:\d{1,3}|[a-z])\]`)
+
+// listNumbering matches the numbering or bullet of a list item at the start
+// of a text: "1. ", "2) ", "(3) ", "b) ", "• ".
+var listNumbering = regexp.MustCompile(`^(?:\d{1,2}[.)]|\(\d{1,2}\)|\(?[a-h]\)|[•·▪‣◦–-])\s+`)
+
+// exampleNumbering is listNumbering without "1. ", which in examples is
+// more often a date ("17. mai er nasjonaldagen") than numbering.
+var exampleNumbering = regexp.MustCompile(`^(?:\d{1,2}\)|\(\d{1,2}\)|\(?[a-h]\)|[•·▪‣◦–-])\s+`)
+
+// CleanText removes what pages leave in scraped text: HTML entities that
+// were escaped twice or came through as text ("&amp;nbsp;", "&quot;"),
+// footnote markers, and runs of whitespace, which are collapsed to single
+// spaces.
+func CleanText(s string) string {
+	// Twice, for entities that were escaped twice
+	for range 2 {
+		if !strings.Contains(s, "&") {
+			break
+		}
+		s = html.UnescapeString(s)
+	}
+	s = footnoteMarker.ReplaceAllString(s, "")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// CleanDefinition cleans a definition like CleanText, and drops the list
+// numbering it starts with.
+func CleanDefinition(s string) string {
+	return listNumbering.ReplaceAllString(CleanText(s), "")
+}
+
+// CleanExample cleans an example like CleanText, and drops the list
+// numbering it starts with, except "1. ".
+func CleanExample(s string) string {
+	return exampleNumbering.ReplaceAllString(CleanText(s), "")
+}
+
+// CleanSense cleans the text of a scraped sense: definitions, examples,
+// expressions and etymology. Meanings and examples left empty are dropped,
+// and repeated ones merged by DedupeSense. Every scraper runs its senses
+// through it, so all sources come out the same.
+func CleanSense(sense *models.SenseEntry) {
+	sense.Category = CleanText(sense.Category)
+	sense.Etymology = CleanText(sense.Etymology)
+	for i := range sense.Meanings {
+		m := &sense.Meanings[i]
+		m.Description = CleanDefinition(m.Description)
+		for j := range m.Examples {
+			m.Examples[j] = CleanExample(m.Examples[j])
+		}
+		m.Examples = slices.DeleteFunc(m.Examples, func(ex string) bool { return ex == "" })
+	}
+	sense.Meanings = slices.DeleteFunc(sense.Meanings, func(m models.MeaningEntry) bool {
+		return m.Description == "" && len(m.Examples) == 0
+	})
+	for i := range sense.Expressions {
+		sense.Expressions[i].Phrase = CleanText(sense.Expressions[i].Phrase)
+		sense.Expressions[i].Explanation = CleanDefinition(sense.Expressions[i].Explanation)
+	}
+	DedupeSense(sense)
+}
+
+// CleanEntry runs CleanSense over every sense of an entry.
+func CleanEntry(entry *models.WordEntry) {
+	for i := range entry.Senses {
+		CleanSense(&entry.Senses[i])
+	}
+}
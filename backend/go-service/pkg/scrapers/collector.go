@@ -0,0 +1,26 @@
+package scrapers
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"time"
+
+	"github.com/gocolly/colly"
+)
+
+// NewCollector creates a colly collector that fetches through transport,
+// keeps cookies in jar and gives up on a page after timeout. Collectors
+// are cheap, so scrapers create one per page; sharing transport and jar
+// between them is what lets the pages of a word reuse open connections
+// and the cookies the site set.
+func NewCollector(transport http.RoundTripper, jar *cookiejar.Jar, timeout time.Duration) *colly.Collector {
+	c := colly.NewCollector()
+	c.WithTransport(transport)
+	if jar != nil {
+		c.SetCookieJar(jar)
+	}
+	if timeout > 0 {
+		c.SetRequestTimeout(timeout)
+	}
+	return c
+}
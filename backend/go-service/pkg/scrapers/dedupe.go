@@ -0,0 +1,74 @@
+package scrapers
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+)
+
+// DedupeSense drops meanings that repeat an earlier one of the sense, as
+// when a source gives the same explanation on a definition and on one of
+// its sub-definitions, and merges their examples into the meaning kept.
+// Meanings are compared ignoring case, punctuation and spacing. Repeated
+// examples and expressions are dropped the same way.
+func DedupeSense(sense *models.SenseEntry) {
+	kept := map[string]int{} // text key -> index in meanings
+	meanings := sense.Meanings[:0]
+	for _, m := range sense.Meanings {
+		key := TextKey(m.Description)
+		if i, ok := kept[key]; ok && key != "" {
+			meanings[i].Examples = append(meanings[i].Examples, m.Examples...)
+			continue
+		}
+		kept[key] = len(meanings)
+		meanings = append(meanings, m)
+	}
+	for i := range meanings {
+		meanings[i].Examples = dedupeTexts(meanings[i].Examples)
+	}
+	sense.Meanings = meanings
+
+	seen := map[string]bool{}
+	expressions := sense.Expressions[:0]
+	for _, e := range sense.Expressions {
+		key := TextKey(e.Phrase) + "\x00" + TextKey(e.Explanation)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		expressions = append(expressions, e)
+	}
+	sense.Expressions = expressions
+}
+
+// dedupeTexts drops the texts that repeat an earlier one.
+func dedupeTexts(texts []string) []string {
+	seen := map[string]bool{}
+	kept := texts[:0]
+	for _, t := range texts {
+		key := TextKey(t)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		kept = append(kept, t)
+	}
+	return kept
+}
+
+// TextKey is text lowercased, with punctuation dropped and spacing
+// collapsed, so "et hus; en bygning." and "Et hus, en bygning" have the
+// same key. Scrapers compare texts by it to find repeats.
+func TextKey(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return unicode.ToLower(r)
+		case unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r):
+			return ' '
+		}
+		return -1
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
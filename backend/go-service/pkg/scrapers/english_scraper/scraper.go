@@ -1,14 +1,17 @@
+// Package english_scraper is a stub for an English dictionary scraper.
 package english_scraper
 
 import (
-	"fmt"
-	"vocabulary-app/backend/go-service/models"
+	"log/slog"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/scrapers"
 )
 
 // ScrapeWord is a stub implementation for English dictionary scraping.
 // TODO: Implement actual scraping from an English dictionary source (e.g., Free Dictionary API, Wiktionary)
 func ScrapeWord(word string) (models.WordEntry, error) {
-	fmt.Println("🔷 [English] Stub scraper called for word:", word)
+	slog.Debug("stub scraper called", "word", word)
 	
 	// Return a stub entry with placeholder data
 	entry := models.WordEntry{
@@ -27,5 +30,6 @@ func ScrapeWord(word string) (models.WordEntry, error) {
 		},
 	}
 	
+	scrapers.CleanEntry(&entry)
 	return entry, nil
 }
@@ -1,14 +1,17 @@
+// Package german_scraper is a stub for a German dictionary scraper.
 package german_scraper
 
 import (
-	"fmt"
-	"vocabulary-app/backend/go-service/models"
+	"log/slog"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/scrapers"
 )
 
 // ScrapeWord is a stub implementation for German dictionary scraping.
 // TODO: Implement actual scraping from a German dictionary source (e.g., Duden, Wiktionary)
 func ScrapeWord(word string) (models.WordEntry, error) {
-	fmt.Println("🔸 [German] Stub scraper called for word:", word)
+	slog.Debug("stub scraper called", "word", word)
 	
 	// Return a stub entry with placeholder data
 	entry := models.WordEntry{
@@ -27,5 +30,6 @@ func ScrapeWord(word string) (models.WordEntry, error) {
 		},
 	}
 	
+	scrapers.CleanEntry(&entry)
 	return entry, nil
 }
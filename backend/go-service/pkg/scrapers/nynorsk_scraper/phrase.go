@@ -0,0 +1,64 @@
+package nynorsk_scraper
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/scrapers"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/sources"
+)
+
+// phraseCategory is the category of senses built from fixed expressions.
+const phraseCategory = "fast uttrykk"
+
+// ScrapePhrase looks up a multi-word phrase ("til og med", "ta igjen").
+// ordbokene.no lists Nynorsk phrases as fixed expressions inside the articles of
+// their words, so the search page for the phrase is scraped and every
+// expression matching it becomes a sense of its own, with the expression's
+// explanation as the meaning.
+func (sc *Scraper) ScrapePhrase(phrase string) (models.WordEntry, error) {
+	url := fmt.Sprintf("%s/nob/nn/%s", sc.settings.OrdbokeneURL, url.PathEscape(phrase))
+	entry := models.WordEntry{Word: phrase, SourceURL: url}
+
+	senseIDs, err := sc.ExtractSenseIDs(url)
+	if err != nil {
+		return entry, fmt.Errorf("failed to extract sense IDs: %w", err)
+	}
+	// The same expression can be listed under each of its words
+	seen := map[string]bool{}
+	for _, senseID := range senseIDs {
+		sense, err := sc.ScrapeSense(url, senseID)
+		if errors.Is(err, sources.ErrThrottled) {
+			return entry, err
+		}
+		if err != nil {
+			slog.Warn("scraping sense failed", "word", phrase, "language", "no-nn", "sense_id", senseID, "error", err)
+			entry.Warn(models.WarningSenseFailed, senseID, err)
+			continue
+		}
+		for i, expr := range sense.Expressions {
+			key := scrapers.TextKey(expr.Explanation)
+			if !matchesPhrase(expr.Phrase, phrase) || key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			entry.Senses = append(entry.Senses, models.SenseEntry{
+				ID:       fmt.Sprintf("%s_expr%d", senseID, i),
+				Category: phraseCategory,
+				Meanings: []models.MeaningEntry{{Description: expr.Explanation}},
+			})
+		}
+	}
+	return entry, nil
+}
+
+// matchesPhrase reports whether a listed expression is the phrase, either
+// exactly or followed by placeholders ("ta igjen noen" for "ta igjen").
+func matchesPhrase(expression, phrase string) bool {
+	expression = strings.Join(strings.Fields(strings.ToLower(expression)), " ")
+	return expression == phrase || strings.HasPrefix(expression, phrase+" ")
+}
@@ -0,0 +1,115 @@
+// Package nynorsk_scraper scrapes Norwegian Nynorsk entries from
+// ordbokene.no, like bokmal_scraper does for Bokmål.
+//
+// The package-level functions share one scraper configured by Configure;
+// New creates independent ones with their own settings and clients.
+package nynorsk_scraper
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/langcode"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/sources"
+)
+
+// ScrapeWord orchestrates the entire scraping process for Norwegian Nynorsk.
+// This is a stub implementation that adapts the Bokmål scraper for Nynorsk variant.
+func (sc *Scraper) ScrapeWord(word string) (models.WordEntry, error) {
+	// Nynorsk uses /nn/ instead of /bm/ in the URL
+	// Escape the word so letters like å and apostrophes make a valid path
+	return sc.ScrapeURL(word, fmt.Sprintf("%s/nob/nn/%s", sc.settings.OrdbokeneURL, url.PathEscape(word)))
+}
+
+// ScrapeURL scrapes the article page at url for word. ScrapeWord uses the
+// word's search page; pass an article's own page to pick one homograph.
+func (sc *Scraper) ScrapeURL(word, url string) (models.WordEntry, error) {
+	entry := models.WordEntry{Word: word, SourceURL: url}
+
+	// Step 1: Extract all sense IDs
+	senseIDs, err := sc.ExtractSenseIDs(url)
+	if err != nil {
+		return entry, fmt.Errorf("failed to extract sense IDs: %w", err)
+	}
+	if len(senseIDs) == 0 {
+		// The page has no article: the word isn't in the dictionary
+		return entry, &models.NotFoundError{Word: word, Language: langcode.Nynorsk}
+	}
+	slog.Debug("found sense IDs", "word", word, "language", "no-nn", "senses", senseIDs)
+	if limit := sc.settings.MaxSenses; limit > 0 && len(senseIDs) > limit {
+		// Senses past the limit would be cut from the entry anyway; don't scrape them
+		entry.Warn(models.WarningTruncated, "", fmt.Errorf("left out %d of %d senses", len(senseIDs)-limit, len(senseIDs)))
+		senseIDs = senseIDs[:limit]
+	}
+
+	// Step 2: Loop over each sense ID
+	for i, senseID := range senseIDs {
+		// Space out sense requests on top of the source's rate limit
+		if i > 0 && sc.settings.SenseDelay > 0 {
+			time.Sleep(sc.settings.SenseDelay)
+		}
+		start := time.Now()
+		sense, err := sc.ScrapeSense(url, senseID)
+		sources.Default.Record(langcode.Nynorsk, sources.StageSense, start, err)
+		if errors.Is(err, sources.ErrThrottled) {
+			// The remaining senses would fail too; let the caller retry the whole word
+			return entry, err
+		}
+		if err != nil {
+			slog.Warn("scraping sense failed", "word", word, "language", "no-nn", "sense_id", senseID, "error", err)
+			entry.Warn(models.WarningSenseFailed, senseID, err)
+			continue
+		}
+		entry.Senses = append(entry.Senses, sense)
+	}
+
+	// Step 3: Inflection, for all senses in one browser session, with a static
+	// fallback per sense when Chrome is unavailable or disabled
+	ids := make([]string, len(entry.Senses))
+	for i, sense := range entry.Senses {
+		ids[i] = sense.ID
+	}
+	var rendered map[string][]models.WordFormEntry
+	var failed map[string]error
+	var sessionErr error
+	if len(ids) > 0 {
+		start := time.Now()
+		rendered, failed, sessionErr = sc.ScrapeInflections(url, ids)
+		if !errors.Is(sessionErr, errBrowserDisabled) {
+			// A table that couldn't be read fails the stage as well
+			err := sessionErr
+			if err == nil && len(failed) > 0 {
+				err = fmt.Errorf("%d of %d inflection tables failed", len(failed), len(ids))
+			}
+			sources.Default.Record(langcode.Nynorsk, sources.StageInflection, start, err)
+		}
+	}
+	for i := range entry.Senses {
+		sense := &entry.Senses[i]
+		forms, ok := rendered[sense.ID]
+		if !ok {
+			err := failed[sense.ID]
+			if sessionErr != nil {
+				err = sessionErr
+			}
+			if !errors.Is(err, errBrowserDisabled) {
+				slog.Warn("inflection scrape failed, trying static fallback", "word", word, "language", "no-nn", "sense_id", sense.ID, "error", err)
+				entry.Warn(models.WarningInflectionFallback, sense.ID, err)
+			}
+			entry.InflectionsPartial = true
+			forms, err = sc.ScrapeInflectionStatic(url, sense.ID)
+			if err != nil {
+				slog.Warn("static inflection fallback failed", "word", word, "language", "no-nn", "sense_id", sense.ID, "error", err)
+				entry.Warn(models.WarningInflectionFailed, sense.ID, err)
+				continue
+			}
+		}
+		sense.WordForms = forms
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,207 @@
+package nynorsk_scraper
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/scrapers"
+
+	"github.com/gocolly/colly"
+)
+
+// ExtractSenseIDs scans the page and returns a list of sense IDs (Nynorsk variant).
+func (sc *Scraper) ExtractSenseIDs(url string) ([]string, error) {
+	var ids []string
+	c := sc.newCollector()
+
+	c.OnHTML("div.article.flex.flex-col", func(e *colly.HTMLElement) {
+		id := e.ChildAttr("div.flex.flex-col.grow", "id")
+		if id != "" {
+			slog.Debug("found sense ID", "sense_id", id)
+			ids = append(ids, id)
+		}
+	})
+
+	// ordbokene answers 404 for some words it has no article for
+	var status int
+	c.OnError(func(r *colly.Response, _ error) {
+		status = r.StatusCode
+	})
+
+	if err := c.Visit(url); err != nil {
+		if status == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// ScrapeSense scrapes one sense block for Nynorsk.
+func (sc *Scraper) ScrapeSense(url, senseID string) (models.SenseEntry, error) {
+	var sense models.SenseEntry
+	sense.ID = senseID
+
+	c := sc.newCollector()
+	selector := fmt.Sprintf("div#%s", senseID)
+	c.OnHTML(selector, func(e *colly.HTMLElement) {
+		sense.ID = senseID
+		sense.Category = strings.TrimSpace(e.ChildText(".subheader .header-group-list"))
+		sense.Gender = strings.TrimSpace(e.ChildText(".subheader em"))
+
+		e.ForEach("section.pronunciation li", func(_ int, pron *colly.HTMLElement) {
+			if text := strings.TrimSpace(pron.Text); text != "" {
+				sense.Pronunciations = append(sense.Pronunciations, models.Pronunciation{
+					Text: text, Notation: models.NotationRespelling, Audio: pronunciationAudio(pron),
+				})
+			}
+		})
+
+		e.ForEach("section.definitions .definition.level1", func(_ int, def *colly.HTMLElement) {
+			def.ForEach(".explanation", func(_ int, exp *colly.HTMLElement) {
+				desc := strings.TrimSpace(exp.Text)
+				if desc != "" {
+					meaning := models.MeaningEntry{Description: desc}
+
+					def.ForEach("ul.examples li", func(_ int, ex *colly.HTMLElement) {
+						exText := strings.TrimSpace(ex.Text)
+						if exText != "" {
+							meaning.Examples = append(meaning.Examples, exText)
+						}
+					})
+
+					sense.Meanings = append(sense.Meanings, meaning)
+				}
+			})
+
+			def.ForEach("ol.sub_definitions li.definition.level2", func(_ int, subDef *colly.HTMLElement) {
+				subDef.ForEach(".explanation", func(_ int, exp *colly.HTMLElement) {
+					desc := strings.TrimSpace(exp.Text)
+					if desc != "" {
+						meaning := models.MeaningEntry{Description: desc}
+
+						subDef.ForEach("ul.examples li", func(_ int, ex *colly.HTMLElement) {
+							exText := strings.TrimSpace(ex.Text)
+							if exText != "" {
+								meaning.Examples = append(meaning.Examples, exText)
+							}
+						})
+
+						sense.Meanings = append(sense.Meanings, meaning)
+					}
+				})
+			})
+		})
+
+		var origins []string
+		e.ForEach("section.etymology li", func(_ int, ety *colly.HTMLElement) {
+			if text := strings.TrimSpace(ety.Text); text != "" {
+				origins = append(origins, text)
+			}
+		})
+		sense.Etymology = strings.Join(origins, "; ")
+
+		sense.References = parseReferences(e)
+
+		e.ForEach("section.expressions li", func(_ int, expr *colly.HTMLElement) {
+			phrase := strings.TrimSpace(expr.ChildText("strong"))
+			explanation := strings.TrimSpace(expr.ChildText(".explanation"))
+			if phrase != "" {
+				sense.Expressions = append(sense.Expressions, models.ExpressionEntry{
+					Phrase: phrase, Explanation: explanation,
+				})
+			}
+		})
+	})
+
+	if err := c.Visit(url); err != nil {
+		return sense, err
+	}
+	scrapers.CleanSense(&sense)
+	return sense, nil
+}
+
+// pronunciationAudio returns the absolute URL of the recording attached to
+// a pronunciation, if any.
+func pronunciationAudio(pron *colly.HTMLElement) string {
+	src := pron.ChildAttr("audio", "src")
+	if src == "" {
+		src = pron.ChildAttr("audio source", "src")
+	}
+	if src == "" {
+		return ""
+	}
+	return pron.Request.AbsoluteURL(src)
+}
+
+// referenceCues map the word before a link to its relation, in both written
+// standards ("se"/"sjå", "jf."/"jamfør").
+var referenceCues = map[string]string{
+	"se":         models.RelationSee,
+	"sjå":        models.RelationSee,
+	"jf":         models.RelationCompare,
+	"jamfør":     models.RelationCompare,
+	"sammenlign": models.RelationCompare,
+	"samanlikn":  models.RelationCompare,
+	"samanlikna": models.RelationCompare,
+	"sammenlikn": models.RelationCompare,
+	"motsatt":    models.RelationCompare,
+	"motsett":    models.RelationCompare,
+}
+
+// parseReferences collects the article links (<a class="article_ref">) in
+// a sense's definitions and etymology. The relation comes from the word
+// just before the link, or the section for etymology links.
+func parseReferences(e *colly.HTMLElement) []models.Reference {
+	var refs []models.Reference
+	seen := map[models.Reference]bool{}
+	add := func(ref models.Reference) {
+		if ref.Word != "" && !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+
+	e.ForEach("section.definitions a.article_ref", func(_ int, a *colly.HTMLElement) {
+		add(models.Reference{Word: strings.TrimSpace(a.Text), Relation: referenceRelation(a), ArticleID: articleID(a.Attr("href"))})
+	})
+	e.ForEach("section.etymology a.article_ref", func(_ int, a *colly.HTMLElement) {
+		add(models.Reference{Word: strings.TrimSpace(a.Text), Relation: models.RelationOrigin, ArticleID: articleID(a.Attr("href"))})
+	})
+	return refs
+}
+
+// referenceRelation reads the cue word in the link's parent text just
+// before the link.
+func referenceRelation(a *colly.HTMLElement) string {
+	parent := a.DOM.Parent().Text()
+	i := strings.Index(parent, a.Text)
+	if i <= 0 {
+		return models.RelationRelated
+	}
+	words := strings.FieldsFunc(strings.ToLower(parent[:i]), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	if len(words) == 0 {
+		return models.RelationRelated
+	}
+	if relation, ok := referenceCues[words[len(words)-1]]; ok {
+		return relation
+	}
+	return models.RelationRelated
+}
+
+// articleID returns the numeric article id in a link such as "/bm/12345/hund".
+func articleID(href string) string {
+	for _, segment := range strings.Split(href, "/") {
+		if segment != "" && strings.Trim(segment, "0123456789") == "" {
+			return segment
+		}
+	}
+	return ""
+}
@@ -0,0 +1,51 @@
+// Package scrapers holds what the dictionary scrapers in its subpackages
+// share. Use package dictionary to scrape any supported language; the
+// subpackages scrape one source each:
+//
+//   - bokmal_scraper and nynorsk_scraper: Norwegian Bokmål and Nynorsk from
+//     ordbokene.no and its article API
+//   - english_scraper, spanish_scraper, german_scraper: stubs
+//   - wordforms: grammatical features from inflection table labels
+package scrapers
+
+import "time"
+
+// Settings configures the ordbokene.no scrapers.
+type Settings struct {
+	// OrdbokeneURL is the base URL of the Norwegian dictionary site.
+	OrdbokeneURL string
+	// ArticleAPIURL is the base URL of the ordbokene article JSON API.
+	ArticleAPIURL string
+	// RequestTimeout bounds each page and article API request.
+	RequestTimeout time.Duration
+	// BrowserEnabled turns on Chrome for inflection tables; when off, the
+	// static fallback is used directly.
+	BrowserEnabled bool
+	// BrowserTimeout bounds the Chrome inflection scrape of one sense; a
+	// word's senses share a session bounded by their sum.
+	BrowserTimeout time.Duration
+	// BrowserURL attaches to a running Chrome (e.g. a headless-shell
+	// container) at this DevTools URL instead of starting a local one.
+	BrowserURL string
+	// SenseDelay is a pause between the requests for each sense of a word.
+	SenseDelay time.Duration
+	// ResolveLemmas looks inflected input ("hunder") up as its lemma
+	// ("hund") before scraping, at the cost of one suggest API request.
+	ResolveLemmas bool
+	// MaxSenses caps the senses scraped for a word; the rest are left out
+	// with a truncated warning. Zero means no limit.
+	MaxSenses int
+}
+
+// DefaultSettings are the settings scrapers use until configured: the
+// public sites, with Chrome for inflection tables and lemma resolution on.
+func DefaultSettings() Settings {
+	return Settings{
+		OrdbokeneURL:   "https://ordbokene.no",
+		ArticleAPIURL:  "https://ord.uib.no",
+		RequestTimeout: 10 * time.Second,
+		BrowserEnabled: true,
+		BrowserTimeout: 40 * time.Second,
+		ResolveLemmas:  true,
+	}
+}
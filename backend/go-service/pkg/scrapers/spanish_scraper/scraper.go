@@ -1,14 +1,17 @@
+// Package spanish_scraper is a stub for a Spanish dictionary scraper.
 package spanish_scraper
 
 import (
-	"fmt"
-	"vocabulary-app/backend/go-service/models"
+	"log/slog"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/scrapers"
 )
 
 // ScrapeWord is a stub implementation for Spanish dictionary scraping.
 // TODO: Implement actual scraping from a Spanish dictionary source (e.g., RAE, WordReference)
 func ScrapeWord(word string) (models.WordEntry, error) {
-	fmt.Println("🔶 [Spanish] Stub scraper called for word:", word)
+	slog.Debug("stub scraper called", "word", word)
 	
 	// Return a stub entry with placeholder data
 	entry := models.WordEntry{
@@ -27,5 +30,6 @@ func ScrapeWord(word string) (models.WordEntry, error) {
 		},
 	}
 	
+	scrapers.CleanEntry(&entry)
 	return entry, nil
 }
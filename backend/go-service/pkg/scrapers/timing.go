@@ -0,0 +1,33 @@
+package scrapers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/sources"
+)
+
+// TimeRequests wraps base so every request through it is recorded with
+// sources.Default as a StageFetch of language. Responses with an error
+// status count as failures, except 404: that is the source saying it has
+// no such word.
+func TimeRequests(language string, base http.RoundTripper) http.RoundTripper {
+	return timedTransport{language: language, base: base}
+}
+
+type timedTransport struct {
+	language string
+	base     http.RoundTripper
+}
+
+func (t timedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	failure := err
+	if err == nil && resp.StatusCode >= 400 && resp.StatusCode != http.StatusNotFound {
+		failure = fmt.Errorf("%s returned %s", req.URL.Host, resp.Status)
+	}
+	sources.Default.Record(t.language, sources.StageFetch, start, failure)
+	return resp, err
+}
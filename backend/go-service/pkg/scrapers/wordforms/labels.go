@@ -0,0 +1,109 @@
+package wordforms
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/langcode"
+)
+
+// maxUnrecognized bounds the distinct labels recorded; once reached, only
+// labels already recorded are counted, so a page full of junk can't grow
+// the record without limit.
+const maxUnrecognized = 1000
+
+// fields names the metadata fields for Configure.
+var fields = map[string]Field{
+	"number": Number, "definiteness": Definiteness, "gender": Gender,
+	"degree": Degree, "tense": Tense, "person": Person, "mood": Mood,
+	"voice": Voice, "case": Case,
+}
+
+// configured holds the "language:word" keys Configure has set.
+var configured = map[string]bool{}
+
+// Configure adds label words to the vocabularies, so a label a source
+// starts using can be read without a code change. Each spec maps one word
+// to one piece of metadata as "language:word=field=value", e.g.
+// "no-bm:infinitiv=mood=infinitive"; repeat the word for several. Configured
+// words override built-in ones. Call it before scraping starts.
+func Configure(specs []string) error {
+	for _, spec := range specs {
+		rawLanguage, mapping, _ := strings.Cut(spec, ":")
+		word, feature, _ := strings.Cut(mapping, "=")
+		name, value, _ := strings.Cut(feature, "=")
+		language, known := langcode.Normalize(rawLanguage)
+		field, ok := fields[strings.ToLower(name)]
+		word = strings.ToLower(strings.TrimSpace(word))
+		if !known || !ok || word == "" || value == "" {
+			return fmt.Errorf("invalid label mapping %q, want language:word=field=value", spec)
+		}
+		vocab := vocabularies[language]
+		if vocab == nil {
+			vocab = Vocabulary{}
+			vocabularies[language] = vocab
+		}
+		if !configured[language+":"+word] {
+			// The first configured feature replaces the built-in ones
+			vocab[word] = nil
+			configured[language+":"+word] = true
+		}
+		vocab[word] = append(vocab[word], Feature{field, value})
+	}
+	return nil
+}
+
+// UnrecognizedLabel is an inflection row label no word of which is in its
+// language's vocabulary, so its row came without metadata.
+type UnrecognizedLabel struct {
+	Language string    `json:"language"`
+	Label    string    `json:"label"`
+	Count    uint64    `json:"count"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+type labelRecord struct {
+	mu     sync.Mutex
+	labels map[[2]string]*UnrecognizedLabel // language, label
+}
+
+var unrecognized = labelRecord{labels: map[[2]string]*UnrecognizedLabel{}}
+
+func (r *labelRecord) record(language, label string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	l, ok := r.labels[[2]string{language, label}]
+	if !ok {
+		if len(r.labels) >= maxUnrecognized {
+			return
+		}
+		l = &UnrecognizedLabel{Language: language, Label: label}
+		r.labels[[2]string{language, label}] = l
+	}
+	l.Count++
+	l.LastSeen = time.Now()
+}
+
+// Unrecognized lists the labels recorded as unrecognized since the process
+// started, most frequent first. Map their words with Configure.
+func Unrecognized() []UnrecognizedLabel {
+	unrecognized.mu.Lock()
+	defer unrecognized.mu.Unlock()
+	labels := make([]UnrecognizedLabel, 0, len(unrecognized.labels))
+	for _, l := range unrecognized.labels {
+		labels = append(labels, *l)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if labels[i].Count != labels[j].Count {
+			return labels[i].Count > labels[j].Count
+		}
+		if labels[i].Language != labels[j].Language {
+			return labels[i].Language < labels[j].Language
+		}
+		return labels[i].Label < labels[j].Label
+	})
+	return labels
+}
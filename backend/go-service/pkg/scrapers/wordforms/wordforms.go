@@ -0,0 +1,260 @@
+// Package wordforms reads grammatical metadata (number, definiteness,
+// gender, degree, tense, person, mood, voice, case) out of the labels of
+// inflection table rows, e.g. "flertall / bestemt form" or
+// "Indikativ / Präsens / 1. Person Singular".
+//
+// Labels are split into words and each word is looked up in the language's
+// vocabulary, so "ubestemt" never matches "bestemt". When a label names the
+// same feature twice, the later word wins: "pretérito imperfecto" is
+// imperfect, not past.
+//
+// Labels that set nothing are counted (see Unrecognized), and words can be
+// added to a vocabulary from the configuration (see Configure).
+package wordforms
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/langcode"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+)
+
+// Feature is a piece of metadata a label word sets.
+type Feature struct {
+	Field Field
+	Value string
+}
+
+// Field names a WordFormEntry metadata field.
+type Field int
+
+const (
+	Number Field = iota
+	Definiteness
+	Gender
+	Degree
+	Tense
+	Person
+	Mood
+	Voice
+	Case
+)
+
+// Vocabulary maps lower-case label words to the metadata they set. A word
+// may set several fields (Spanish "nosotros" is first person plural).
+type Vocabulary map[string][]Feature
+
+// personNumerals are read as person when the label also says "person".
+var personNumerals = map[string]string{"1": "first", "2": "second", "3": "third"}
+
+// Entry builds a word form row for label, with the metadata the vocabulary
+// of a canonical language finds in it. Labels it finds nothing in are
+// recorded as unrecognized.
+func Entry(language, label string, forms []string) models.WordFormEntry {
+	entry := models.WordFormEntry{Label: label, Forms: forms}
+	vocab := vocabularies[language]
+
+	words := strings.FieldsFunc(strings.ToLower(label), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	hasPerson := false
+	for _, w := range words {
+		if w == "person" || w == "persona" || w == "personne" {
+			hasPerson = true
+		}
+	}
+
+	recognized := false
+	for _, w := range words {
+		if person, ok := personNumerals[w]; ok && hasPerson {
+			entry.Person = person
+			recognized = true
+			continue
+		}
+		for _, f := range vocab[w] {
+			set(&entry, f)
+			recognized = true
+		}
+	}
+	if !recognized {
+		unrecognized.record(language, label)
+	}
+	return entry
+}
+
+func set(entry *models.WordFormEntry, f Feature) {
+	switch f.Field {
+	case Number:
+		entry.Number = f.Value
+	case Definiteness:
+		entry.Definiteness = f.Value
+	case Gender:
+		entry.Gender = f.Value
+	case Degree:
+		entry.Degree = f.Value
+	case Tense:
+		entry.Tense = f.Value
+	case Person:
+		entry.Person = f.Value
+	case Mood:
+		entry.Mood = f.Value
+	case Voice:
+		entry.Voice = f.Value
+	case Case:
+		entry.Case = f.Value
+	}
+}
+
+// merge combines vocabularies; later ones win on shared words.
+func merge(vocabs ...Vocabulary) Vocabulary {
+	merged := Vocabulary{}
+	for _, v := range vocabs {
+		for word, features := range v {
+			merged[word] = features
+		}
+	}
+	return merged
+}
+
+func one(field Field, value string) []Feature {
+	return []Feature{{field, value}}
+}
+
+func two(field1 Field, value1 string, field2 Field, value2 string) []Feature {
+	return []Feature{{field1, value1}, {field2, value2}}
+}
+
+// vocabularies are the vocabularies by canonical language code.
+var vocabularies = map[string]Vocabulary{
+	langcode.Bokmal:  Bokmal,
+	langcode.Nynorsk: Nynorsk,
+	langcode.German:  German,
+	langcode.Spanish: Spanish,
+	langcode.English: English,
+}
+
+// Bokmal covers ordbokene's Bokmål inflection tables.
+var Bokmal = Vocabulary{
+	"entall":       one(Number, "singular"),
+	"flertall":     one(Number, "plural"),
+	"ubestemt":     one(Definiteness, "indefinite"),
+	"bestemt":      one(Definiteness, "definite"),
+	"hankjønn":     one(Gender, "masculine"),
+	"hunkjønn":     one(Gender, "feminine"),
+	"intetkjønn":   one(Gender, "neuter"),
+	"positiv":      one(Degree, "positive"),
+	"komparativ":   one(Degree, "comparative"),
+	"superlativ":   one(Degree, "superlative"),
+	"presens":      one(Tense, "present"),
+	"preteritum":   one(Tense, "past"),
+	"perfektum":    one(Tense, "perfect"),
+	"imperativ":    one(Mood, "imperative"),
+	"aktiv":        one(Voice, "active"),
+	"passiv":       one(Voice, "passive"),
+	"subjektsform": one(Case, "nominative"),
+	"objektsform":  one(Case, "accusative"),
+	"genitiv":      one(Case, "genitive"),
+}
+
+// Nynorsk covers ordbokene's Nynorsk tables, which may also carry the
+// Bokmål labels used for the article API fallback.
+var Nynorsk = merge(Bokmal, Vocabulary{
+	"eintal":     one(Number, "singular"),
+	"fleirtal":   one(Number, "plural"),
+	"ubunden":    one(Definiteness, "indefinite"),
+	"bunden":     one(Definiteness, "definite"),
+	"hokjønn":    one(Gender, "feminine"),
+	"inkjekjønn": one(Gender, "neuter"),
+})
+
+// German covers Wiktionary/Duden style tables.
+var German = Vocabulary{
+	"singular":        one(Number, "singular"),
+	"plural":          one(Number, "plural"),
+	"unbestimmt":      one(Definiteness, "indefinite"),
+	"bestimmt":        one(Definiteness, "definite"),
+	"maskulinum":      one(Gender, "masculine"),
+	"femininum":       one(Gender, "feminine"),
+	"neutrum":         one(Gender, "neuter"),
+	"positiv":         one(Degree, "positive"),
+	"komparativ":      one(Degree, "comparative"),
+	"superlativ":      one(Degree, "superlative"),
+	"präsens":         one(Tense, "present"),
+	"präteritum":      one(Tense, "past"),
+	"perfekt":         one(Tense, "perfect"),
+	"plusquamperfekt": one(Tense, "pluperfect"),
+	"futur":           one(Tense, "future"),
+	"indikativ":       one(Mood, "indicative"),
+	"konjunktiv":      one(Mood, "subjunctive"),
+	"imperativ":       one(Mood, "imperative"),
+	"aktiv":           one(Voice, "active"),
+	"passiv":          one(Voice, "passive"),
+	"nominativ":       one(Case, "nominative"),
+	"akkusativ":       one(Case, "accusative"),
+	"dativ":           one(Case, "dative"),
+	"genitiv":         one(Case, "genitive"),
+	"ich":             two(Person, "first", Number, "singular"),
+	"du":              two(Person, "second", Number, "singular"),
+	"er":              two(Person, "third", Number, "singular"),
+	"wir":             two(Person, "first", Number, "plural"),
+	"ihr":             two(Person, "second", Number, "plural"),
+}
+
+// Spanish covers conjugation tables labelled by mood, tense and pronoun.
+var Spanish = Vocabulary{
+	"singular":         one(Number, "singular"),
+	"plural":           one(Number, "plural"),
+	"masculino":        one(Gender, "masculine"),
+	"femenino":         one(Gender, "feminine"),
+	"comparativo":      one(Degree, "comparative"),
+	"superlativo":      one(Degree, "superlative"),
+	"presente":         one(Tense, "present"),
+	"pretérito":        one(Tense, "past"),
+	"indefinido":       one(Tense, "past"),
+	"imperfecto":       one(Tense, "imperfect"),
+	"perfecto":         one(Tense, "perfect"),
+	"pluscuamperfecto": one(Tense, "pluperfect"),
+	"futuro":           one(Tense, "future"),
+	"indicativo":       one(Mood, "indicative"),
+	"subjuntivo":       one(Mood, "subjunctive"),
+	"imperativo":       one(Mood, "imperative"),
+	"condicional":      one(Mood, "conditional"),
+	"activa":           one(Voice, "active"),
+	"pasiva":           one(Voice, "passive"),
+	"primera":          one(Person, "first"),
+	"segunda":          one(Person, "second"),
+	"tercera":          one(Person, "third"),
+	"yo":               two(Person, "first", Number, "singular"),
+	"tú":               two(Person, "second", Number, "singular"),
+	"vos":              two(Person, "second", Number, "singular"),
+	"él":               two(Person, "third", Number, "singular"),
+	"ella":             two(Person, "third", Number, "singular"),
+	"usted":            two(Person, "third", Number, "singular"),
+	"nosotros":         two(Person, "first", Number, "plural"),
+	"nosotras":         two(Person, "first", Number, "plural"),
+	"vosotros":         two(Person, "second", Number, "plural"),
+	"vosotras":         two(Person, "second", Number, "plural"),
+	"ellos":            two(Person, "third", Number, "plural"),
+	"ellas":            two(Person, "third", Number, "plural"),
+	"ustedes":          two(Person, "third", Number, "plural"),
+}
+
+// English covers the few inflections English marks.
+var English = Vocabulary{
+	"singular":    one(Number, "singular"),
+	"plural":      one(Number, "plural"),
+	"comparative": one(Degree, "comparative"),
+	"superlative": one(Degree, "superlative"),
+	"present":     one(Tense, "present"),
+	"past":        one(Tense, "past"),
+	"perfect":     one(Tense, "perfect"),
+	"imperative":  one(Mood, "imperative"),
+	"subjunctive": one(Mood, "subjunctive"),
+	"active":      one(Voice, "active"),
+	"passive":     one(Voice, "passive"),
+	"first":       one(Person, "first"),
+	"second":      one(Person, "second"),
+	"third":       one(Person, "third"),
+	"possessive":  one(Case, "genitive"),
+}
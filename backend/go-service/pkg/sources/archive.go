@@ -0,0 +1,65 @@
+package sources
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// archiveMaxSize caps how much of a page is buffered for archiving.
+const archiveMaxSize = 10 << 20
+
+// Archiver stores raw pages fetched from sources.
+type Archiver interface {
+	Save(rawURL string, body []byte, fetchedAt time.Time)
+}
+
+// SetArchiver keeps a copy of every HTML page fetched from a known source.
+// Call it before scraping starts.
+func (r *Registry) SetArchiver(a Archiver) {
+	r.archiver = a
+}
+
+// Archive hands a page or DOM snapshot to the archiver, for content that
+// doesn't go through Transport (e.g. chromedp). It does nothing when
+// archiving is off.
+func (r *Registry) Archive(rawURL string, body []byte) {
+	if r.archiver != nil {
+		r.archiver.Save(rawURL, body, time.Now())
+	}
+}
+
+// archiveResponse reads an HTML response from a known source into memory,
+// archives it, and replaces resp.Body so the caller sees the same bytes.
+func (r *Registry) archiveResponse(req *http.Request, resp *http.Response) error {
+	if r.archiver == nil || resp.StatusCode != http.StatusOK {
+		return nil
+	}
+	if _, ok := r.ForHost(req.URL.Hostname()); !ok {
+		return nil
+	}
+	if !strings.Contains(resp.Header.Get("Content-Type"), "html") {
+		return nil
+	}
+
+	orig := resp.Body
+	body, err := io.ReadAll(io.LimitReader(orig, archiveMaxSize+1))
+	if err != nil {
+		orig.Close()
+		return err
+	}
+	if len(body) > archiveMaxSize {
+		// Too large to keep; hand the caller what was read plus the rest
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), orig), orig}
+		return nil
+	}
+	orig.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	r.archiver.Save(req.URL.String(), body, time.Now())
+	return nil
+}
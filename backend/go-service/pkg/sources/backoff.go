@@ -0,0 +1,179 @@
+package sources
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// backoffInitial is how long to back off after a rate-limit response
+	// without Retry-After; it doubles with each consecutive one.
+	backoffInitial = time.Minute
+	// backoffMax caps both the doubling and what Retry-After may ask for.
+	backoffMax = 30 * time.Minute
+)
+
+// ErrThrottled is returned while a source is asking us to slow down.
+var ErrThrottled = errors.New("source is throttling us")
+
+// ThrottledError carries the source and when it may be retried.
+type ThrottledError struct {
+	Source  string
+	RetryAt time.Time
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("%s: %v (retry at %s)", e.Source, ErrThrottled, e.RetryAt.Format(time.RFC3339))
+}
+
+func (e *ThrottledError) Unwrap() error { return ErrThrottled }
+
+// RetryAfter is how long until the source may be retried, rounded up to
+// whole seconds for the Retry-After header.
+func (e *ThrottledError) RetryAfter() time.Duration {
+	d := time.Until(e.RetryAt)
+	if d < 0 {
+		return 0
+	}
+	return d.Truncate(time.Second) + time.Second
+}
+
+// backoff tracks rate-limit responses from one source.
+type backoff struct {
+	mu          sync.Mutex
+	until       time.Time
+	consecutive int
+	total       int
+	last        time.Time
+}
+
+// BackoffStatus is a snapshot of a source's rate-limit backoff.
+type BackoffStatus struct {
+	Source         string     `json:"source"`
+	ThrottledUntil *time.Time `json:"throttled_until,omitempty"`
+	RateLimited    int        `json:"rate_limited"` // 429 (or 503 with Retry-After) responses since startup
+	LastRateLimit  *time.Time `json:"last_rate_limit,omitempty"`
+}
+
+// check returns a *ThrottledError while the source is backing off.
+func (b *backoff) check(source string, now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if now.Before(b.until) {
+		return &ThrottledError{Source: source, RetryAt: b.until}
+	}
+	return nil
+}
+
+// record starts a backoff after a rate-limit response. retryAfter is the
+// delay the source asked for, or 0 to back off exponentially.
+func (b *backoff) record(source string, retryAfter time.Duration, now time.Time) *ThrottledError {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.total++
+	b.last = now
+	b.consecutive++
+	if retryAfter <= 0 {
+		retryAfter = backoffInitial << min(b.consecutive-1, 5)
+	}
+	retryAfter = min(retryAfter, backoffMax)
+	if until := now.Add(retryAfter); until.After(b.until) {
+		b.until = until
+	}
+	slog.Warn("source is rate limiting us, backing off", "source", source, "retry_at", b.until.Format(time.RFC3339), "consecutive", b.consecutive)
+	return &ThrottledError{Source: source, RetryAt: b.until}
+}
+
+// reset ends the run of consecutive rate-limit responses.
+func (b *backoff) reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.consecutive = 0
+}
+
+func (b *backoff) status(source string, now time.Time) BackoffStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s := BackoffStatus{Source: source, RateLimited: b.total}
+	if now.Before(b.until) {
+		until := b.until
+		s.ThrottledUntil = &until
+	}
+	if !b.last.IsZero() {
+		last := b.last
+		s.LastRateLimit = &last
+	}
+	return s
+}
+
+// CheckBackoff returns a *ThrottledError if the source serving host has
+// recently rate limited us. Hosts that don't belong to a known source are
+// not checked.
+func (r *Registry) CheckBackoff(host string) error {
+	p, ok := r.ForHost(host)
+	if !ok {
+		return nil
+	}
+	return p.backoff.check(p.Name, time.Now())
+}
+
+// BackoffUntil returns when the source serving a canonical language may be
+// contacted again, or the zero time if it isn't backing off.
+func (r *Registry) BackoffUntil(language string) time.Time {
+	p, ok := r.ForLanguage(language)
+	if !ok {
+		return time.Time{}
+	}
+	var throttled *ThrottledError
+	if errors.As(p.backoff.check(p.Name, time.Now()), &throttled) {
+		return throttled.RetryAt
+	}
+	return time.Time{}
+}
+
+// observe inspects a response from host and starts a backoff if the source
+// answered with 429 Too Many Requests, or 503 with a Retry-After.
+func (r *Registry) observe(host string, resp *http.Response) error {
+	p, ok := r.ForHost(host)
+	if !ok {
+		return nil
+	}
+	retryAfter, hasRetryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	if resp.StatusCode == http.StatusTooManyRequests || (resp.StatusCode == http.StatusServiceUnavailable && hasRetryAfter) {
+		return p.backoff.record(p.Name, retryAfter, time.Now())
+	}
+	if resp.StatusCode < 400 {
+		p.backoff.reset()
+	}
+	return nil
+}
+
+// parseRetryAfter reads a Retry-After value in seconds or as an HTTP date.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// Backoffs returns the rate-limit backoff status of every source.
+func (r *Registry) Backoffs() []BackoffStatus {
+	now := time.Now()
+	statuses := make([]BackoffStatus, 0, len(r.profiles))
+	for _, p := range r.profiles {
+		statuses = append(statuses, p.backoff.status(p.Name, now))
+	}
+	return statuses
+}
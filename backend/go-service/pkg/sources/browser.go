@@ -0,0 +1,27 @@
+package sources
+
+import "context"
+
+// SetBrowserLimit caps how many Chrome instances may run at once across all
+// scrapers; 0 means unlimited. Call it before scraping starts.
+func (r *Registry) SetBrowserLimit(n int) {
+	if n <= 0 {
+		r.browsers = nil
+		return
+	}
+	r.browsers = make(chan struct{}, n)
+}
+
+// AcquireBrowser waits until a Chrome instance may be started, or ctx is
+// done. Call release once the browser has exited.
+func (r *Registry) AcquireBrowser(ctx context.Context) (release func(), err error) {
+	if r.browsers == nil {
+		return func() {}, nil
+	}
+	select {
+	case r.browsers <- struct{}{}:
+		return func() { <-r.browsers }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
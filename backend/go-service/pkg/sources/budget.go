@@ -0,0 +1,120 @@
+package sources
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ErrBudgetExhausted is returned when a source's daily request budget is used up.
+var ErrBudgetExhausted = errors.New("daily request budget exhausted")
+
+// BudgetError carries the source and reset time of an exhausted budget.
+type BudgetError struct {
+	Source  string
+	ResetAt time.Time
+}
+
+func (e *BudgetError) Error() string {
+	return fmt.Sprintf("%s: %v (resets at %s)", e.Source, ErrBudgetExhausted, e.ResetAt.Format(time.RFC3339))
+}
+
+func (e *BudgetError) Unwrap() error { return ErrBudgetExhausted }
+
+// budget counts requests to one source per UTC day.
+type budget struct {
+	mu    sync.Mutex
+	limit int // 0 means unlimited
+	day   string
+	used  int
+}
+
+// BudgetStatus is a snapshot of a source's budget for the current day.
+type BudgetStatus struct {
+	Source    string    `json:"source"`
+	Limit     int       `json:"limit"`
+	Used      int       `json:"used"`
+	Remaining int       `json:"remaining"`
+	ResetAt   time.Time `json:"reset_at"`
+}
+
+func (b *budget) reserve(source string, now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.rollover(now)
+	if b.limit > 0 && b.used >= b.limit {
+		return &BudgetError{Source: source, ResetAt: nextUTCMidnight(now)}
+	}
+	b.used++
+	return nil
+}
+
+func (b *budget) status(source string, now time.Time) BudgetStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.rollover(now)
+	remaining := -1 // unlimited
+	if b.limit > 0 {
+		remaining = b.limit - b.used
+	}
+	return BudgetStatus{
+		Source:    source,
+		Limit:     b.limit,
+		Used:      b.used,
+		Remaining: remaining,
+		ResetAt:   nextUTCMidnight(now),
+	}
+}
+
+func (b *budget) rollover(now time.Time) {
+	if day := now.UTC().Format("2006-01-02"); day != b.day {
+		b.day = day
+		b.used = 0
+	}
+}
+
+func nextUTCMidnight(now time.Time) time.Time {
+	y, m, d := now.UTC().Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+}
+
+// Reserve counts one request to host against its source's daily budget.
+// Hosts that don't belong to a known source are not limited.
+func (r *Registry) Reserve(host string) error {
+	p, ok := r.ForHost(host)
+	if !ok {
+		return nil
+	}
+	return p.budget.reserve(p.Name, time.Now())
+}
+
+// ReserveURL applies the same checks as Transport (robots.txt, backoff, then
+// Reserve) to rawURL, for traffic that doesn't go through Transport (e.g. chromedp
+// navigations).
+func (r *Registry) ReserveURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if err := r.CheckRobots(u); err != nil {
+		return err
+	}
+	if err := r.CheckBackoff(u.Hostname()); err != nil {
+		return err
+	}
+	return r.Reserve(u.Hostname())
+}
+
+// Budgets returns today's budget status for every source.
+func (r *Registry) Budgets() []BudgetStatus {
+	now := time.Now()
+	statuses := make([]BudgetStatus, 0, len(r.profiles))
+	for _, p := range r.profiles {
+		statuses = append(statuses, p.budget.status(p.Name, now))
+	}
+	return statuses
+}
@@ -0,0 +1,130 @@
+package sources
+
+import (
+	"errors"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// ErrDisabled is returned when a scrape targets a disabled language or source.
+var ErrDisabled = errors.New("disabled")
+
+// DisabledError names what is disabled.
+type DisabledError struct {
+	Kind string // "source" or "language"
+	Name string
+}
+
+func (e *DisabledError) Error() string {
+	return fmt.Sprintf("%s %s is %v", e.Kind, e.Name, ErrDisabled)
+}
+
+func (e *DisabledError) Unwrap() error { return ErrDisabled }
+
+// Flags reports which sources and languages are enabled.
+type Flags struct {
+	Sources   map[string]bool `json:"sources"`
+	Languages map[string]bool `json:"languages"`
+}
+
+var flagsBucket = []byte("feature_flags")
+
+// SetSourceEnabled switches a source, and with it all its languages, on or off.
+func (r *Registry) SetSourceEnabled(name string, enabled bool) error {
+	if !r.hasSource(name) {
+		return fmt.Errorf("unknown source %q", name)
+	}
+	return r.setFlag("source:"+name, enabled)
+}
+
+// SetLanguageEnabled switches a canonical language on or off.
+func (r *Registry) SetLanguageEnabled(language string, enabled bool) error {
+	if _, ok := r.ForLanguage(language); !ok {
+		return fmt.Errorf("unknown language %q", language)
+	}
+	return r.setFlag("language:"+language, enabled)
+}
+
+// CheckEnabled returns a *DisabledError if language or its source is switched off.
+func (r *Registry) CheckEnabled(language string) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.disabled["language:"+language] {
+		return &DisabledError{Kind: "language", Name: language}
+	}
+	if p, ok := r.ForLanguage(language); ok && r.disabled["source:"+p.Name] {
+		return &DisabledError{Kind: "source", Name: p.Name}
+	}
+	return nil
+}
+
+// Flags returns the current state of every source and language.
+func (r *Registry) Flags() Flags {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	flags := Flags{Sources: map[string]bool{}, Languages: map[string]bool{}}
+	for _, p := range r.profiles {
+		flags.Sources[p.Name] = !r.disabled["source:"+p.Name]
+		for _, l := range p.Languages {
+			flags.Languages[l] = !r.disabled["language:"+l]
+		}
+	}
+	return flags
+}
+
+// PersistFlags stores runtime changes to the flags in db, so they survive
+// restarts, and applies the changes stored earlier. Stored changes take
+// precedence over the configured defaults.
+func (r *Registry) PersistFlags(db *bolt.DB) error {
+	stored := map[string]bool{}
+	err := db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(flagsBucket)
+		if err != nil {
+			return err
+		}
+		return b.ForEach(func(k, v []byte) error {
+			stored[string(k)] = string(v) == "on"
+			return nil
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("loading feature flags: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key, enabled := range stored {
+		r.disabled[key] = !enabled
+	}
+	r.flagDB = db
+	return nil
+}
+
+func (r *Registry) setFlag(key string, enabled bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.flagDB != nil {
+		value := "off"
+		if enabled {
+			value = "on"
+		}
+		err := r.flagDB.Update(func(tx *bolt.Tx) error {
+			return tx.Bucket(flagsBucket).Put([]byte(key), []byte(value))
+		})
+		if err != nil {
+			return fmt.Errorf("saving feature flag: %w", err)
+		}
+	}
+	r.disabled[key] = !enabled
+	return nil
+}
+
+func (r *Registry) hasSource(name string) bool {
+	for _, p := range r.profiles {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package sources
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// SetUserAgent sets the User-Agent sent to every source. Sources may still
+// override it through their own headers.
+func (r *Registry) SetUserAgent(ua string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.userAgent = ua
+}
+
+// Headers returns the request headers for host: the configured User-Agent,
+// overlaid with the headers of the source serving host.
+func (r *Registry) Headers(host string) http.Header {
+	h := http.Header{}
+	r.mu.RLock()
+	if r.userAgent != "" {
+		h.Set("User-Agent", r.userAgent)
+	}
+	r.mu.RUnlock()
+
+	if p, ok := r.ForHost(host); ok {
+		for name, value := range p.Headers {
+			h.Set(name, value)
+		}
+	}
+	return h
+}
+
+// HeadersForURL is Headers for the host of rawURL, for traffic that doesn't
+// go through Transport (e.g. chromedp navigations).
+func (r *Registry) HeadersForURL(rawURL string) http.Header {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return r.Headers("")
+	}
+	return r.Headers(u.Hostname())
+}
@@ -0,0 +1,36 @@
+package sources
+
+import "time"
+
+// Stages of a scrape whose duration and outcome are recorded.
+const (
+	// StageWord is a whole word: lookup, senses, inflections and extras.
+	StageWord = "word"
+	// StageFetch is one HTTP request to a source: a page or an API call.
+	StageFetch = "fetch"
+	// StageSense is scraping and parsing one sense of an article.
+	StageSense = "sense"
+	// StageInflection is the Chrome session reading a word's inflection
+	// tables.
+	StageInflection = "inflection"
+)
+
+// Recorder receives how long each stage of a scrape took, and whether it
+// failed, per canonical language.
+type Recorder interface {
+	Record(language, stage string, d time.Duration, err error)
+}
+
+// SetRecorder times every scrape stage with rec. Call it before scraping
+// starts.
+func (r *Registry) SetRecorder(rec Recorder) {
+	r.recorder = rec
+}
+
+// Record reports a stage that started at start and ended with err. It does
+// nothing when no Recorder is set.
+func (r *Registry) Record(language, stage string, start time.Time, err error) {
+	if r.recorder != nil {
+		r.recorder.Record(language, stage, time.Since(start), err)
+	}
+}
@@ -0,0 +1,256 @@
+package sources
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/langcode"
+)
+
+// Profile describes an upstream dictionary source and how we may use it.
+type Profile struct {
+	Name      string   `json:"name"`
+	Host      string   `json:"host,omitempty"`
+	Languages []string `json:"languages"`
+	// Version identifies the scraper output for this source. Bump it when a
+	// parser change alters entries, so re-scraped words get new idempotency
+	// keys instead of being dropped downstream as duplicates.
+	Version string `json:"version"`
+	// License is the license the source's content is published under,
+	// copied onto every entry for attribution.
+	License string `json:"license,omitempty"`
+	// BatchWindow restricts background work (batch and pre-warm jobs) to the
+	// source's quiet hours. Nil means background work may run at any time.
+	BatchWindow *Window `json:"batch_window,omitempty"`
+	// DailyBudget caps upstream requests per UTC day. 0 means unlimited.
+	DailyBudget int `json:"daily_budget,omitempty"`
+	// RespectRobots makes requests to Host honor its robots.txt.
+	RespectRobots bool `json:"respect_robots"`
+	// RateLimit caps requests per second to Host across all scrapers, and
+	// RateBurst how many may go out back to back. 0 means unlimited.
+	RateLimit float64 `json:"rate_limit,omitempty"`
+	RateBurst int     `json:"rate_burst,omitempty"`
+	// Headers are sent with every request to Host, overriding the defaults
+	// (including User-Agent). Not exposed, as they may carry credentials.
+	Headers map[string]string `json:"-"`
+
+	budget   budget
+	throttle throttle
+	backoff  backoff
+}
+
+// Registry holds the known source profiles.
+type Registry struct {
+	profiles []*Profile
+	// browsers holds a token per running Chrome instance; nil means unlimited.
+	browsers chan struct{}
+
+	mu sync.RWMutex
+	// disabled holds switched-off "source:<name>" and "language:<code>" keys.
+	disabled map[string]bool
+	// flagDB persists flag changes; nil until PersistFlags is called.
+	flagDB *bolt.DB
+
+	robots  robotsCache
+	proxies proxyPool
+	// userAgent is sent to every source unless its headers override it.
+	userAgent string
+	// archiver keeps fetched pages; nil when archiving is off.
+	archiver Archiver
+	// recorder times scrape stages; nil when no one is listening.
+	recorder Recorder
+}
+
+// Default is the registry shared by the scrapers and the HTTP handlers, so
+// request accounting sees all upstream traffic.
+var Default = DefaultRegistry()
+
+// DefaultRegistry returns the built-in source profiles, with overrides from
+// the environment:
+//
+//	SOURCE_<NAME>_BATCH_WINDOW="01:00-06:00 CET"
+//	SOURCE_<NAME>_DAILY_BUDGET=5000
+//	SOURCE_<NAME>_RESPECT_ROBOTS=false
+//	SOURCE_<NAME>_RATE_LIMIT=2 (requests per second)
+//	SOURCE_<NAME>_RATE_BURST=1
+//	SOURCE_<NAME>_HEADERS='{"Accept-Language": "nb"}'
+func DefaultRegistry() *Registry {
+	r := &Registry{disabled: map[string]bool{}, profiles: []*Profile{
+		{Name: "ordbokene", Host: "ordbokene.no", Languages: []string{langcode.Bokmal, langcode.Nynorsk}, Version: "1", License: "CC BY 4.0", RespectRobots: true, RateLimit: 2, RateBurst: 1},
+		{Name: "english-stub", Languages: []string{langcode.English}, Version: "1"},
+		{Name: "spanish-stub", Languages: []string{langcode.Spanish}, Version: "1"},
+		{Name: "german-stub", Languages: []string{langcode.German}, Version: "1"},
+	}}
+
+	for _, p := range r.profiles {
+		prefix := "SOURCE_" + strings.ToUpper(strings.ReplaceAll(p.Name, "-", "_"))
+		if v := os.Getenv(prefix + "_BATCH_WINDOW"); v != "" {
+			w, err := ParseWindow(v)
+			if err != nil {
+				slog.Warn("ignoring invalid batch window", "variable", prefix+"_BATCH_WINDOW", "error", err)
+			} else {
+				p.BatchWindow = w
+			}
+		}
+		if v := os.Getenv(prefix + "_DAILY_BUDGET"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				slog.Warn("ignoring invalid daily budget, want a non-negative number", "variable", prefix+"_DAILY_BUDGET", "value", v)
+			} else {
+				p.DailyBudget = n
+			}
+		}
+		if v := os.Getenv(prefix + "_RESPECT_ROBOTS"); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				slog.Warn("ignoring invalid robots.txt setting, want true or false", "variable", prefix+"_RESPECT_ROBOTS", "value", v)
+			} else {
+				p.RespectRobots = b
+			}
+		}
+		if v := os.Getenv(prefix + "_RATE_LIMIT"); v != "" {
+			rps, err := strconv.ParseFloat(v, 64)
+			if err != nil || rps < 0 {
+				slog.Warn("ignoring invalid rate limit, want a non-negative number", "variable", prefix+"_RATE_LIMIT", "value", v)
+			} else {
+				p.RateLimit = rps
+			}
+		}
+		if v := os.Getenv(prefix + "_RATE_BURST"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				slog.Warn("ignoring invalid rate burst, want a positive number", "variable", prefix+"_RATE_BURST", "value", v)
+			} else {
+				p.RateBurst = n
+			}
+		}
+		if v := os.Getenv(prefix + "_HEADERS"); v != "" {
+			var headers map[string]string
+			if err := json.Unmarshal([]byte(v), &headers); err != nil {
+				slog.Warn("ignoring invalid headers, want a JSON object of strings", "variable", prefix+"_HEADERS", "error", err)
+			} else {
+				p.Headers = headers
+			}
+		}
+		if p.RateLimit > 0 && p.RateBurst < 1 {
+			p.RateBurst = 1
+		}
+		p.budget.limit = p.DailyBudget
+		p.throttle.rate = p.RateLimit
+		p.throttle.burst = float64(p.RateBurst)
+	}
+	return r
+}
+
+// All returns every registered profile.
+func (r *Registry) All() []*Profile {
+	return r.profiles
+}
+
+// ForLanguage returns the profile serving a canonical language code.
+func (r *Registry) ForLanguage(language string) (*Profile, bool) {
+	for _, p := range r.profiles {
+		for _, l := range p.Languages {
+			if l == language {
+				return p, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// ForHost returns the profile for an upstream host name.
+func (r *Registry) ForHost(host string) (*Profile, bool) {
+	for _, p := range r.profiles {
+		if p.Host != "" && (host == p.Host || strings.HasSuffix(host, "."+p.Host)) {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// Window is a daily time range in a specific time zone, e.g. 01:00–06:00 CET.
+// Windows may wrap past midnight (22:00–05:00).
+type Window struct {
+	Start    time.Duration  `json:"-"` // offset from local midnight
+	End      time.Duration  `json:"-"`
+	Location *time.Location `json:"-"`
+	Spec     string         `json:"spec"`
+}
+
+// ParseWindow parses "HH:MM-HH:MM [Zone]". The zone defaults to UTC.
+func ParseWindow(spec string) (*Window, error) {
+	fields := strings.Fields(spec)
+	if len(fields) == 0 || len(fields) > 2 {
+		return nil, fmt.Errorf("invalid window %q, want \"HH:MM-HH:MM [Zone]\"", spec)
+	}
+
+	startStr, endStr, ok := strings.Cut(fields[0], "-")
+	if !ok {
+		return nil, fmt.Errorf("invalid window %q, missing '-'", spec)
+	}
+	start, err := parseClock(startStr)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseClock(endStr)
+	if err != nil {
+		return nil, err
+	}
+	if start == end {
+		return nil, fmt.Errorf("invalid window %q, start equals end", spec)
+	}
+
+	loc := time.UTC
+	if len(fields) == 2 {
+		if loc, err = time.LoadLocation(fields[1]); err != nil {
+			return nil, fmt.Errorf("invalid window zone: %w", err)
+		}
+	}
+	return &Window{Start: start, End: end, Location: loc, Spec: spec}, nil
+}
+
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, want HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Contains reports whether t falls inside the window.
+func (w *Window) Contains(t time.Time) bool {
+	local := t.In(w.Location)
+	offset := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute
+	if w.Start < w.End {
+		return offset >= w.Start && offset < w.End
+	}
+	return offset >= w.Start || offset < w.End
+}
+
+// NextOpen returns t if the window is open, otherwise the next time it opens.
+func (w *Window) NextOpen(t time.Time) time.Time {
+	if w.Contains(t) {
+		return t
+	}
+	local := t.In(w.Location)
+	open := atClock(local, w.Start)
+	if !open.After(local) {
+		open = atClock(local.AddDate(0, 0, 1), w.Start)
+	}
+	return open
+}
+
+// atClock returns the given clock time on t's date, in t's location.
+func atClock(t time.Time, clock time.Duration) time.Time {
+	h, m := int(clock/time.Hour), int(clock%time.Hour/time.Minute)
+	return time.Date(t.Year(), t.Month(), t.Day(), h, m, 0, 0, t.Location())
+}
@@ -0,0 +1,203 @@
+package sources
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// proxyMaxFailures is how many consecutive failures put a proxy on cooldown.
+const proxyMaxFailures = 3
+
+// directTransport connects to the sources when no proxies are configured.
+// It is shared by every scraper so their connections are pooled.
+var directTransport = pooledTransport()
+
+// pooledTransport is tuned for fetching many pages from a few hosts: a
+// word's page, its senses and its article API requests go one after
+// another to the same host, so more idle connections are kept per host
+// than http.DefaultTransport's two and reused instead of dialled again.
+func pooledTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConns = 100
+	t.MaxIdleConnsPerHost = 16
+	t.IdleConnTimeout = 90 * time.Second
+	return t
+}
+
+// proxyPool holds the outbound proxies used for scraping. With no proxies,
+// requests connect directly.
+type proxyPool struct {
+	mu       sync.Mutex
+	proxies  []*proxy
+	rotate   bool // round-robin instead of sticking to the first healthy proxy
+	cooldown time.Duration
+	next     int
+}
+
+type proxy struct {
+	url       *url.URL
+	transport *http.Transport
+
+	requests      int
+	failures      int // consecutive
+	totalFailures int
+	lastError     string
+	coolingUntil  time.Time
+}
+
+// ProxyStatus is a snapshot of one proxy's health.
+type ProxyStatus struct {
+	URL                 string     `json:"url"`
+	Requests            int        `json:"requests"`
+	Failures            int        `json:"failures"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastError           string     `json:"last_error,omitempty"`
+	CoolingUntil        *time.Time `json:"cooling_until,omitempty"`
+}
+
+// SetProxies routes scraping traffic through the given http, https or
+// socks5 proxy URLs. With rotate, requests take turns across proxies;
+// otherwise the first healthy proxy is used. A proxy that fails
+// repeatedly is skipped for cooldown. Call it before scraping starts.
+func (r *Registry) SetProxies(rawURLs []string, rotate bool, cooldown time.Duration) error {
+	proxies := make([]*proxy, 0, len(rawURLs))
+	for _, raw := range rawURLs {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return fmt.Errorf("invalid proxy %q: %w", raw, err)
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return fmt.Errorf("invalid proxy %s: scheme must be http, https or socks5", u.Redacted())
+		}
+		t := pooledTransport()
+		t.Proxy = http.ProxyURL(u)
+		proxies = append(proxies, &proxy{url: u, transport: t})
+	}
+
+	r.proxies.mu.Lock()
+	defer r.proxies.mu.Unlock()
+	r.proxies.proxies = proxies
+	r.proxies.rotate = rotate
+	r.proxies.cooldown = cooldown
+	r.proxies.next = 0
+	return nil
+}
+
+// PickProxy chooses a proxy for traffic that doesn't go through Transport
+// (e.g. chromedp, via its proxy-server flag). It returns "" when no
+// proxies are configured. The URL has no credentials, since Chrome can't
+// take them on the command line. Call done with the outcome of the request
+// so failing proxies are rotated out.
+func (r *Registry) PickProxy() (proxyURL string, done func(error)) {
+	p := r.proxies.pick(time.Now())
+	if p == nil {
+		return "", func(error) {}
+	}
+	u := *p.url
+	u.User = nil
+	return u.String(), func(err error) { r.proxies.report(p, err, time.Now()) }
+}
+
+// Proxies returns the health of every configured proxy.
+func (r *Registry) Proxies() []ProxyStatus {
+	r.proxies.mu.Lock()
+	defer r.proxies.mu.Unlock()
+
+	now := time.Now()
+	statuses := make([]ProxyStatus, 0, len(r.proxies.proxies))
+	for _, p := range r.proxies.proxies {
+		s := ProxyStatus{
+			URL:                 p.url.Redacted(),
+			Requests:            p.requests,
+			Failures:            p.totalFailures,
+			ConsecutiveFailures: p.failures,
+			LastError:           p.lastError,
+		}
+		if p.coolingUntil.After(now) {
+			until := p.coolingUntil
+			s.CoolingUntil = &until
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses
+}
+
+// pick returns the next healthy proxy, or if all are cooling down, the one
+// that recovers first. It returns nil when no proxies are configured.
+func (pp *proxyPool) pick(now time.Time) *proxy {
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+
+	n := len(pp.proxies)
+	if n == 0 {
+		return nil
+	}
+	start := 0
+	if pp.rotate {
+		start = pp.next
+		pp.next = (pp.next + 1) % n
+	}
+
+	var soonest *proxy
+	for i := 0; i < n; i++ {
+		p := pp.proxies[(start+i)%n]
+		if !p.coolingUntil.After(now) {
+			p.requests++
+			return p
+		}
+		if soonest == nil || p.coolingUntil.Before(soonest.coolingUntil) {
+			soonest = p
+		}
+	}
+	soonest.requests++
+	return soonest
+}
+
+// report records the outcome of a request through p.
+func (pp *proxyPool) report(p *proxy, err error, now time.Time) {
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+
+	if err == nil {
+		p.failures = 0
+		return
+	}
+	p.failures++
+	p.totalFailures++
+	p.lastError = err.Error()
+	if p.failures >= proxyMaxFailures {
+		p.failures = 0
+		p.coolingUntil = now.Add(pp.cooldown)
+		slog.Warn("proxy failing, cooling down", "proxy", p.url.Redacted(), "until", p.coolingUntil.Format(time.RFC3339), "error", err)
+	}
+}
+
+// proxyTransport sends requests through the registry's proxies, or directly
+// when none are configured.
+type proxyTransport struct {
+	registry *Registry
+}
+
+func (t proxyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	p := t.registry.proxies.pick(time.Now())
+	if p == nil {
+		return directTransport.RoundTrip(req)
+	}
+
+	resp, err := p.transport.RoundTrip(req)
+	outcome := err
+	if err == nil && resp.StatusCode == http.StatusProxyAuthRequired {
+		outcome = fmt.Errorf("proxy: %s", resp.Status)
+	}
+	// A caller giving up says nothing about the proxy
+	if req.Context().Err() == nil {
+		t.registry.proxies.report(p, outcome, time.Now())
+	}
+	return resp, err
+}
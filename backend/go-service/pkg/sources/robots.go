@@ -0,0 +1,226 @@
+package sources
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RobotsAgent is the product token matched against robots.txt User-agent lines.
+const RobotsAgent = "vocabulary-app"
+
+const (
+	// robotsTTL is how long a fetched robots.txt is trusted, as RFC 9309 suggests.
+	robotsTTL = 24 * time.Hour
+	// robotsRetry is how soon an unreachable robots.txt is fetched again.
+	robotsRetry = 5 * time.Minute
+	// robotsMaxSize caps how much of a robots.txt is read.
+	robotsMaxSize = 500 << 10
+)
+
+// ErrDisallowed is returned when a source's robots.txt forbids a URL.
+var ErrDisallowed = errors.New("disallowed by robots.txt")
+
+// robotsCache holds parsed robots.txt rules per scheme and host.
+type robotsCache struct {
+	mu      sync.Mutex
+	entries map[string]*robotsEntry
+}
+
+type robotsEntry struct {
+	ready   chan struct{} // closed once rules are fetched
+	rules   *robotsRules
+	expires time.Time
+}
+
+// CheckRobots returns ErrDisallowed if u belongs to a source that respects
+// robots.txt and the host's robots.txt forbids it. Hosts that don't belong
+// to a known source are not checked.
+func (r *Registry) CheckRobots(u *url.URL) error {
+	p, ok := r.ForHost(u.Hostname())
+	if !ok || !p.RespectRobots || u.Path == "/robots.txt" {
+		return nil
+	}
+	client := &http.Client{Timeout: 10 * time.Second, Transport: proxyTransport{registry: r}}
+	if !r.robots.rules(u, client, r.Headers(u.Hostname())).allowed(u.RequestURI()) {
+		return fmt.Errorf("%s: %w", u.Redacted(), ErrDisallowed)
+	}
+	return nil
+}
+
+// rules returns the cached rules for u's host, fetching them if missing or
+// stale. Concurrent callers for the same host share one fetch.
+func (c *robotsCache) rules(u *url.URL, client *http.Client, header http.Header) *robotsRules {
+	key := u.Scheme + "://" + u.Host
+
+	c.mu.Lock()
+	if c.entries == nil {
+		c.entries = map[string]*robotsEntry{}
+	}
+	e, ok := c.entries[key]
+	if ok {
+		select {
+		case <-e.ready:
+			if time.Now().After(e.expires) {
+				ok = false
+			}
+		default:
+		}
+	}
+	if !ok {
+		e = &robotsEntry{ready: make(chan struct{})}
+		c.entries[key] = e
+		c.mu.Unlock()
+
+		e.rules, e.expires = fetchRobots(client, key, header)
+		close(e.ready)
+		return e.rules
+	}
+	c.mu.Unlock()
+
+	<-e.ready
+	return e.rules
+}
+
+// fetchRobots downloads and parses origin's robots.txt with client, sending
+// header with the request. Following RFC 9309, a missing file (4xx) allows
+// everything, while server or network errors disallow everything until the
+// next retry.
+func fetchRobots(client *http.Client, origin string, header http.Header) (*robotsRules, time.Time) {
+	now := time.Now()
+	req, err := http.NewRequest(http.MethodGet, origin+"/robots.txt", nil)
+	if err != nil {
+		return disallowAll, now.Add(robotsRetry)
+	}
+	req.Header = header
+	resp, err := client.Do(req)
+	if err != nil {
+		slog.Warn("fetching robots.txt failed, treating source as disallowed", "origin", origin, "error", err)
+		return disallowAll, now.Add(robotsRetry)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 500:
+		slog.Warn("fetching robots.txt failed, treating source as disallowed", "origin", origin, "status", resp.StatusCode)
+		return disallowAll, now.Add(robotsRetry)
+	case resp.StatusCode >= 400:
+		return &robotsRules{}, now.Add(robotsTTL)
+	}
+
+	rules := parseRobots(io.LimitReader(resp.Body, robotsMaxSize), RobotsAgent)
+	slog.Debug("robots.txt loaded", "origin", origin, "rules", len(rules.rules))
+	return rules, now.Add(robotsTTL)
+}
+
+// robotsRules are the Allow and Disallow lines that apply to our agent.
+type robotsRules struct {
+	rules []robotsRule
+}
+
+type robotsRule struct {
+	allow   bool
+	pattern string
+}
+
+var disallowAll = &robotsRules{rules: []robotsRule{{allow: false, pattern: "/"}}}
+
+// parseRobots reads the group for agent from a robots.txt, falling back to
+// the "*" group when no group names the agent.
+func parseRobots(r io.Reader, agent string) *robotsRules {
+	var specific, wildcard []robotsRule
+	var matchesAgent, matchesWildcard, inRules bool
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			// A user-agent line after rules starts a new group
+			if inRules {
+				matchesAgent, matchesWildcard, inRules = false, false, false
+			}
+			name := strings.ToLower(value)
+			if name == "*" {
+				matchesWildcard = true
+			} else if name == strings.ToLower(agent) {
+				matchesAgent = true
+			}
+		case "allow", "disallow":
+			inRules = true
+			if value == "" {
+				continue // an empty Disallow allows everything
+			}
+			rule := robotsRule{allow: key == "allow", pattern: value}
+			if matchesAgent {
+				specific = append(specific, rule)
+			}
+			if matchesWildcard {
+				wildcard = append(wildcard, rule)
+			}
+		}
+	}
+
+	if specific != nil {
+		return &robotsRules{rules: specific}
+	}
+	return &robotsRules{rules: wildcard}
+}
+
+// allowed applies the most specific (longest) matching rule to path (with
+// its query string); on a tie Allow wins. Paths no rule matches are allowed.
+func (r *robotsRules) allowed(path string) bool {
+	allow, best := true, -1
+	for _, rule := range r.rules {
+		if !robotsMatch(rule.pattern, path) {
+			continue
+		}
+		if n := len(rule.pattern); n > best || (n == best && rule.allow) {
+			allow, best = rule.allow, n
+		}
+	}
+	return allow
+}
+
+// robotsMatch reports whether path matches pattern, where "*" matches any
+// run of characters and a trailing "$" anchors the end of the path.
+func robotsMatch(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	rest := path[len(parts[0]):]
+	for _, part := range parts[1:] {
+		i := strings.Index(rest, part)
+		if i < 0 {
+			return false
+		}
+		rest = rest[i+len(part):]
+	}
+	if !anchored {
+		return true
+	}
+	if rest == "" {
+		return true
+	}
+	// The last part must sit at the very end, which a greedy "*" may allow
+	last := parts[len(parts)-1]
+	return len(parts) > 1 && strings.HasSuffix(path, last)
+}
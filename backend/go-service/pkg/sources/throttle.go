@@ -0,0 +1,80 @@
+// Package sources governs the requests scrapers make to dictionary sites.
+// A Registry rate-limits each source, backs off when it throttles us,
+// spends a daily request budget, rotates proxies, sets request headers and can
+// switch sources and languages off. Scrapers use the shared Default.
+package sources
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// throttle paces requests to one source with a token bucket. Unlike the
+// API rate limiter, callers wait for their turn instead of being rejected,
+// so scrapes slow down rather than fail.
+type throttle struct {
+	mu     sync.Mutex
+	rate   float64 // requests per second; 0 means unlimited
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// reserve takes a token and returns how long the caller must wait before
+// using it. Tokens may go negative: each waiting caller holds a later slot.
+func (t *throttle) reserve(now time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.rate <= 0 {
+		return 0
+	}
+	if t.last.IsZero() {
+		t.tokens = t.burst
+	} else {
+		t.tokens = math.Min(t.burst, t.tokens+now.Sub(t.last).Seconds()*t.rate)
+	}
+	t.last = now
+	t.tokens--
+	if t.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-t.tokens / t.rate * float64(time.Second))
+}
+
+// Wait blocks until a request to host is allowed by its source's rate
+// limit, or ctx is done. Hosts that don't belong to a known source are not
+// limited.
+func (r *Registry) Wait(ctx context.Context, host string) error {
+	p, ok := r.ForHost(host)
+	if !ok {
+		return nil
+	}
+	delay := p.throttle.reserve(time.Now())
+	if delay <= 0 {
+		return nil
+	}
+	slog.DebugContext(ctx, "throttling request to source", "source", p.Name, "host", host, "delay", delay.Round(time.Millisecond))
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// WaitURL is Wait for the host of rawURL.
+func (r *Registry) WaitURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	return r.Wait(ctx, u.Hostname())
+}
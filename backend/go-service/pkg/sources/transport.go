@@ -0,0 +1,53 @@
+package sources
+
+import "net/http"
+
+// Transport wraps base so every outgoing request is checked against the
+// registry's per-source policies (robots.txt, rate-limit backoff, daily
+// budget and rate limit) before it leaves the process, and carries the
+// source's headers. A 429 from a source is returned as a *ThrottledError.
+// HTML pages are archived when an Archiver is set.
+func (r *Registry) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = proxyTransport{registry: r}
+	}
+	return &transport{registry: r, base: base}
+}
+
+type transport struct {
+	registry *Registry
+	base     http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.registry.CheckRobots(req.URL); err != nil {
+		return nil, err
+	}
+	if err := t.registry.CheckBackoff(req.URL.Hostname()); err != nil {
+		return nil, err
+	}
+	if err := t.registry.Reserve(req.URL.Hostname()); err != nil {
+		return nil, err
+	}
+	if err := t.registry.Wait(req.Context(), req.URL.Hostname()); err != nil {
+		return nil, err
+	}
+
+	// RoundTrippers must not modify the caller's request
+	req = req.Clone(req.Context())
+	for name, values := range t.registry.Headers(req.URL.Hostname()) {
+		req.Header[name] = values
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.registry.observe(req.URL.Hostname(), resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if err := t.registry.archiveResponse(req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
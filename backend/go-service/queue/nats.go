@@ -0,0 +1,91 @@
+// Package queue publishes scraped entries to a NATS JetStream stream, so
+// the Python service (and any other subscriber) can consume them at its own
+// pace and catch up after being offline.
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"vocabulary-app/backend/go-service/config"
+	"vocabulary-app/backend/go-service/requestid"
+)
+
+const (
+	// StreamName is the JetStream stream holding word events.
+	StreamName = "WORDS"
+	// ScrapedSubject prefixes the subject of each scraped entry; the
+	// entry's language is appended, e.g. "words.scraped.no-bm".
+	ScrapedSubject = "words.scraped"
+)
+
+// Publisher publishes entries to JetStream.
+type Publisher struct {
+	nc      *nats.Conn
+	js      jetstream.JetStream
+	timeout time.Duration
+}
+
+// Connect connects to NATS and makes sure the stream exists.
+func Connect(cfg config.QueueConfig) (*Publisher, error) {
+	nc, err := nats.Connect(cfg.NATSURL, nats.Name("vocabulary-go-service"), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, fmt.Errorf("connecting to NATS at %s: %w", cfg.NATSURL, err)
+	}
+	js, err := jetstream.New(nc)
+	if err != nil {
+		nc.Close()
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.PublishTimeout)
+	defer cancel()
+	_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     StreamName,
+		Subjects: []string{"words.>"},
+		MaxAge:   cfg.Retention,
+		Storage:  jetstream.FileStorage,
+	})
+	if err != nil {
+		nc.Close()
+		return nil, fmt.Errorf("creating stream %s: %w", StreamName, err)
+	}
+	return &Publisher{nc: nc, js: js, timeout: cfg.PublishTimeout}, nil
+}
+
+// Close drains the connection.
+func (p *Publisher) Close() error {
+	return p.nc.Drain()
+}
+
+// Publish publishes entry and waits for JetStream to acknowledge it.
+func (p *Publisher) Publish(ctx context.Context, entry models.WordEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	language := entry.Language
+	if language == "" {
+		language = "unknown"
+	}
+	msg := nats.NewMsg(ScrapedSubject + "." + language)
+	msg.Data = data
+	msg.Header.Set("Content-Type", "application/json")
+	if id := requestid.FromContext(ctx); id != "" {
+		msg.Header.Set(requestid.Header, id)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	if _, err := p.js.PublishMsg(ctx, msg); err != nil {
+		return fmt.Errorf("publishing %q: %w", entry.Word, err)
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/langcode"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/sources"
+
+	"vocabulary-app/backend/go-service/frequency"
+)
+
+// maxCachedLemmas bounds the lemma cache; it is cleared when full.
+const maxCachedLemmas = 50000
+
+// analyzeWorkers is how many words are lemmatized at once. The source's
+// rate limit applies on top.
+const analyzeWorkers = 4
+
+// AnalyzedWord is a word found in analyzed text, in its dictionary form.
+type AnalyzedWord struct {
+	Word string `json:"word"`
+	// Forms are the inflected forms the text used, other than Word itself.
+	Forms     []string          `json:"forms,omitempty"`
+	Count     int               `json:"count"`
+	Frequency *models.Frequency `json:"frequency,omitempty"`
+	CEFR      *models.CEFRLevel `json:"cefr,omitempty"`
+}
+
+// Analysis lists the distinct words of a text, in order of first use.
+type Analysis struct {
+	Language string         `json:"language"`
+	Tokens   int            `json:"tokens"`
+	Words    []AnalyzedWord `json:"words"`
+	// Partial is set when not every word could be lemmatized, because the
+	// text had too many distinct words or ctx ran out; those words are
+	// listed as written.
+	Partial bool `json:"partial,omitempty"`
+}
+
+// Tokenize splits text into normalized words: runs of letters, which may
+// hold an apostrophe or hyphen between letters ("don't", "e-post"). Runs
+// with digits in them ("3rd", "mp3") are left out.
+func Tokenize(canonical, text string) []string {
+	var words []string
+	runes := []rune(text)
+	start := -1
+	flush := func(end int) {
+		token := runes[start:end]
+		start = -1
+		if !slices.ContainsFunc(token, unicode.IsDigit) {
+			words = append(words, models.NormalizeWord(canonical, string(token)))
+		}
+	}
+	for i, r := range runes {
+		inner := (r == '\'' || r == '’' || r == '-') && start >= 0 && i+1 < len(runes) && unicode.IsLetter(runes[i+1])
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r) || inner {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			flush(i)
+		}
+	}
+	if start >= 0 {
+		flush(len(runes))
+	}
+	return words
+}
+
+// Analyze tokenizes text in a language and maps each word to its lemma,
+// lemmatizing at most maxWords distinct words, the most used first.
+// Languages whose source can't look forms up, or that are switched off,
+// keep words as written.
+func (lr *LanguageRouter) Analyze(ctx context.Context, text, language string, maxWords int) (Analysis, error) {
+	canonical, ok := langcode.Normalize(language)
+	if !ok {
+		return Analysis{}, fmt.Errorf("unsupported language: %s", language)
+	}
+	tokens := Tokenize(canonical, text)
+	analysis := Analysis{Language: canonical, Tokens: len(tokens), Words: []AnalyzedWord{}}
+
+	counts := map[string]int{}
+	var distinct []string
+	for _, token := range tokens {
+		if counts[token] == 0 {
+			distinct = append(distinct, token)
+		}
+		counts[token]++
+	}
+
+	lemmas := make([]string, len(distinct))
+	copy(lemmas, distinct)
+	if sources.Default.CheckEnabled(canonical) == nil {
+		order := make([]int, len(distinct))
+		for i := range order {
+			order[i] = i
+		}
+		slices.SortStableFunc(order, func(a, b int) int {
+			return counts[distinct[b]] - counts[distinct[a]]
+		})
+		if len(order) > maxWords {
+			order = order[:maxWords]
+			analysis.Partial = true
+		}
+		if !lr.lemmatize(ctx, canonical, distinct, lemmas, order) {
+			analysis.Partial = true
+		}
+	}
+
+	index := map[string]int{}
+	for i, form := range distinct {
+		lemma := lemmas[i]
+		j, seen := index[lemma]
+		if !seen {
+			j = len(analysis.Words)
+			index[lemma] = j
+			analysis.Words = append(analysis.Words, lr.analyzedWord(lemma, canonical))
+		}
+		word := &analysis.Words[j]
+		word.Count += counts[form]
+		if form != lemma {
+			word.Forms = append(word.Forms, form)
+		}
+	}
+	return analysis, nil
+}
+
+// analyzedWord is a lemma with its frequency rank and CEFR level.
+func (lr *LanguageRouter) analyzedWord(lemma, canonical string) AnalyzedWord {
+	word := AnalyzedWord{Word: lemma}
+	if lr.frequency != nil {
+		if f, ok := lr.frequency(lemma, canonical); ok {
+			word.Frequency = &f
+		}
+	}
+	if lr.level != nil {
+		if level, ok := lr.level(lemma, canonical); ok {
+			word.CEFR = &level
+		}
+	}
+	return word
+}
+
+// lemmatize writes the lemma of words[i] into lemmas[i] for each i in
+// order, until ctx is done. It reports whether every word was done.
+func (lr *LanguageRouter) lemmatize(ctx context.Context, canonical string, words, lemmas []string, order []int) bool {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(analyzeWorkers, len(order)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				lemmas[i] = lr.Lemma(ctx, words[i], canonical)
+			}
+		}()
+	}
+	complete := true
+	for _, i := range order {
+		select {
+		case jobs <- i:
+			continue
+		case <-ctx.Done():
+			complete = false
+		}
+		break
+	}
+	close(jobs)
+	wg.Wait()
+	return complete
+}
+
+// Lemma returns the dictionary form of a normalized word in a canonical
+// language, or the word itself when it has none or the lookup fails.
+// Lookups are cached.
+func (lr *LanguageRouter) Lemma(ctx context.Context, word, canonical string) string {
+	key := canonical + "\x00" + word
+	lr.lemmaMu.Lock()
+	lemma, ok := lr.lemmas[key]
+	lr.lemmaMu.Unlock()
+	if ok {
+		return lemma
+	}
+
+	lemma, err := resolveLemma(word, canonical)
+	if err != nil {
+		slog.DebugContext(ctx, "lemma resolution failed, keeping the word as written", "word", word, "language", canonical, "error", err)
+		return word
+	}
+	if strings.TrimSpace(lemma) == "" {
+		lemma = word
+	}
+
+	lr.lemmaMu.Lock()
+	if lr.lemmas == nil || len(lr.lemmas) >= maxCachedLemmas {
+		lr.lemmas = map[string]string{}
+	}
+	lr.lemmas[key] = lemma
+	lr.lemmaMu.Unlock()
+	return lemma
+}
+
+// BandShare is how much of a text falls in one frequency band.
+type BandShare struct {
+	Band int `json:"band"`
+	// UpToRank is the band's highest rank; 0 for the last band and for
+	// words off the frequency list (band 0).
+	UpToRank int `json:"up_to_rank"`
+	// Tokens counts running words, Words distinct (dictionary form) words.
+	Tokens int `json:"tokens"`
+	Words  int `json:"words"`
+	// Coverage is the share of the text's running words in this band, and
+	// CumulativeCoverage in this band or a more frequent one.
+	Coverage           float64 `json:"coverage"`
+	CumulativeCoverage float64 `json:"cumulative_coverage"`
+}
+
+// FrequencyProfile is how a text's running words spread over the frequency
+// bands: a text that is 95% top-3,000 words suits a learner who knows them.
+type FrequencyProfile struct {
+	Language string      `json:"language"`
+	Tokens   int         `json:"tokens"`
+	Words    int         `json:"words"`
+	Bands    []BandShare `json:"bands"`
+	// Unranked are the words off the frequency list: names, rare words,
+	// and inflected forms that weren't lemmatized.
+	Unranked BandShare `json:"unranked"`
+	Partial  bool      `json:"partial,omitempty"`
+}
+
+// Profile groups the analyzed words by frequency band.
+func (a Analysis) Profile() FrequencyProfile {
+	profile := FrequencyProfile{
+		Language: a.Language,
+		Tokens:   a.Tokens,
+		Words:    len(a.Words),
+		Bands:    make([]BandShare, frequency.Bands),
+		Partial:  a.Partial,
+	}
+	for i := range profile.Bands {
+		profile.Bands[i] = BandShare{Band: i + 1, UpToRank: frequency.BandLimit(i + 1)}
+	}
+	for _, word := range a.Words {
+		share := &profile.Unranked
+		if word.Frequency != nil {
+			share = &profile.Bands[word.Frequency.Band-1]
+		}
+		share.Tokens += word.Count
+		share.Words++
+	}
+	if a.Tokens == 0 {
+		return profile
+	}
+	cumulative := 0
+	for i := range profile.Bands {
+		band := &profile.Bands[i]
+		cumulative += band.Tokens
+		band.Coverage = ratio(band.Tokens, a.Tokens)
+		band.CumulativeCoverage = ratio(cumulative, a.Tokens)
+	}
+	profile.Unranked.Coverage = ratio(profile.Unranked.Tokens, a.Tokens)
+	profile.Unranked.CumulativeCoverage = 1
+	return profile
+}
+
+// ratio is n/total rounded to four decimals.
+func ratio(n, total int) float64 {
+	return math.Round(float64(n)/float64(total)*10000) / 10000
+}
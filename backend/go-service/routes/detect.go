@@ -0,0 +1,102 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/langcode"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+)
+
+// letterLanguages maps letters used by only some supported languages to
+// those languages. Letters shared by most of them (é) are left out.
+var letterLanguages = map[rune][]string{
+	'æ': {langcode.Bokmal, langcode.Nynorsk},
+	'ø': {langcode.Bokmal, langcode.Nynorsk},
+	'å': {langcode.Bokmal, langcode.Nynorsk},
+	'ä': {langcode.German},
+	'ö': {langcode.German},
+	'ü': {langcode.German, langcode.Spanish},
+	'ß': {langcode.German},
+	'ñ': {langcode.Spanish},
+	'á': {langcode.Spanish},
+	'í': {langcode.Spanish},
+	'ú': {langcode.Spanish},
+	'¿': {langcode.Spanish},
+	'¡': {langcode.Spanish},
+}
+
+// DetectLanguages orders the languages to try for a word given without a
+// language. A word with letters only some languages use can only be in
+// those, so it gets them, the candidates among them first; any other word
+// gets the candidates (such as the user's configured languages) in order.
+func DetectLanguages(word string, candidates []string) []string {
+	var hinted []string
+	for _, r := range strings.ToLower(word) {
+		languages, ok := letterLanguages[r]
+		if !ok {
+			continue
+		}
+		if hinted == nil {
+			hinted = slices.Clone(languages)
+			continue
+		}
+		hinted = slices.DeleteFunc(hinted, func(language string) bool {
+			return !slices.Contains(languages, language)
+		})
+	}
+	if hinted == nil {
+		return candidates
+	}
+
+	var ordered []string
+	for _, language := range candidates {
+		if canonical, ok := langcode.Normalize(language); ok && slices.Contains(hinted, canonical) && !slices.Contains(ordered, canonical) {
+			ordered = append(ordered, canonical)
+		}
+	}
+	for _, language := range hinted {
+		if !slices.Contains(ordered, language) {
+			ordered = append(ordered, language)
+		}
+	}
+	return ordered
+}
+
+// SetDetectLanguages sets the languages tried, in order, for words scraped
+// without a language when the request doesn't name its own. Call it before
+// scraping starts.
+func (lr *LanguageRouter) SetDetectLanguages(languages []string) {
+	lr.detect = languages
+}
+
+// ScrapeWordDetectingLanguage scrapes a word whose language wasn't given,
+// trying the languages from DetectLanguages until one has the word. It
+// tries candidates, or the router's detect languages when there are none.
+// The entry is marked LanguageDetected, with the chosen language in
+// Language; when no language has the word, the last attempt is returned.
+func (lr *LanguageRouter) ScrapeWordDetectingLanguage(ctx context.Context, word string, candidates []string) (models.WordEntry, error) {
+	if len(candidates) == 0 {
+		candidates = lr.detect
+	}
+	languages := DetectLanguages(word, candidates)
+	if len(languages) == 0 {
+		return models.WordEntry{}, fmt.Errorf("no languages to detect %q in", word)
+	}
+	var entry models.WordEntry
+	var err error
+	for _, language := range languages {
+		entry, err = lr.ScrapeWordByLanguage(ctx, word, language)
+		if err == nil && len(entry.Senses) > 0 {
+			slog.DebugContext(ctx, "detected language", "word", word, "language", entry.Language)
+			break
+		}
+	}
+	if err == nil {
+		entry.LanguageDetected = true
+	}
+	return entry, err
+}
@@ -1,54 +1,387 @@
 package routes
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"vocabulary-app/backend/go-service/models"
-	"vocabulary-app/backend/go-service/scrapers/bokmal_scraper"
-	"vocabulary-app/backend/go-service/scrapers/english_scraper"
-	"vocabulary-app/backend/go-service/scrapers/german_scraper"
-	"vocabulary-app/backend/go-service/scrapers/nynorsk_scraper"
-	"vocabulary-app/backend/go-service/scrapers/spanish_scraper"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/dictionary"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/langcode"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/sources"
+
+	"vocabulary-app/backend/go-service/cache"
 )
 
 // LanguageRouter routes scraping requests to the appropriate language scraper
-type LanguageRouter struct{}
+type LanguageRouter struct {
+	mu        sync.Mutex
+	inflight  map[string]*scrapeCall               // keyed by language and word, and the article URL of URL scrapes
+	entries   *cache.LRU[string, models.WordEntry] // keyed like inflight; nil when off
+	looked    time.Time                            // when a word was last looked up, guarded by mu
+	audio     AudioLookup
+	frequency FrequencyLookup
+	level     LevelLookup
+	detect    []string // languages tried for words given without one
+	offline   OfflineLookup
+	preferred map[string]bool // languages looked up offline before scraping
+	limits    models.Limits
+	lemmaMu   sync.Mutex
+	lemmas    map[string]string // lemma cache for Analyze, keyed by language and form
+}
+
+// AudioLookup finds recordings of a word in a canonical language.
+type AudioLookup func(ctx context.Context, word, language string) ([]models.AudioClip, error)
+
+// FrequencyLookup returns how common a word is in a canonical language, and
+// false when it isn't ranked.
+type FrequencyLookup func(word, language string) (models.Frequency, bool)
+
+// LevelLookup returns a word's CEFR level in a canonical language, and
+// false when it has none.
+type LevelLookup func(word, language string) (models.CEFRLevel, bool)
+
+// OfflineLookup finds a word in the offline dictionaries of a canonical
+// language, and false when none has it.
+type OfflineLookup func(word, language string) (models.WordEntry, bool)
+
+// scrapeCall is a scrape in progress that concurrent identical requests wait on.
+type scrapeCall struct {
+	done    chan struct{}
+	entry   models.WordEntry
+	err     error
+	waiters int
+}
 
 // NewLanguageRouter creates a new language router instance
 func NewLanguageRouter() *LanguageRouter {
-	return &LanguageRouter{}
-}
-
-// ScrapeWordByLanguage routes the word to the appropriate scraper based on language code
-func (lr *LanguageRouter) ScrapeWordByLanguage(word string, language string) (models.WordEntry, error) {
-	fmt.Printf("📌 Routing scrape request: word='%s', language='%s'\n", word, language)
-	
-	switch language {
-	case "no-bm", "nb", "no", "bokmal":
-		fmt.Println("→ Using Norwegian Bokmål scraper")
-		return bokmal_scraper.ScrapeWord(word)
-		
-	case "no-nn", "nn", "nynorsk":
-		fmt.Println("→ Using Norwegian Nynorsk scraper")
-		return nynorsk_scraper.ScrapeWord(word)
-		
-	case "en", "english":
-		fmt.Println("→ Using English scraper (stub)")
-		return english_scraper.ScrapeWord(word)
-		
-	case "es", "spanish":
-		fmt.Println("→ Using Spanish scraper (stub)")
-		return spanish_scraper.ScrapeWord(word)
-		
-	case "de", "german":
-		fmt.Println("→ Using German scraper (stub)")
-		return german_scraper.ScrapeWord(word)
-		
-	default:
+	return &LanguageRouter{inflight: make(map[string]*scrapeCall)}
+}
+
+// SetAudioLookup adds recordings from lookup to every scraped entry. A
+// failed lookup leaves a warning instead of failing the scrape. Call it
+// before scraping starts.
+func (lr *LanguageRouter) SetAudioLookup(lookup AudioLookup) {
+	lr.audio = lookup
+}
+
+// SetFrequencyLookup adds frequency ranks from lookup to every scraped entry.
+// Call it before scraping starts.
+func (lr *LanguageRouter) SetFrequencyLookup(lookup FrequencyLookup) {
+	lr.frequency = lookup
+}
+
+// SetLevelLookup adds CEFR levels from lookup to every scraped entry.
+// Call it before scraping starts.
+func (lr *LanguageRouter) SetLevelLookup(lookup LevelLookup) {
+	lr.level = lookup
+}
+
+// SetEntryCache keeps complete entries in entries and serves repeated
+// lookups of the same word from it. Entries with warnings are scraped
+// again next time instead. Call it before scraping starts.
+func (lr *LanguageRouter) SetEntryCache(entries *cache.LRU[string, models.WordEntry]) {
+	lr.entries = entries
+}
+
+// EntryCacheStats reports the entry cache's size and hit rate, and false
+// when there is no cache.
+func (lr *LanguageRouter) EntryCacheStats() (cache.Stats, bool) {
+	if lr.entries == nil {
+		return cache.Stats{}, false
+	}
+	return lr.entries.Stats(), true
+}
+
+// PurgeEntryCache drops the cached entries for which match returns true,
+// given their canonical language and normalized word, so they are scraped
+// again. Entries scraped from an article URL go with their word. It returns
+// how many it dropped, and false when there is no cache.
+func (lr *LanguageRouter) PurgeEntryCache(match func(language, word string) bool) (int, bool) {
+	if lr.entries == nil {
+		return 0, false
+	}
+	return lr.entries.RemoveFunc(func(key string) bool {
+		language, rest, _ := strings.Cut(key, "\x00")
+		word, _, _ := strings.Cut(rest, "\x00")
+		return match(language, word)
+	}), true
+}
+
+// SetOfflineLookup serves words from lookup when their scrape fails or finds
+// nothing, and for the preferred languages before scraping at all. Call it
+// before scraping starts.
+func (lr *LanguageRouter) SetOfflineLookup(lookup OfflineLookup, preferred []string) {
+	lr.offline = lookup
+	lr.preferred = map[string]bool{}
+	for _, language := range preferred {
+		lr.preferred[language] = true
+	}
+}
+
+// SetLimits cuts every entry down to limits, so a pathological one doesn't
+// blow up responses, deliveries or the cache. Call it before scraping
+// starts.
+func (lr *LanguageRouter) SetLimits(limits models.Limits) {
+	lr.limits = limits
+}
+
+// ScrapeWordByLanguage routes the word to the appropriate scraper based on
+// language code. Concurrent requests for the same word and language share a
+// single scrape. ctx only carries the request ID for logging.
+func (lr *LanguageRouter) ScrapeWordByLanguage(ctx context.Context, word string, language string) (models.WordEntry, error) {
+	canonical, ok := langcode.Normalize(language)
+	if !ok {
 		return models.WordEntry{}, fmt.Errorf("unsupported language: %s", language)
 	}
+	if err := models.CheckWord(word); err != nil {
+		return models.WordEntry{}, err
+	}
+	if err := sources.Default.CheckEnabled(canonical); err != nil {
+		return models.WordEntry{}, err
+	}
+	word = models.NormalizeWord(canonical, word)
+
+	key := canonical + "\x00" + word
+	lr.mu.Lock()
+	lr.looked = time.Now()
+	lr.mu.Unlock()
+	if lr.entries != nil {
+		if entry, ok := lr.entries.Get(key); ok {
+			slog.DebugContext(ctx, "serving cached entry", "word", word, "language", canonical)
+			return entry, nil
+		}
+	}
+	return lr.scrapeShared(ctx, key, word, canonical, func() (models.WordEntry, error) {
+		return lr.scrape(ctx, word, canonical)
+	})
 }
 
-// GetSupportedLanguages returns a list of supported language codes
+// Warm scrapes word into the entry cache unless it is already there, and
+// reports whether it was. Unlike ScrapeWordByLanguage, it doesn't count as
+// a lookup for Idle or in the cache's hit rate.
+func (lr *LanguageRouter) Warm(ctx context.Context, word, language string) (cached bool, err error) {
+	canonical, ok := langcode.Normalize(language)
+	if !ok {
+		return false, fmt.Errorf("unsupported language: %s", language)
+	}
+	if lr.entries == nil {
+		return false, fmt.Errorf("the entry cache is off")
+	}
+	if err := sources.Default.CheckEnabled(canonical); err != nil {
+		return false, err
+	}
+	word = models.NormalizeWord(canonical, word)
+
+	key := canonical + "\x00" + word
+	if lr.entries.Contains(key) {
+		return true, nil
+	}
+	_, err = lr.scrapeShared(ctx, key, word, canonical, func() (models.WordEntry, error) {
+		return lr.scrape(ctx, word, canonical)
+	})
+	return false, err
+}
+
+// Idle reports whether no word has been looked up for quiet and no scrape
+// is in progress.
+func (lr *LanguageRouter) Idle(quiet time.Duration) bool {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+	return len(lr.inflight) == 0 && time.Since(lr.looked) >= quiet
+}
+
+// scrapeShared scrapes a normalized word with run, sharing the scrape with
+// concurrent requests for the same key, and caches complete entries.
+func (lr *LanguageRouter) scrapeShared(ctx context.Context, key, word, canonical string, run func() (models.WordEntry, error)) (models.WordEntry, error) {
+	lr.mu.Lock()
+	if call, ok := lr.inflight[key]; ok {
+		call.waiters++
+		lr.mu.Unlock()
+		slog.DebugContext(ctx, "joining scrape already in progress", "word", word, "language", canonical)
+		<-call.done
+		return call.entry, call.err
+	}
+	call := &scrapeCall{done: make(chan struct{})}
+	lr.inflight[key] = call
+	lr.mu.Unlock()
+
+	// Release waiters even if the scraper panics
+	call.err = fmt.Errorf("scrape of %q aborted", word)
+	defer func() {
+		lr.mu.Lock()
+		delete(lr.inflight, key)
+		waiters := call.waiters
+		lr.mu.Unlock()
+		close(call.done)
+		if waiters > 0 {
+			slog.InfoContext(ctx, "scrape shared with concurrent requests", "word", word, "language", canonical, "waiters", waiters)
+		}
+	}()
+	call.entry, call.err = run()
+	if lr.entries != nil && call.err == nil && len(call.entry.Senses) > 0 && call.entry.CheckComplete() == nil {
+		lr.entries.Add(key, call.entry)
+	}
+	return call.entry, call.err
+}
+
+// scrape runs the scraper for a canonical language.
+func (lr *LanguageRouter) scrape(ctx context.Context, word, canonical string) (models.WordEntry, error) {
+	start := time.Now()
+	query := word
+	lemma, lemmaErr := resolveLemma(word, canonical)
+	if lemmaErr != nil {
+		slog.WarnContext(ctx, "lemma resolution failed, scraping the word as given", "word", word, "language", canonical, "error", lemmaErr)
+	} else if lemma != word {
+		slog.DebugContext(ctx, "resolved inflected form to lemma", "word", word, "lemma", lemma, "language", canonical)
+		word = lemma
+	}
+
+	var entry models.WordEntry
+	var err error
+	if offline, ok := lr.lookupOffline(word, canonical, true); ok {
+		// The language's source is unreliable; the offline dictionary answers first
+		entry = offline
+	} else {
+		entry, err = dictionary.Scrape(word, canonical)
+	}
+	logger := slog.With("word", word, "language", canonical, "duration", time.Since(start).Round(time.Millisecond))
+	if err == nil && len(entry.Senses) == 0 {
+		err = &models.NotFoundError{Word: word, Language: canonical}
+	}
+	if err != nil {
+		if offline, ok := lr.lookupOffline(word, canonical, false); ok {
+			logger.WarnContext(ctx, "scrape failed, using offline dictionary", "error", err, "dictionary", offline.Source)
+			offline.Warn(models.WarningOfflineFallback, "", err)
+			entry, err = offline, nil
+		}
+	}
+	if errors.Is(err, models.ErrNotFound) {
+		// The source answered; not having the word isn't a failed scrape
+		logger.InfoContext(ctx, "word not found in the source")
+		sources.Default.Record(canonical, sources.StageWord, start, nil)
+		return entry, err
+	}
+	if err != nil {
+		logger.WarnContext(ctx, "scrape failed", "error", err)
+		sources.Default.Record(canonical, sources.StageWord, start, err)
+		return entry, err
+	}
+	logger.InfoContext(ctx, "scrape finished", "senses", len(entry.Senses))
+	if word != query {
+		entry.Query = query
+	}
+	if lemmaErr != nil {
+		entry.Warn(models.WarningLemmaFailed, "", lemmaErr)
+	}
+	if err := lr.finish(ctx, &entry, canonical, logger); err != nil {
+		sources.Default.Record(canonical, sources.StageWord, start, err)
+		return entry, err
+	}
+	sources.Default.Record(canonical, sources.StageWord, start, nil)
+	return entry, nil
+}
+
+// lookupOffline looks word up in the offline dictionaries: before
+// scraping for preferred languages, or after a failed scrape for the
+// others.
+func (lr *LanguageRouter) lookupOffline(word, canonical string, beforeScrape bool) (models.WordEntry, bool) {
+	if lr.offline == nil || lr.preferred[canonical] != beforeScrape {
+		return models.WordEntry{}, false
+	}
+	return lr.offline(word, canonical)
+}
+
+// finish adds what every scraped entry in a canonical language gets:
+// normalized text, the language, normalized parts of speech, the limits,
+// source metadata, the idempotency key, frequency, CEFR level and
+// recordings. It
+// returns the error of an entry that fails validation, which must not be
+// returned or delivered.
+func (lr *LanguageRouter) finish(ctx context.Context, entry *models.WordEntry, canonical string, logger *slog.Logger) error {
+	// Offline dictionary entries don't go through dictionary.Scrape
+	entry.NormalizeText()
+	entry.Language = canonical
+	for i := range entry.Senses {
+		// Offline dictionaries set their own, from tables of their abbreviations
+		if entry.Senses[i].PartOfSpeech == "" {
+			entry.Senses[i].PartOfSpeech = models.NormalizePartOfSpeech(canonical, entry.Senses[i].Category)
+		}
+	}
+	if err := entry.Validate(); err != nil {
+		logger.WarnContext(ctx, "scraped entry failed validation", "error", err)
+		return err
+	}
+	for _, w := range entry.Warnings {
+		if w.Code == models.WarningInvalidContent {
+			logger.WarnContext(ctx, "dropped malformed parts of scraped entry", "sense", w.SenseID, "problems", w.Message)
+		}
+	}
+	entry.ApplyLimits(lr.limits)
+	entry.ScrapedAt = time.Now().UTC()
+	var sourceVersion string
+	if entry.Source != "" {
+		// Offline dictionary entries name their dictionary; their keys are
+		// kept apart from scraped ones so a later scrape isn't dropped
+		sourceVersion = "offline:" + entry.Source
+	} else if profile, ok := sources.Default.ForLanguage(canonical); ok {
+		sourceVersion = profile.Version
+		entry.Source = profile.Name
+		entry.License = profile.License
+	}
+	entry.IdempotencyKey = models.IdempotencyKey(entry.Word, canonical, sourceVersion)
+	if lr.frequency != nil {
+		if f, ok := lr.frequency(entry.Word, canonical); ok {
+			entry.Frequency = &f
+		}
+	}
+	if lr.level != nil {
+		if level, ok := lr.level(entry.Word, canonical); ok {
+			entry.CEFR = &level
+		}
+	}
+	if lr.audio != nil {
+		clips, err := lr.audio(context.WithoutCancel(ctx), entry.Word, canonical)
+		if err != nil {
+			logger.WarnContext(ctx, "audio lookup failed", "error", err)
+			entry.Warn(models.WarningAudioFailed, "", err)
+		}
+		entry.Audio = clips
+	}
+	return nil
+}
+
+// Suggest returns up to n words starting with prefix in a language, from
+// the source's autocomplete. Languages without one return no words.
+func (lr *LanguageRouter) Suggest(ctx context.Context, prefix, language string, n int) ([]string, error) {
+	canonical, ok := langcode.Normalize(language)
+	if !ok {
+		return nil, fmt.Errorf("unsupported language: %s", language)
+	}
+	if err := sources.Default.CheckEnabled(canonical); err != nil {
+		return nil, err
+	}
+	return dictionary.Suggest(prefix, canonical, n)
+}
+
+// resolveLemma maps inflected input to its lemma for languages whose source
+// can look forms up; others get the word back unchanged.
+func resolveLemma(word, canonical string) (string, error) {
+	return dictionary.ResolveLemma(word, canonical)
+}
+
+// GetSupportedLanguages returns a list of supported language codes,
+// leaving out languages that are switched off
 func (lr *LanguageRouter) GetSupportedLanguages() []string {
-	return []string{"no-bm", "no-nn", "en", "es", "de"}
+	enabled := []string{}
+	for _, language := range langcode.Supported {
+		if sources.Default.CheckEnabled(language) == nil {
+			enabled = append(enabled, language)
+		}
+	}
+	return enabled
 }
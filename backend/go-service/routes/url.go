@@ -0,0 +1,131 @@
+package routes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/langcode"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/models"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/scrapers/bokmal_scraper"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/scrapers/nynorsk_scraper"
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/sources"
+)
+
+// ErrUnsupportedURL is returned for URLs that aren't a dictionary article
+// page of a source with a URL parser.
+var ErrUnsupportedURL = errors.New("unsupported dictionary URL")
+
+// defaultPorts are the ports article URLs may give explicitly.
+var defaultPorts = map[string]string{"http": "80", "https": "443"}
+
+// ScrapeURL scrapes the dictionary article at rawURL, for when looking the
+// word up finds the wrong article (one of several homographs, say). The
+// source is picked by the URL's host and the language and word are read
+// from its path. Like ScrapeWordByLanguage, concurrent requests for the
+// same article share a scrape and complete entries are cached, and the
+// entry is finished like any other scrape.
+func (lr *LanguageRouter) ScrapeURL(ctx context.Context, rawURL string) (models.WordEntry, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || defaultPorts[u.Scheme] == "" {
+		return models.WordEntry{}, fmt.Errorf("%w: %s", ErrUnsupportedURL, rawURL)
+	}
+	if port := u.Port(); port != "" && port != defaultPorts[u.Scheme] {
+		return models.WordEntry{}, fmt.Errorf("%w: %s: only the default port is allowed", ErrUnsupportedURL, rawURL)
+	}
+	profile, ok := sources.Default.ForHost(u.Hostname())
+	if !ok {
+		return models.WordEntry{}, fmt.Errorf("%w: no source for host %s", ErrUnsupportedURL, u.Hostname())
+	}
+
+	var canonical, word string
+	switch profile.Name {
+	case "ordbokene":
+		canonical, word, ok = ordbokeneArticle(u)
+	}
+	if !ok {
+		return models.WordEntry{}, fmt.Errorf("%w: %s", ErrUnsupportedURL, rawURL)
+	}
+	if err := models.CheckWord(word); err != nil {
+		return models.WordEntry{}, fmt.Errorf("%w: %s: %v", ErrUnsupportedURL, rawURL, err)
+	}
+	if err := sources.Default.CheckEnabled(canonical); err != nil {
+		return models.WordEntry{}, err
+	}
+
+	// The article is named by its page alone, so the same page spelled
+	// differently shares a scrape and a cache entry
+	article := (&url.URL{Scheme: u.Scheme, Host: strings.ToLower(u.Hostname()), Path: u.Path}).String()
+	key := canonical + "\x00" + word + "\x00" + article
+	lr.mu.Lock()
+	lr.looked = time.Now()
+	lr.mu.Unlock()
+	if lr.entries != nil {
+		if entry, ok := lr.entries.Get(key); ok {
+			slog.DebugContext(ctx, "serving cached entry", "word", word, "language", canonical, "url", article)
+			return entry, nil
+		}
+	}
+	return lr.scrapeShared(ctx, key, word, canonical, func() (models.WordEntry, error) {
+		return lr.scrapeArticle(ctx, word, canonical, article)
+	})
+}
+
+// scrapeArticle runs the URL scraper for a canonical language, recording
+// the scrape in the source's stage metrics as scrape does.
+func (lr *LanguageRouter) scrapeArticle(ctx context.Context, word, canonical, article string) (models.WordEntry, error) {
+	start := time.Now()
+	var entry models.WordEntry
+	var err error
+	switch canonical {
+	case langcode.Bokmal:
+		entry, err = bokmal_scraper.ScrapeURL(word, article)
+	case langcode.Nynorsk:
+		entry, err = nynorsk_scraper.ScrapeURL(word, article)
+	}
+	logger := slog.With("word", word, "language", canonical, "url", article, "duration", time.Since(start).Round(time.Millisecond))
+	if err == nil && len(entry.Senses) == 0 {
+		err = &models.NotFoundError{Word: word, Language: canonical}
+	}
+	if errors.Is(err, models.ErrNotFound) {
+		logger.InfoContext(ctx, "word not found in the source")
+		sources.Default.Record(canonical, sources.StageWord, start, nil)
+		return entry, err
+	}
+	if err != nil {
+		logger.WarnContext(ctx, "scrape failed", "error", err)
+		sources.Default.Record(canonical, sources.StageWord, start, err)
+		return entry, err
+	}
+	logger.InfoContext(ctx, "scrape finished", "senses", len(entry.Senses))
+	if err := lr.finish(ctx, &entry, canonical, logger); err != nil {
+		sources.Default.Record(canonical, sources.StageWord, start, err)
+		return entry, err
+	}
+	sources.Default.Record(canonical, sources.StageWord, start, nil)
+	return entry, nil
+}
+
+// ordbokeneArticle reads the dictionary and word from an ordbokene.no page
+// path: the dictionary is the "bm" or "nn" segment and the word the last
+// segment, as in /nob/bm/hund or /bm/12345/hund.
+func ordbokeneArticle(u *url.URL) (canonical, word string, ok bool) {
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for _, segment := range segments {
+		switch segment {
+		case "bm":
+			canonical = langcode.Bokmal
+		case "nn":
+			canonical = langcode.Nynorsk
+		}
+	}
+	last := segments[len(segments)-1]
+	if canonical == "" || last == "bm" || last == "nn" || strings.Trim(last, "0123456789") == "" {
+		return "", "", false
+	}
+	return canonical, models.NormalizeWord(canonical, last), true
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestScrapeURLRequiresDefaultPort(t *testing.T) {
+	lr := NewLanguageRouter()
+	for _, rawURL := range []string{
+		"https://ordbokene.no:8443/nob/bm/hund",
+		"http://ordbokene.no:443/nob/bm/hund",
+		"ftp://ordbokene.no/nob/bm/hund",
+	} {
+		if _, err := lr.ScrapeURL(context.Background(), rawURL); !errors.Is(err, ErrUnsupportedURL) {
+			t.Errorf("ScrapeURL(%q) error = %v, want ErrUnsupportedURL", rawURL, err)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Package warmer scrapes the most frequent words of each language into the
+// entry cache while the service is idle, so common lookups are answered
+// from memory instead of waiting on a scrape.
+package warmer
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/klaraeelise/vocabulary-app/backend/go-service/pkg/sources"
+)
+
+// maxFailures is how many scrapes in a row may fail before a language is
+// left for the next round; the source is likely down or blocking us.
+const maxFailures = 5
+
+// WarmFunc scrapes a word of a canonical language into the cache unless it
+// is already there, and reports whether it was.
+type WarmFunc func(ctx context.Context, word, language string) (cached bool, err error)
+
+// Config is how a Warmer finds its words and how politely it scrapes them.
+type Config struct {
+	// Warm scrapes a word into the cache.
+	Warm WarmFunc
+	// Languages returns the canonical languages to warm, checked each round
+	// so languages switched off in the meantime are left out.
+	Languages func() []string
+	// Top returns the n most frequent words of a language.
+	Top func(language string, n int) []string
+	// Idle reports whether users have been quiet long enough to scrape.
+	Idle func() bool
+	// Words is how many of each language's most frequent words are warmed.
+	Words int
+	// Interval is the time between rounds.
+	Interval time.Duration
+	// Delay is the least time between two scrapes, on top of the source's
+	// own rate limit.
+	Delay time.Duration
+}
+
+// Round is the outcome of a round of warming.
+type Round struct {
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"` // nil while the round runs
+	Scraped    int        `json:"scraped"`
+	Cached     int        `json:"cached"` // already in the cache, not scraped
+	Failed     int        `json:"failed"`
+}
+
+// Warmer runs the rounds of warming.
+type Warmer struct {
+	cfg Config
+
+	mu    sync.Mutex
+	round Round
+}
+
+// New creates a warmer.
+func New(cfg Config) *Warmer {
+	return &Warmer{cfg: cfg}
+}
+
+// Run warms the cache right away and then every interval until ctx is
+// cancelled.
+func (w *Warmer) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		w.WarmAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// WarmAll runs one round for every language, waiting for idle time before
+// each scrape.
+func (w *Warmer) WarmAll(ctx context.Context) {
+	w.mu.Lock()
+	w.round = Round{StartedAt: time.Now()}
+	w.mu.Unlock()
+
+	for _, language := range w.cfg.Languages() {
+		if ctx.Err() != nil {
+			return
+		}
+		w.warm(ctx, language)
+	}
+
+	w.mu.Lock()
+	finished := time.Now()
+	w.round.FinishedAt = &finished
+	round := w.round
+	w.mu.Unlock()
+	slog.Info("cache warming round finished", "scraped", round.Scraped, "cached", round.Cached, "failed", round.Failed, "duration", finished.Sub(round.StartedAt).Round(time.Second))
+}
+
+func (w *Warmer) warm(ctx context.Context, language string) {
+	failures := 0
+	for _, word := range w.cfg.Top(language, w.cfg.Words) {
+		if !w.wait(ctx) {
+			return
+		}
+		// A source that is rate limiting us gets no extra requests
+		if until := sources.Default.BackoffUntil(language); !until.IsZero() {
+			slog.Info("source is backing off, leaving language for the next warming round", "language", language, "until", until)
+			return
+		}
+		cached, err := w.cfg.Warm(ctx, word, language)
+		var disabled *sources.DisabledError
+		switch {
+		case errors.As(err, &disabled):
+			slog.Info("language switched off, leaving it for the next warming round", "language", language)
+			return
+		case err != nil:
+			w.count(func(r *Round) { r.Failed++ })
+			slog.Debug("warming scrape failed", "word", word, "language", language, "error", err)
+			if failures++; failures >= maxFailures {
+				slog.Warn("warming scrapes keep failing, leaving language for the next round", "language", language, "failures", failures)
+				return
+			}
+			continue
+		case cached:
+			w.count(func(r *Round) { r.Cached++ })
+			continue
+		}
+		failures = 0
+		w.count(func(r *Round) { r.Scraped++ })
+		// Only an actual scrape has to be spaced out from the next
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.cfg.Delay):
+		}
+	}
+}
+
+// wait blocks until users are idle, and returns false if ctx is cancelled
+// first.
+func (w *Warmer) wait(ctx context.Context) bool {
+	for !w.cfg.Idle() {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(max(w.cfg.Delay, time.Second)):
+		}
+	}
+	return ctx.Err() == nil
+}
+
+func (w *Warmer) count(update func(*Round)) {
+	w.mu.Lock()
+	update(&w.round)
+	w.mu.Unlock()
+}
+
+// Status returns the current or latest round.
+func (w *Warmer) Status() Round {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.round
+}
@@ -0,0 +1,87 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateAddress is returned for webhook URLs that point into the
+// service's own network: loopback, private, link-local (which holds the
+// cloud metadata endpoints), shared carrier-grade NAT and other addresses
+// that aren't globally routable.
+var ErrPrivateAddress = errors.New("webhook URL points to a private or local address")
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), which
+// netip doesn't count as private.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// blocked reports whether ip is an address webhooks may not be sent to.
+func blocked(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return !ip.IsGlobalUnicast() || ip.IsPrivate() || ip.IsLoopback() ||
+		ip.IsLinkLocalUnicast() || sharedAddressSpace.Contains(ip)
+}
+
+// CheckURL parses a webhook URL and checks that it is an absolute http(s)
+// URL whose host resolves only to public addresses. Unless allowPrivate is
+// set, a URL that reaches into the private network is rejected with
+// ErrPrivateAddress. The check is repeated when a notification is sent, as
+// DNS may have changed since.
+func CheckURL(ctx context.Context, raw string, allowPrivate bool) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return nil, fmt.Errorf("webhook URL must be an absolute http(s) URL")
+	}
+	if allowPrivate {
+		return u, nil
+	}
+	if ip, err := netip.ParseAddr(u.Hostname()); err == nil {
+		if blocked(ip) {
+			return nil, ErrPrivateAddress
+		}
+		return u, nil
+	}
+	ips, err := net.DefaultResolver.LookupNetIP(ctx, "ip", u.Hostname())
+	if err != nil {
+		return nil, fmt.Errorf("resolving webhook host: %w", err)
+	}
+	for _, ip := range ips {
+		if blocked(ip) {
+			return nil, ErrPrivateAddress
+		}
+	}
+	return u, nil
+}
+
+// newClient returns the client notifications are sent with. Unless
+// allowPrivate is set, it refuses to connect to blocked addresses at dial
+// time, after DNS resolution, so neither a redirect nor a host whose DNS
+// changes after registration can reach the private network. Proxies from
+// the environment are ignored, as the check needs to see the webhook's own
+// address.
+func newClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if !allowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if blocked(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s", ErrPrivateAddress, addrPort.Addr())
+			}
+			return nil
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
@@ -0,0 +1,159 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"vocabulary-app/backend/go-service/config"
+	"vocabulary-app/backend/go-service/deadletter"
+	"vocabulary-app/backend/go-service/jobs"
+)
+
+// Event names sent in the payload and the X-Webhook-Event header.
+const (
+	EventJobCompleted = "job.completed"
+	EventJobFailed    = "job.failed"
+)
+
+// SignatureHeader carries "sha256=<hex HMAC of the body>" keyed by the webhook secret.
+const SignatureHeader = "X-Webhook-Signature"
+
+// Payload is the JSON body POSTed to webhooks.
+type Payload struct {
+	Event      string    `json:"event"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Job        *jobs.Job `json:"job"`
+}
+
+// Dispatcher notifies webhooks about finished jobs.
+type Dispatcher struct {
+	store       *Store
+	deadLetters *deadletter.Store
+	client      *http.Client
+	attempts    int
+}
+
+// NewDispatcher creates a dispatcher for the webhooks in store. Deliveries
+// that fail every attempt move to deadLetters.
+func NewDispatcher(store *Store, deadLetters *deadletter.Store, cfg config.WebhooksConfig) *Dispatcher {
+	return &Dispatcher{
+		store:       store,
+		deadLetters: deadLetters,
+		client:      newClient(cfg.Timeout, cfg.AllowPrivateNetworks),
+		attempts:    max(cfg.Attempts, 1),
+	}
+}
+
+// failedSend is the payload of a webhook dead letter.
+type failedSend struct {
+	WebhookID string          `json:"webhook_id"`
+	Event     string          `json:"event"`
+	Body      json.RawMessage `json:"body"`
+}
+
+// JobFinished notifies the job owner's webhooks and all global webhooks in
+// the background. It is meant to be registered with Scheduler.OnFinish.
+func (d *Dispatcher) JobFinished(job *jobs.Job) {
+	hooks, err := d.store.Subscribers(job.OwnerID)
+	if err != nil {
+		slog.Error("loading webhooks failed", "job_id", job.ID, "error", err)
+		return
+	}
+	if len(hooks) == 0 {
+		return
+	}
+
+	event := EventJobCompleted
+	if job.Status == jobs.StatusFailed {
+		event = EventJobFailed
+	}
+	body, err := json.Marshal(Payload{Event: event, OccurredAt: time.Now().UTC(), Job: job})
+	if err != nil {
+		slog.Error("encoding webhook payload failed", "job_id", job.ID, "error", err)
+		return
+	}
+
+	for _, hook := range hooks {
+		go d.send(hook, event, job.ID, body)
+	}
+}
+
+func (d *Dispatcher) send(hook Webhook, event, jobID string, body []byte) {
+	signature := sign(hook.Secret, body)
+	firstTry := time.Now()
+	backoff := 2 * time.Second
+	var err error
+	for attempt := 1; attempt <= d.attempts; attempt++ {
+		if err = d.post(context.Background(), hook.URL, event, signature, body); err == nil {
+			return
+		}
+		if attempt < d.attempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	slog.Warn("webhook delivery failed", "webhook_id", hook.ID, "url", hook.URL, "attempts", d.attempts, "error", err)
+
+	payload, _ := json.Marshal(failedSend{WebhookID: hook.ID, Event: event, Body: body})
+	_, err = d.deadLetters.Add(deadletter.Letter{
+		Kind:     deadletter.KindWebhook,
+		Payload:  payload,
+		Context:  map[string]string{"webhook_id": hook.ID, "url": hook.URL, "event": event, "job_id": jobID},
+		Error:    err.Error(),
+		Attempts: d.attempts,
+		FirstTry: firstTry,
+	})
+	if err != nil {
+		slog.Error("saving failed webhook delivery to the dead letters failed", "webhook_id", hook.ID, "error", err)
+	}
+}
+
+// Redrive sends the notification of a webhook dead letter again, once, if
+// the webhook still exists. It is registered with the dead letters for
+// KindWebhook.
+func (d *Dispatcher) Redrive(ctx context.Context, letter deadletter.Letter) error {
+	var failed failedSend
+	if err := json.Unmarshal(letter.Payload, &failed); err != nil {
+		return err
+	}
+	hook, err := d.store.get(failed.WebhookID)
+	if err != nil {
+		return err
+	}
+	return d.post(ctx, hook.URL, failed.Event, sign(hook.Secret, failed.Body), failed.Body)
+}
+
+// sign returns the SignatureHeader value for body.
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (d *Dispatcher) post(ctx context.Context, url, event, signature string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", event)
+	req.Header.Set(SignatureHeader, signature)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
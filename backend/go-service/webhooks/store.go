@@ -0,0 +1,153 @@
+// Package webhooks lets users register URLs that are notified when their
+// scrape jobs finish. Admins can register global webhooks that are notified
+// about every job.
+package webhooks
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var webhooksBucket = []byte("webhooks")
+
+// ErrNotFound is returned for unknown webhooks or webhooks owned by someone else.
+var ErrNotFound = errors.New("webhook not found")
+
+// Webhook is a registered notification target.
+type Webhook struct {
+	ID      string `json:"id"`
+	OwnerID int    `json:"owner_id"`
+	URL     string `json:"url"`
+	// Global webhooks receive every job, not just the owner's.
+	Global bool `json:"global"`
+	// Secret signs deliveries; it is only shown when the webhook is created.
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Store keeps webhooks in the service's bolt database.
+type Store struct {
+	db *bolt.DB
+}
+
+// NewStore keeps webhooks in their own bucket of db.
+func NewStore(db *bolt.DB) (*Store, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(webhooksBucket)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("initialising webhooks: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Create registers a webhook and returns it, including its signing secret.
+func (s *Store) Create(ownerID int, url string, global bool) (Webhook, error) {
+	hook := Webhook{
+		ID:        randomHex(8),
+		OwnerID:   ownerID,
+		URL:       url,
+		Global:    global,
+		Secret:    randomHex(32),
+		CreatedAt: time.Now(),
+	}
+	data, err := json.Marshal(hook)
+	if err != nil {
+		return Webhook{}, err
+	}
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(webhooksBucket).Put([]byte(hook.ID), data)
+	})
+	return hook, err
+}
+
+// List returns the webhooks owned by ownerID, without secrets.
+func (s *Store) List(ownerID int) ([]Webhook, error) {
+	hooks, err := s.all()
+	if err != nil {
+		return nil, err
+	}
+	owned := []Webhook{}
+	for _, hook := range hooks {
+		if hook.OwnerID == ownerID {
+			hook.Secret = ""
+			owned = append(owned, hook)
+		}
+	}
+	return owned, nil
+}
+
+// Delete removes a webhook. Unless admin is set, only the owner may delete it.
+func (s *Store) Delete(id string, ownerID int, admin bool) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(webhooksBucket)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return ErrNotFound
+		}
+		var hook Webhook
+		if err := json.Unmarshal(data, &hook); err != nil {
+			return err
+		}
+		if hook.OwnerID != ownerID && !admin {
+			return ErrNotFound
+		}
+		return b.Delete([]byte(id))
+	})
+}
+
+// Subscribers returns the webhooks to notify about a job owned by ownerID:
+// the owner's own webhooks plus all global ones.
+func (s *Store) Subscribers(ownerID int) ([]Webhook, error) {
+	hooks, err := s.all()
+	if err != nil {
+		return nil, err
+	}
+	var subscribers []Webhook
+	for _, hook := range hooks {
+		if hook.Global || (ownerID != 0 && hook.OwnerID == ownerID) {
+			subscribers = append(subscribers, hook)
+		}
+	}
+	return subscribers, nil
+}
+
+func (s *Store) get(id string) (Webhook, error) {
+	var hook Webhook
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(webhooksBucket).Get([]byte(id))
+		if data == nil {
+			return ErrNotFound
+		}
+		return json.Unmarshal(data, &hook)
+	})
+	return hook, err
+}
+
+func (s *Store) all() ([]Webhook, error) {
+	var hooks []Webhook
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(webhooksBucket).ForEach(func(k, v []byte) error {
+			var hook Webhook
+			if err := json.Unmarshal(v, &hook); err != nil {
+				return err
+			}
+			hooks = append(hooks, hook)
+			return nil
+		})
+	})
+	return hooks, err
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
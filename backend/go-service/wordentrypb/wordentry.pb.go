@@ -0,0 +1,1105 @@
+// WordEntry and its parts are the dictionary entry the Go service scrapes,
+// shared by both services so field names are defined once. The JSON the Go
+// service serves uses the same field names. Generated code lives in
+// backend/go-service/wordentrypb and backend/python-service/wordentry_pb2.py;
+// regenerate with backend/proto/generate.sh after editing this file.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: wordentry.proto
+
+package wordentrypb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Meaning struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Description   string                 `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	Examples      []string               `protobuf:"bytes,2,rep,name=examples,proto3" json:"examples,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Meaning) Reset() {
+	*x = Meaning{}
+	mi := &file_wordentry_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Meaning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Meaning) ProtoMessage() {}
+
+func (x *Meaning) ProtoReflect() protoreflect.Message {
+	mi := &file_wordentry_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Meaning.ProtoReflect.Descriptor instead.
+func (*Meaning) Descriptor() ([]byte, []int) {
+	return file_wordentry_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Meaning) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Meaning) GetExamples() []string {
+	if x != nil {
+		return x.Examples
+	}
+	return nil
+}
+
+type Expression struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Phrase        string                 `protobuf:"bytes,1,opt,name=phrase,proto3" json:"phrase,omitempty"`
+	Explanation   string                 `protobuf:"bytes,2,opt,name=explanation,proto3" json:"explanation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Expression) Reset() {
+	*x = Expression{}
+	mi := &file_wordentry_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Expression) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Expression) ProtoMessage() {}
+
+func (x *Expression) ProtoReflect() protoreflect.Message {
+	mi := &file_wordentry_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Expression.ProtoReflect.Descriptor instead.
+func (*Expression) Descriptor() ([]byte, []int) {
+	return file_wordentry_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Expression) GetPhrase() string {
+	if x != nil {
+		return x.Phrase
+	}
+	return ""
+}
+
+func (x *Expression) GetExplanation() string {
+	if x != nil {
+		return x.Explanation
+	}
+	return ""
+}
+
+type WordForm struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Label         string                 `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	Forms         []string               `protobuf:"bytes,2,rep,name=forms,proto3" json:"forms,omitempty"`
+	Number        string                 `protobuf:"bytes,3,opt,name=number,proto3" json:"number,omitempty"`
+	Definiteness  string                 `protobuf:"bytes,4,opt,name=definiteness,proto3" json:"definiteness,omitempty"`
+	Gender        string                 `protobuf:"bytes,5,opt,name=gender,proto3" json:"gender,omitempty"`
+	Degree        string                 `protobuf:"bytes,6,opt,name=degree,proto3" json:"degree,omitempty"`
+	Tense         string                 `protobuf:"bytes,7,opt,name=tense,proto3" json:"tense,omitempty"`
+	Person        string                 `protobuf:"bytes,8,opt,name=person,proto3" json:"person,omitempty"`
+	Mood          string                 `protobuf:"bytes,9,opt,name=mood,proto3" json:"mood,omitempty"`
+	Voice         string                 `protobuf:"bytes,10,opt,name=voice,proto3" json:"voice,omitempty"`
+	Case          string                 `protobuf:"bytes,11,opt,name=case,proto3" json:"case,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WordForm) Reset() {
+	*x = WordForm{}
+	mi := &file_wordentry_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WordForm) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WordForm) ProtoMessage() {}
+
+func (x *WordForm) ProtoReflect() protoreflect.Message {
+	mi := &file_wordentry_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WordForm.ProtoReflect.Descriptor instead.
+func (*WordForm) Descriptor() ([]byte, []int) {
+	return file_wordentry_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WordForm) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *WordForm) GetForms() []string {
+	if x != nil {
+		return x.Forms
+	}
+	return nil
+}
+
+func (x *WordForm) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *WordForm) GetDefiniteness() string {
+	if x != nil {
+		return x.Definiteness
+	}
+	return ""
+}
+
+func (x *WordForm) GetGender() string {
+	if x != nil {
+		return x.Gender
+	}
+	return ""
+}
+
+func (x *WordForm) GetDegree() string {
+	if x != nil {
+		return x.Degree
+	}
+	return ""
+}
+
+func (x *WordForm) GetTense() string {
+	if x != nil {
+		return x.Tense
+	}
+	return ""
+}
+
+func (x *WordForm) GetPerson() string {
+	if x != nil {
+		return x.Person
+	}
+	return ""
+}
+
+func (x *WordForm) GetMood() string {
+	if x != nil {
+		return x.Mood
+	}
+	return ""
+}
+
+func (x *WordForm) GetVoice() string {
+	if x != nil {
+		return x.Voice
+	}
+	return ""
+}
+
+func (x *WordForm) GetCase() string {
+	if x != nil {
+		return x.Case
+	}
+	return ""
+}
+
+// Pronunciation mirrors models.Pronunciation.
+type Pronunciation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Text  string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	// "ipa" or "respelling".
+	Notation string `protobuf:"bytes,2,opt,name=notation,proto3" json:"notation,omitempty"`
+	Audio    string `protobuf:"bytes,3,opt,name=audio,proto3" json:"audio,omitempty"`
+	// Regional variant, e.g. "uk" or "us".
+	Variant       string `protobuf:"bytes,4,opt,name=variant,proto3" json:"variant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pronunciation) Reset() {
+	*x = Pronunciation{}
+	mi := &file_wordentry_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pronunciation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pronunciation) ProtoMessage() {}
+
+func (x *Pronunciation) ProtoReflect() protoreflect.Message {
+	mi := &file_wordentry_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pronunciation.ProtoReflect.Descriptor instead.
+func (*Pronunciation) Descriptor() ([]byte, []int) {
+	return file_wordentry_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Pronunciation) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Pronunciation) GetNotation() string {
+	if x != nil {
+		return x.Notation
+	}
+	return ""
+}
+
+func (x *Pronunciation) GetAudio() string {
+	if x != nil {
+		return x.Audio
+	}
+	return ""
+}
+
+func (x *Pronunciation) GetVariant() string {
+	if x != nil {
+		return x.Variant
+	}
+	return ""
+}
+
+// AudioClip mirrors models.AudioClip.
+type AudioClip struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Url   string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// e.g. "forvo".
+	Source string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	// Regional variant or speaker's country.
+	Variant       string `protobuf:"bytes,3,opt,name=variant,proto3" json:"variant,omitempty"`
+	Speaker       string `protobuf:"bytes,4,opt,name=speaker,proto3" json:"speaker,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AudioClip) Reset() {
+	*x = AudioClip{}
+	mi := &file_wordentry_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AudioClip) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AudioClip) ProtoMessage() {}
+
+func (x *AudioClip) ProtoReflect() protoreflect.Message {
+	mi := &file_wordentry_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AudioClip.ProtoReflect.Descriptor instead.
+func (*AudioClip) Descriptor() ([]byte, []int) {
+	return file_wordentry_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AudioClip) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *AudioClip) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *AudioClip) GetVariant() string {
+	if x != nil {
+		return x.Variant
+	}
+	return ""
+}
+
+func (x *AudioClip) GetSpeaker() string {
+	if x != nil {
+		return x.Speaker
+	}
+	return ""
+}
+
+// ScrapeWarning mirrors models.ScrapeWarning.
+type ScrapeWarning struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// See the models.Warning* constants, e.g. "offline_fallback".
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	// The sense the warning is about, if any.
+	SenseId       string `protobuf:"bytes,2,opt,name=sense_id,json=senseId,proto3" json:"sense_id,omitempty"`
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScrapeWarning) Reset() {
+	*x = ScrapeWarning{}
+	mi := &file_wordentry_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScrapeWarning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScrapeWarning) ProtoMessage() {}
+
+func (x *ScrapeWarning) ProtoReflect() protoreflect.Message {
+	mi := &file_wordentry_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScrapeWarning.ProtoReflect.Descriptor instead.
+func (*ScrapeWarning) Descriptor() ([]byte, []int) {
+	return file_wordentry_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ScrapeWarning) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ScrapeWarning) GetSenseId() string {
+	if x != nil {
+		return x.SenseId
+	}
+	return ""
+}
+
+func (x *ScrapeWarning) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Reference mirrors models.Reference.
+type Reference struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Word  string                 `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	// "see", "compare", "origin" or "related".
+	Relation      string `protobuf:"bytes,2,opt,name=relation,proto3" json:"relation,omitempty"`
+	ArticleId     string `protobuf:"bytes,3,opt,name=article_id,json=articleId,proto3" json:"article_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reference) Reset() {
+	*x = Reference{}
+	mi := &file_wordentry_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reference) ProtoMessage() {}
+
+func (x *Reference) ProtoReflect() protoreflect.Message {
+	mi := &file_wordentry_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reference.ProtoReflect.Descriptor instead.
+func (*Reference) Descriptor() ([]byte, []int) {
+	return file_wordentry_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Reference) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *Reference) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *Reference) GetArticleId() string {
+	if x != nil {
+		return x.ArticleId
+	}
+	return ""
+}
+
+// Frequency mirrors models.Frequency.
+type Frequency struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 1 is the most frequent word.
+	Rank int32 `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	// 1 (top 1,000) to 5 (beyond the top 30,000).
+	Band          int32 `protobuf:"varint,2,opt,name=band,proto3" json:"band,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Frequency) Reset() {
+	*x = Frequency{}
+	mi := &file_wordentry_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Frequency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Frequency) ProtoMessage() {}
+
+func (x *Frequency) ProtoReflect() protoreflect.Message {
+	mi := &file_wordentry_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Frequency.ProtoReflect.Descriptor instead.
+func (*Frequency) Descriptor() ([]byte, []int) {
+	return file_wordentry_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Frequency) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *Frequency) GetBand() int32 {
+	if x != nil {
+		return x.Band
+	}
+	return 0
+}
+
+// CefrLevel mirrors models.CEFRLevel.
+type CefrLevel struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "A1" to "C2".
+	Level string `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	// "list" or "frequency".
+	Basis         string `protobuf:"bytes,2,opt,name=basis,proto3" json:"basis,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CefrLevel) Reset() {
+	*x = CefrLevel{}
+	mi := &file_wordentry_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CefrLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CefrLevel) ProtoMessage() {}
+
+func (x *CefrLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_wordentry_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CefrLevel.ProtoReflect.Descriptor instead.
+func (*CefrLevel) Descriptor() ([]byte, []int) {
+	return file_wordentry_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CefrLevel) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *CefrLevel) GetBasis() string {
+	if x != nil {
+		return x.Basis
+	}
+	return ""
+}
+
+type Sense struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Category       string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	Gender         string                 `protobuf:"bytes,3,opt,name=gender,proto3" json:"gender,omitempty"`
+	Article        string                 `protobuf:"bytes,4,opt,name=article,proto3" json:"article,omitempty"`
+	Meanings       []*Meaning             `protobuf:"bytes,5,rep,name=meanings,proto3" json:"meanings,omitempty"`
+	Expressions    []*Expression          `protobuf:"bytes,6,rep,name=expressions,proto3" json:"expressions,omitempty"`
+	WordForms      []*WordForm            `protobuf:"bytes,7,rep,name=word_forms,json=wordForms,proto3" json:"word_forms,omitempty"`
+	Pronunciations []*Pronunciation       `protobuf:"bytes,8,rep,name=pronunciations,proto3" json:"pronunciations,omitempty"`
+	Etymology      string                 `protobuf:"bytes,9,opt,name=etymology,proto3" json:"etymology,omitempty"`
+	Synonyms       []string               `protobuf:"bytes,10,rep,name=synonyms,proto3" json:"synonyms,omitempty"`
+	Antonyms       []string               `protobuf:"bytes,11,rep,name=antonyms,proto3" json:"antonyms,omitempty"`
+	// Normalized word class, e.g. "noun"; see models.PartOfSpeech.
+	PartOfSpeech  string       `protobuf:"bytes,12,opt,name=part_of_speech,json=partOfSpeech,proto3" json:"part_of_speech,omitempty"`
+	References    []*Reference `protobuf:"bytes,13,rep,name=references,proto3" json:"references,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Sense) Reset() {
+	*x = Sense{}
+	mi := &file_wordentry_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Sense) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sense) ProtoMessage() {}
+
+func (x *Sense) ProtoReflect() protoreflect.Message {
+	mi := &file_wordentry_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sense.ProtoReflect.Descriptor instead.
+func (*Sense) Descriptor() ([]byte, []int) {
+	return file_wordentry_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Sense) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Sense) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Sense) GetGender() string {
+	if x != nil {
+		return x.Gender
+	}
+	return ""
+}
+
+func (x *Sense) GetArticle() string {
+	if x != nil {
+		return x.Article
+	}
+	return ""
+}
+
+func (x *Sense) GetMeanings() []*Meaning {
+	if x != nil {
+		return x.Meanings
+	}
+	return nil
+}
+
+func (x *Sense) GetExpressions() []*Expression {
+	if x != nil {
+		return x.Expressions
+	}
+	return nil
+}
+
+func (x *Sense) GetWordForms() []*WordForm {
+	if x != nil {
+		return x.WordForms
+	}
+	return nil
+}
+
+func (x *Sense) GetPronunciations() []*Pronunciation {
+	if x != nil {
+		return x.Pronunciations
+	}
+	return nil
+}
+
+func (x *Sense) GetEtymology() string {
+	if x != nil {
+		return x.Etymology
+	}
+	return ""
+}
+
+func (x *Sense) GetSynonyms() []string {
+	if x != nil {
+		return x.Synonyms
+	}
+	return nil
+}
+
+func (x *Sense) GetAntonyms() []string {
+	if x != nil {
+		return x.Antonyms
+	}
+	return nil
+}
+
+func (x *Sense) GetPartOfSpeech() string {
+	if x != nil {
+		return x.PartOfSpeech
+	}
+	return ""
+}
+
+func (x *Sense) GetReferences() []*Reference {
+	if x != nil {
+		return x.References
+	}
+	return nil
+}
+
+// WordEntry mirrors models.WordEntry in the Go service.
+type WordEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Word  string                 `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	// Canonical dictionary code, e.g. "no-bm".
+	Language           string   `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Senses             []*Sense `protobuf:"bytes,3,rep,name=senses,proto3" json:"senses,omitempty"`
+	InflectionsPartial bool     `protobuf:"varint,4,opt,name=inflections_partial,json=inflectionsPartial,proto3" json:"inflections_partial,omitempty"`
+	// Hash of word, language and source version; see models.IdempotencyKey.
+	IdempotencyKey string           `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	Pronunciations []*Pronunciation `protobuf:"bytes,6,rep,name=pronunciations,proto3" json:"pronunciations,omitempty"`
+	// sources.Profile name, e.g. "ordbokene".
+	Source    string `protobuf:"bytes,7,opt,name=source,proto3" json:"source,omitempty"`
+	SourceUrl string `protobuf:"bytes,8,opt,name=source_url,json=sourceUrl,proto3" json:"source_url,omitempty"`
+	// RFC 3339 time the source was read.
+	ScrapedAt string `protobuf:"bytes,9,opt,name=scraped_at,json=scrapedAt,proto3" json:"scraped_at,omitempty"`
+	License   string `protobuf:"bytes,10,opt,name=license,proto3" json:"license,omitempty"`
+	// Unset when the word isn't on its language's frequency list.
+	Frequency *Frequency `protobuf:"bytes,11,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	// Unset when no level could be given.
+	Cefr *CefrLevel `protobuf:"bytes,12,opt,name=cefr,proto3" json:"cefr,omitempty"`
+	// The word as looked up, when it was an inflected form resolved to word.
+	Query string `protobuf:"bytes,13,opt,name=query,proto3" json:"query,omitempty"`
+	// Set when the request gave no language and it was detected.
+	LanguageDetected bool             `protobuf:"varint,14,opt,name=language_detected,json=languageDetected,proto3" json:"language_detected,omitempty"`
+	Audio            []*AudioClip     `protobuf:"bytes,15,rep,name=audio,proto3" json:"audio,omitempty"`
+	Warnings         []*ScrapeWarning `protobuf:"bytes,16,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *WordEntry) Reset() {
+	*x = WordEntry{}
+	mi := &file_wordentry_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WordEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WordEntry) ProtoMessage() {}
+
+func (x *WordEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_wordentry_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WordEntry.ProtoReflect.Descriptor instead.
+func (*WordEntry) Descriptor() ([]byte, []int) {
+	return file_wordentry_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WordEntry) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *WordEntry) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *WordEntry) GetSenses() []*Sense {
+	if x != nil {
+		return x.Senses
+	}
+	return nil
+}
+
+func (x *WordEntry) GetInflectionsPartial() bool {
+	if x != nil {
+		return x.InflectionsPartial
+	}
+	return false
+}
+
+func (x *WordEntry) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *WordEntry) GetPronunciations() []*Pronunciation {
+	if x != nil {
+		return x.Pronunciations
+	}
+	return nil
+}
+
+func (x *WordEntry) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *WordEntry) GetSourceUrl() string {
+	if x != nil {
+		return x.SourceUrl
+	}
+	return ""
+}
+
+func (x *WordEntry) GetScrapedAt() string {
+	if x != nil {
+		return x.ScrapedAt
+	}
+	return ""
+}
+
+func (x *WordEntry) GetLicense() string {
+	if x != nil {
+		return x.License
+	}
+	return ""
+}
+
+func (x *WordEntry) GetFrequency() *Frequency {
+	if x != nil {
+		return x.Frequency
+	}
+	return nil
+}
+
+func (x *WordEntry) GetCefr() *CefrLevel {
+	if x != nil {
+		return x.Cefr
+	}
+	return nil
+}
+
+func (x *WordEntry) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *WordEntry) GetLanguageDetected() bool {
+	if x != nil {
+		return x.LanguageDetected
+	}
+	return false
+}
+
+func (x *WordEntry) GetAudio() []*AudioClip {
+	if x != nil {
+		return x.Audio
+	}
+	return nil
+}
+
+func (x *WordEntry) GetWarnings() []*ScrapeWarning {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+var File_wordentry_proto protoreflect.FileDescriptor
+
+const file_wordentry_proto_rawDesc = "" +
+	"\n" +
+	"\x0fwordentry.proto\x12\fwordentry.v1\"G\n" +
+	"\aMeaning\x12 \n" +
+	"\vdescription\x18\x01 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bexamples\x18\x02 \x03(\tR\bexamples\"F\n" +
+	"\n" +
+	"Expression\x12\x16\n" +
+	"\x06phrase\x18\x01 \x01(\tR\x06phrase\x12 \n" +
+	"\vexplanation\x18\x02 \x01(\tR\vexplanation\"\x8e\x02\n" +
+	"\bWordForm\x12\x14\n" +
+	"\x05label\x18\x01 \x01(\tR\x05label\x12\x14\n" +
+	"\x05forms\x18\x02 \x03(\tR\x05forms\x12\x16\n" +
+	"\x06number\x18\x03 \x01(\tR\x06number\x12\"\n" +
+	"\fdefiniteness\x18\x04 \x01(\tR\fdefiniteness\x12\x16\n" +
+	"\x06gender\x18\x05 \x01(\tR\x06gender\x12\x16\n" +
+	"\x06degree\x18\x06 \x01(\tR\x06degree\x12\x14\n" +
+	"\x05tense\x18\a \x01(\tR\x05tense\x12\x16\n" +
+	"\x06person\x18\b \x01(\tR\x06person\x12\x12\n" +
+	"\x04mood\x18\t \x01(\tR\x04mood\x12\x14\n" +
+	"\x05voice\x18\n" +
+	" \x01(\tR\x05voice\x12\x12\n" +
+	"\x04case\x18\v \x01(\tR\x04case\"o\n" +
+	"\rPronunciation\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x1a\n" +
+	"\bnotation\x18\x02 \x01(\tR\bnotation\x12\x14\n" +
+	"\x05audio\x18\x03 \x01(\tR\x05audio\x12\x18\n" +
+	"\avariant\x18\x04 \x01(\tR\avariant\"i\n" +
+	"\tAudioClip\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x18\n" +
+	"\avariant\x18\x03 \x01(\tR\avariant\x12\x18\n" +
+	"\aspeaker\x18\x04 \x01(\tR\aspeaker\"X\n" +
+	"\rScrapeWarning\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x19\n" +
+	"\bsense_id\x18\x02 \x01(\tR\asenseId\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"Z\n" +
+	"\tReference\x12\x12\n" +
+	"\x04word\x18\x01 \x01(\tR\x04word\x12\x1a\n" +
+	"\brelation\x18\x02 \x01(\tR\brelation\x12\x1d\n" +
+	"\n" +
+	"article_id\x18\x03 \x01(\tR\tarticleId\"3\n" +
+	"\tFrequency\x12\x12\n" +
+	"\x04rank\x18\x01 \x01(\x05R\x04rank\x12\x12\n" +
+	"\x04band\x18\x02 \x01(\x05R\x04band\"7\n" +
+	"\tCefrLevel\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\x12\x14\n" +
+	"\x05basis\x18\x02 \x01(\tR\x05basis\"\x85\x04\n" +
+	"\x05Sense\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x16\n" +
+	"\x06gender\x18\x03 \x01(\tR\x06gender\x12\x18\n" +
+	"\aarticle\x18\x04 \x01(\tR\aarticle\x121\n" +
+	"\bmeanings\x18\x05 \x03(\v2\x15.wordentry.v1.MeaningR\bmeanings\x12:\n" +
+	"\vexpressions\x18\x06 \x03(\v2\x18.wordentry.v1.ExpressionR\vexpressions\x125\n" +
+	"\n" +
+	"word_forms\x18\a \x03(\v2\x16.wordentry.v1.WordFormR\twordForms\x12C\n" +
+	"\x0epronunciations\x18\b \x03(\v2\x1b.wordentry.v1.PronunciationR\x0epronunciations\x12\x1c\n" +
+	"\tetymology\x18\t \x01(\tR\tetymology\x12\x1a\n" +
+	"\bsynonyms\x18\n" +
+	" \x03(\tR\bsynonyms\x12\x1a\n" +
+	"\bantonyms\x18\v \x03(\tR\bantonyms\x12$\n" +
+	"\x0epart_of_speech\x18\f \x01(\tR\fpartOfSpeech\x127\n" +
+	"\n" +
+	"references\x18\r \x03(\v2\x17.wordentry.v1.ReferenceR\n" +
+	"references\"\x86\x05\n" +
+	"\tWordEntry\x12\x12\n" +
+	"\x04word\x18\x01 \x01(\tR\x04word\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12+\n" +
+	"\x06senses\x18\x03 \x03(\v2\x13.wordentry.v1.SenseR\x06senses\x12/\n" +
+	"\x13inflections_partial\x18\x04 \x01(\bR\x12inflectionsPartial\x12'\n" +
+	"\x0fidempotency_key\x18\x05 \x01(\tR\x0eidempotencyKey\x12C\n" +
+	"\x0epronunciations\x18\x06 \x03(\v2\x1b.wordentry.v1.PronunciationR\x0epronunciations\x12\x16\n" +
+	"\x06source\x18\a \x01(\tR\x06source\x12\x1d\n" +
+	"\n" +
+	"source_url\x18\b \x01(\tR\tsourceUrl\x12\x1d\n" +
+	"\n" +
+	"scraped_at\x18\t \x01(\tR\tscrapedAt\x12\x18\n" +
+	"\alicense\x18\n" +
+	" \x01(\tR\alicense\x125\n" +
+	"\tfrequency\x18\v \x01(\v2\x17.wordentry.v1.FrequencyR\tfrequency\x12+\n" +
+	"\x04cefr\x18\f \x01(\v2\x17.wordentry.v1.CefrLevelR\x04cefr\x12\x14\n" +
+	"\x05query\x18\r \x01(\tR\x05query\x12+\n" +
+	"\x11language_detected\x18\x0e \x01(\bR\x10languageDetected\x12-\n" +
+	"\x05audio\x18\x0f \x03(\v2\x17.wordentry.v1.AudioClipR\x05audio\x127\n" +
+	"\bwarnings\x18\x10 \x03(\v2\x1b.wordentry.v1.ScrapeWarningR\bwarningsB/Z-vocabulary-app/backend/go-service/wordentrypbb\x06proto3"
+
+var (
+	file_wordentry_proto_rawDescOnce sync.Once
+	file_wordentry_proto_rawDescData []byte
+)
+
+func file_wordentry_proto_rawDescGZIP() []byte {
+	file_wordentry_proto_rawDescOnce.Do(func() {
+		file_wordentry_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_wordentry_proto_rawDesc), len(file_wordentry_proto_rawDesc)))
+	})
+	return file_wordentry_proto_rawDescData
+}
+
+var file_wordentry_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_wordentry_proto_goTypes = []any{
+	(*Meaning)(nil),       // 0: wordentry.v1.Meaning
+	(*Expression)(nil),    // 1: wordentry.v1.Expression
+	(*WordForm)(nil),      // 2: wordentry.v1.WordForm
+	(*Pronunciation)(nil), // 3: wordentry.v1.Pronunciation
+	(*AudioClip)(nil),     // 4: wordentry.v1.AudioClip
+	(*ScrapeWarning)(nil), // 5: wordentry.v1.ScrapeWarning
+	(*Reference)(nil),     // 6: wordentry.v1.Reference
+	(*Frequency)(nil),     // 7: wordentry.v1.Frequency
+	(*CefrLevel)(nil),     // 8: wordentry.v1.CefrLevel
+	(*Sense)(nil),         // 9: wordentry.v1.Sense
+	(*WordEntry)(nil),     // 10: wordentry.v1.WordEntry
+}
+var file_wordentry_proto_depIdxs = []int32{
+	0,  // 0: wordentry.v1.Sense.meanings:type_name -> wordentry.v1.Meaning
+	1,  // 1: wordentry.v1.Sense.expressions:type_name -> wordentry.v1.Expression
+	2,  // 2: wordentry.v1.Sense.word_forms:type_name -> wordentry.v1.WordForm
+	3,  // 3: wordentry.v1.Sense.pronunciations:type_name -> wordentry.v1.Pronunciation
+	6,  // 4: wordentry.v1.Sense.references:type_name -> wordentry.v1.Reference
+	9,  // 5: wordentry.v1.WordEntry.senses:type_name -> wordentry.v1.Sense
+	3,  // 6: wordentry.v1.WordEntry.pronunciations:type_name -> wordentry.v1.Pronunciation
+	7,  // 7: wordentry.v1.WordEntry.frequency:type_name -> wordentry.v1.Frequency
+	8,  // 8: wordentry.v1.WordEntry.cefr:type_name -> wordentry.v1.CefrLevel
+	4,  // 9: wordentry.v1.WordEntry.audio:type_name -> wordentry.v1.AudioClip
+	5,  // 10: wordentry.v1.WordEntry.warnings:type_name -> wordentry.v1.ScrapeWarning
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_wordentry_proto_init() }
+func file_wordentry_proto_init() {
+	if File_wordentry_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_wordentry_proto_rawDesc), len(file_wordentry_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_wordentry_proto_goTypes,
+		DependencyIndexes: file_wordentry_proto_depIdxs,
+		MessageInfos:      file_wordentry_proto_msgTypes,
+	}.Build()
+	File_wordentry_proto = out.File
+	file_wordentry_proto_goTypes = nil
+	file_wordentry_proto_depIdxs = nil
+}
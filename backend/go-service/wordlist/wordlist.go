@@ -0,0 +1,62 @@
+// Package wordlist reads word lists, as uploaded to the import endpoint or
+// given to the vocab command.
+package wordlist
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Read reads the words of a list, dropping duplicates. CSV lists take
+// words from the "word" column, or the first column if there is no such
+// header; plain text lists have one word per line, skipping blank lines
+// and # comments.
+func Read(list io.Reader, isCSV bool) ([]string, error) {
+	var words []string
+	seen := map[string]bool{}
+	add := func(word string) {
+		if word = strings.TrimSpace(word); word != "" && !seen[word] {
+			seen[word] = true
+			words = append(words, word)
+		}
+	}
+
+	if !isCSV {
+		scanner := bufio.NewScanner(list)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); !strings.HasPrefix(line, "#") {
+				add(line)
+			}
+		}
+		return words, scanner.Err()
+	}
+
+	reader := csv.NewReader(list)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	column := 0
+	for row := 0; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return words, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if row == 0 {
+			if i := slices.IndexFunc(record, func(field string) bool {
+				return strings.EqualFold(strings.TrimSpace(field), "word")
+			}); i >= 0 {
+				column = i
+				continue
+			}
+		}
+		if column < len(record) {
+			add(record[column])
+		}
+	}
+}
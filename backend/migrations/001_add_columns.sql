@@ -0,0 +1,54 @@
+-- Brings databases created before these columns existed up to schema.sql.
+-- Safe to run more than once: each column is only added when
+-- information_schema says it is missing (MySQL 8.0 has no
+-- ADD COLUMN IF NOT EXISTS).
+--
+--   mysql -u vocabapp -p vocabulary_app < backend/migrations/001_add_columns.sql
+
+DROP PROCEDURE IF EXISTS add_column_if_missing;
+
+DELIMITER //
+CREATE PROCEDURE add_column_if_missing(IN table_name_in VARCHAR(64), IN column_name_in VARCHAR(64), IN definition TEXT)
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM information_schema.COLUMNS
+        WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = table_name_in AND COLUMN_NAME = column_name_in
+    ) THEN
+        SET @ddl = CONCAT('ALTER TABLE `', table_name_in, '` ADD COLUMN `', column_name_in, '` ', definition);
+        PREPARE stmt FROM @ddl;
+        EXECUTE stmt;
+        DEALLOCATE PREPARE stmt;
+    END IF;
+END //
+DELIMITER ;
+
+-- Password-less (Google) accounts and the Google subject ID
+ALTER TABLE users MODIFY password_hash VARCHAR(255) NULL;
+CALL add_column_if_missing('users', 'google_sub', 'VARCHAR(255) UNIQUE NULL');
+-- User roles
+CALL add_column_if_missing('users', 'role', "ENUM('user', 'admin') DEFAULT 'user'");
+
+-- Key scraped entries so repeated deliveries are stored once
+CALL add_column_if_missing('words', 'idempotency_key', 'CHAR(64) UNIQUE NULL');
+-- Phonetic transcription from the source dictionary
+CALL add_column_if_missing('words', 'pronunciation', 'VARCHAR(255) NULL');
+-- Word origin from the source dictionary
+CALL add_column_if_missing('words', 'etymology', 'TEXT NULL');
+-- Where the entry was scraped from, when, and under what license
+CALL add_column_if_missing('words', 'source', 'VARCHAR(100) NULL');
+CALL add_column_if_missing('words', 'source_url', 'VARCHAR(2048) NULL');
+CALL add_column_if_missing('words', 'scraped_at', 'DATETIME NULL');
+CALL add_column_if_missing('words', 'license', 'VARCHAR(100) NULL');
+-- Rank on the language's frequency list, for sorting decks by usefulness
+CALL add_column_if_missing('words', 'frequency_rank', 'INT NULL');
+CALL add_column_if_missing('words', 'frequency_band', 'TINYINT NULL');
+-- Estimated CEFR level, for filtering decks
+CALL add_column_if_missing('words', 'cefr_level', 'CHAR(2) NULL');
+-- Inflection rows the scraped entry had, for the data quality report
+CALL add_column_if_missing('words', 'inflection_count', 'SMALLINT NULL');
+-- Gender, inflected forms and examples from the scraped entry, for exports
+CALL add_column_if_missing('words', 'gender', 'VARCHAR(50) NULL');
+CALL add_column_if_missing('words', 'forms', 'TEXT NULL');
+CALL add_column_if_missing('meanings', 'examples', 'TEXT NULL');
+
+DROP PROCEDURE add_column_if_missing;
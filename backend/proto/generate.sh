@@ -0,0 +1,14 @@
+#!/bin/sh
+# Regenerates the WordEntry messages and WordIngest stubs for both services.
+# Requires protoc, protoc-gen-go, protoc-gen-go-grpc and grpcio-tools.
+set -e
+cd "$(dirname "$0")"
+
+protoc --proto_path=. \
+  --go_out=../go-service --go_opt=module=vocabulary-app/backend/go-service \
+  --go-grpc_out=../go-service --go-grpc_opt=module=vocabulary-app/backend/go-service \
+  wordentry.proto wordingest.proto
+
+python -m grpc_tools.protoc --proto_path=. \
+  --python_out=../python-service --grpc_python_out=../python-service \
+  wordentry.proto wordingest.proto
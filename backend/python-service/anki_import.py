@@ -0,0 +1,175 @@
+"""
+Parsing of Anki exports for import into the learning queue.
+
+Two export formats are read: deck packages (.apkg, a zip holding the
+collection as SQLite) and "Notes in Plain Text" (.txt, tab-separated fields).
+A note's first field is taken as the word and its second as the meaning.
+Packages also carry scheduling, which is mapped onto the SM-2 fields of
+user_progress; plain text exports have none. Packages from recent Anki
+versions store a compressed collection that can't be read here, so they
+must be exported with "Support older Anki versions" ticked.
+"""
+from dataclasses import dataclass
+from datetime import datetime, timedelta
+from html import unescape
+from typing import List, Optional
+import io
+import re
+import shutil
+import sqlite3
+import tempfile
+import zipfile
+
+# Collection files inside a package, newest schema first
+APKG_COLLECTIONS = ("collection.anki21", "collection.anki2")
+
+# Largest upload accepted, and largest collection unpacked from a package
+MAX_UPLOAD_BYTES = 20 * 1024 * 1024
+MAX_COLLECTION_BYTES = 100 * 1024 * 1024
+
+# Anki's card types (cards.type)
+CARD_NEW, CARD_LEARNING, CARD_REVIEW, CARD_RELEARNING = 0, 1, 2, 3
+
+TAG_PATTERN = re.compile(r"<[^>]+>")
+SOUND_PATTERN = re.compile(r"\[sound:[^\]]*\]")
+CLOZE_PATTERN = re.compile(r"\{\{c\d+::(.*?)(?:::[^}]*)?\}\}")
+
+
+@dataclass
+class AnkiSchedule:
+    """A card's review state in user_progress terms."""
+    ease_factor: float
+    interval_days: int
+    repetitions: int
+    review_count: int
+    correct_count: int
+    next_review: datetime
+
+
+@dataclass
+class AnkiNote:
+    """A note reduced to a word, its meaning and its first card's schedule."""
+    word: str
+    meaning: str
+    schedule: Optional[AnkiSchedule] = None
+
+
+class ExportTooLarge(ValueError):
+    """The export, or the collection in it, is over the size limits."""
+
+
+def clean_field(value: str) -> str:
+    """Plain text of a note field: HTML, sound tags and cloze markup removed."""
+    value = re.sub(r"<br\s*/?>|<div>", " ", value, flags=re.IGNORECASE)
+    value = CLOZE_PATTERN.sub(r"\1", value)
+    value = SOUND_PATTERN.sub("", TAG_PATTERN.sub("", value))
+    return " ".join(unescape(value).split())
+
+
+def parse_anki_export(filename: str, data: bytes, max_notes: int) -> List[AnkiNote]:
+    """
+    Read the notes of an Anki export, picking the format by file name.
+    Reading stops after max_notes + 1 notes, enough to tell the export has
+    too many.
+
+    Raises:
+        ExportTooLarge: If a package's collection is over MAX_COLLECTION_BYTES
+        ValueError: If the file isn't a readable Anki export
+    """
+    if filename.lower().endswith(".apkg"):
+        return _parse_package(data, max_notes)
+    if filename.lower().endswith(".txt"):
+        try:
+            return _parse_text(data.decode("utf-8-sig"), max_notes)
+        except UnicodeDecodeError:
+            raise ValueError("Text export must be UTF-8")
+    raise ValueError("Expected an .apkg package or a .txt export")
+
+
+def _note(fields: List[str], schedule: Optional[AnkiSchedule] = None) -> Optional[AnkiNote]:
+    fields = [clean_field(f) for f in fields]
+    if len(fields) < 2 or not fields[0] or not fields[1]:
+        return None
+    return AnkiNote(word=fields[0], meaning=fields[1], schedule=schedule)
+
+
+def _parse_text(text: str, max_notes: int) -> List[AnkiNote]:
+    separator = "\t"
+    notes = []
+    for line in text.splitlines():
+        if len(notes) > max_notes:
+            break
+        # Header lines such as "#separator:tab" or "#html:true"
+        if line.startswith("#"):
+            key, _, value = line[1:].partition(":")
+            if key == "separator":
+                separator = {"tab": "\t", "comma": ",", "semicolon": ";", "space": " ", "pipe": "|"}.get(value, value)
+            continue
+        note = _note(line.split(separator))
+        if note:
+            notes.append(note)
+    return notes
+
+
+def _parse_package(data: bytes, max_notes: int) -> List[AnkiNote]:
+    try:
+        package = zipfile.ZipFile(io.BytesIO(data))
+    except zipfile.BadZipFile:
+        raise ValueError("Not a valid .apkg package")
+    names = set(package.namelist())
+    collection = next((name for name in APKG_COLLECTIONS if name in names), None)
+    if not collection:
+        raise ValueError("Package has no readable collection; export it with \"Support older Anki versions\"")
+    # Checked before unpacking; zipfile won't unpack more than the size says
+    if package.getinfo(collection).file_size > MAX_COLLECTION_BYTES:
+        raise ExportTooLarge(f"Package collection is larger than {MAX_COLLECTION_BYTES // (1024 * 1024)} MB")
+
+    # sqlite3 needs a file to open
+    with tempfile.NamedTemporaryFile(suffix=".anki2") as db_file:
+        with package.open(collection) as source:
+            shutil.copyfileobj(source, db_file)
+        db_file.flush()
+        db = sqlite3.connect(db_file.name)
+        try:
+            created = datetime.fromtimestamp(db.execute("SELECT crt FROM col").fetchone()[0])
+            rows = db.execute("""
+                SELECT n.flds, c.type, c.due, c.ivl, c.factor, c.reps, c.lapses
+                FROM notes n
+                LEFT JOIN cards c ON c.id = (
+                    SELECT id FROM cards WHERE nid = n.id ORDER BY ord LIMIT 1
+                )
+                ORDER BY n.id
+                LIMIT ?
+            """, (max_notes + 1,)).fetchall()
+        except sqlite3.DatabaseError:
+            raise ValueError("Package collection is not a readable Anki database")
+        finally:
+            db.close()
+
+    notes = []
+    for fields, card_type, due, interval, factor, reps, lapses in rows:
+        note = _note(fields.split("\x1f"), _schedule(created, card_type, due, interval, factor, reps, lapses))
+        if note:
+            notes.append(note)
+    return notes
+
+
+def _schedule(created: datetime, card_type, due, interval, factor, reps, lapses) -> Optional[AnkiSchedule]:
+    """Map a card's scheduling onto SM-2 fields; new cards have none."""
+    if card_type not in (CARD_LEARNING, CARD_REVIEW, CARD_RELEARNING) or not reps:
+        return None
+    if card_type == CARD_REVIEW:
+        # Review cards are due a number of days after the collection was created
+        next_review = created + timedelta(days=due)
+    else:
+        # Learning cards are due at a Unix timestamp
+        next_review = datetime.fromtimestamp(due)
+    return AnkiSchedule(
+        ease_factor=max(1.3, (factor or 2500) / 1000),
+        interval_days=max(interval or 0, 0),
+        # Successful reviews since the last lapse aren't recorded; use the total
+        repetitions=reps,
+        review_count=reps,
+        correct_count=max(reps - (lapses or 0), 0),
+        next_review=next_review,
+    )
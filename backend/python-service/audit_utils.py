@@ -0,0 +1,48 @@
+"""
+Audit logging for scrape and data-modifying actions.
+Records who did what to which entity, and when, in the audit_log table.
+"""
+from typing import Any, Dict, Optional
+import json
+from db_utils import logger
+
+
+def record_audit(
+    cursor,
+    action: str,
+    entity_type: str,
+    entity_id: Any = None,
+    user: Optional[Dict] = None,
+    details: Optional[Dict] = None,
+    request_id: Optional[str] = None,
+    service: str = "python"
+) -> None:
+    """
+    Insert an audit record using the caller's cursor, so the record is
+    committed (or rolled back) together with the change it describes.
+    
+    Args:
+        cursor: Open database cursor
+        action: What happened ("scrape", "create", "update", "delete", ...)
+        entity_type: Kind of entity affected ("word", "user", "grammar_topic", ...)
+        entity_id: ID or key of the entity (e.g. word ID, or "hund@no-bm")
+        user: Token payload of the acting user, if authenticated
+        details: Extra context stored as JSON
+        request_id: X-Request-ID of the originating request
+        service: Which backend performed the action ("python" or "go")
+    """
+    cursor.execute("""
+        INSERT INTO audit_log
+        (user_id, user_email, action, entity_type, entity_id, details, request_id, service)
+        VALUES (%s, %s, %s, %s, %s, %s, %s, %s)
+    """, (
+        user.get("id") if user else None,
+        user.get("email") if user else None,
+        action,
+        entity_type,
+        str(entity_id) if entity_id is not None else None,
+        json.dumps(details) if details else None,
+        request_id,
+        service
+    ))
+    logger.info(f"Audit: {action} {entity_type} {entity_id} by user {user.get('id') if user else 'anonymous'}")
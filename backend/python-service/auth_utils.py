@@ -2,7 +2,7 @@
 Authentication utilities for JWT token verification.
 Can be imported by other route modules to protect endpoints.
 """
-from fastapi import HTTPException, Header
+from fastapi import HTTPException, Header, Depends
 from typing import Optional
 import jwt
 import os
@@ -40,3 +40,44 @@ def get_current_user(authorization: Optional[str] = Header(None)):
         raise HTTPException(status_code=401, detail="Token has expired")
     except jwt.InvalidTokenError:
         raise HTTPException(status_code=401, detail="Invalid token")
+
+
+def get_optional_user(authorization: Optional[str] = Header(None)):
+    """
+    Like get_current_user, but returns None for anonymous requests instead of
+    failing. Use this where the user is only needed for attribution (auditing).
+    """
+    if not authorization:
+        return None
+    try:
+        return get_current_user(authorization)
+    except HTTPException:
+        return None
+
+
+# Known roles, from least to most privileged
+ROLES = ("user", "admin")
+
+
+def require_role(role: str):
+    """
+    Build a dependency that only lets users with the given role through.
+    Use this on admin-only routes: user = Depends(require_role("admin"))
+    
+    Args:
+        role: Required role ("user" or "admin")
+    
+    Returns:
+        Callable: FastAPI dependency returning the token payload
+    """
+    if role not in ROLES:
+        raise ValueError(f"Unknown role: {role}")
+    
+    def dependency(user: dict = Depends(get_current_user)):
+        # Tokens issued before roles existed carry no role and count as "user"
+        user_role = user.get("role", "user")
+        if user_role not in ROLES or ROLES.index(user_role) < ROLES.index(role):
+            raise HTTPException(status_code=403, detail="Insufficient permissions")
+        return user
+    
+    return dependency
@@ -27,7 +27,8 @@ from .base import (
     MeaningEntry, 
     ExpressionEntry, 
     WordFormEntry,
-    fetcher_registry
+    fetcher_registry,
+    normalize_word
 )
 from .norwegian import NorwegianFetcher
 from .english import EnglishFetcher
@@ -115,7 +116,7 @@ def fetch_word(word: str, language_code: str) -> Optional[WordEntry]:
         logger.warning(f"No fetcher available for language: {language_code}")
         return None
     
-    return fetcher.fetch_word(word)
+    return fetcher.fetch_word(normalize_word(word, language_code))
 
 
 # Export all public APIs
@@ -134,6 +135,7 @@ __all__ = [
     'is_language_supported',
     'get_supported_languages',
     'fetch_word',
+    'normalize_word',
     'initialize_fetchers',
     'fetcher_registry'
 ]
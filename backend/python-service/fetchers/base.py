@@ -4,11 +4,89 @@ Provides a consistent interface for fetching word data from different sources.
 """
 from abc import ABC, abstractmethod
 from typing import Dict, Any, Optional, List
-from dataclasses import dataclass
+from dataclasses import dataclass, field
+from datetime import datetime, timezone
 import logging
+import re
+import unicodedata
+from language_codes import db_language_code, canonical_language
 
 logger = logging.getLogger(__name__)
 
+# Category words the fetchers' sources use, mapped to the normalized part of
+# speech shared with the Go service (models.PartOfSpeech). Wiktionary's REST
+# API names German word classes in English, so one table covers all sources.
+PARTS_OF_SPEECH = {
+    "noun": "noun",
+    "substantiv": "noun",
+    "proper": "proper_noun",
+    "egennavn": "proper_noun",
+    "eigennamn": "proper_noun",
+    "verb": "verb",
+    "adjective": "adjective",
+    "adjektiv": "adjective",
+    "adverb": "adverb",
+    "pronoun": "pronoun",
+    "pronomen": "pronoun",
+    "determiner": "determiner",
+    "article": "determiner",
+    "determinativ": "determiner",
+    "preposition": "preposition",
+    "preposisjon": "preposition",
+    "conjunction": "conjunction",
+    "konjunksjon": "conjunction",
+    "subjunksjon": "conjunction",
+    "interjection": "interjection",
+    "exclamation": "interjection",
+    "interjeksjon": "interjection",
+    "numeral": "numeral",
+    "number": "numeral",
+    "particle": "particle",
+    "abbreviation": "abbreviation",
+    "phrase": "phrase",
+    "idiom": "phrase",
+    "uttrykk": "phrase",
+}
+
+
+def normalize_part_of_speech(category: str) -> Optional[str]:
+    """
+    Map a source's category text (e.g. "substantiv hankjønn") to a
+    normalized part of speech.
+
+    Returns:
+        The first known word's part of speech, "other" if none is known,
+        or None for an empty category
+    """
+    words = re.findall(r"[^\W\d_]+", category.lower())
+    if not words:
+        return None
+    for word in words:
+        if word in PARTS_OF_SPEECH:
+            return PARTS_OF_SPEECH[word]
+    return "other"
+
+
+# Typographic apostrophes typed in place of the "'" sources spell words with
+APOSTROPHES = str.maketrans({"\u2019": "'", "\u2018": "'", "\u02bc": "'", "\u00b4": "'", "`": "'"})
+
+
+def normalize_word(word: str, language_code: str) -> str:
+    """
+    Prepare user input for lookup, like the Go service's models.NormalizeWord:
+    spaces are collapsed, letters composed (NFC) and typographic apostrophes
+    replaced. Words are lowercased except in German, where capitals mark
+    nouns; Norwegian input also gets ä and ö read as æ and ø.
+    """
+    word = unicodedata.normalize("NFC", " ".join(word.split())).translate(APOSTROPHES)
+    language = canonical_language(language_code)
+    if language == "de":
+        return word
+    word = word.lower()
+    if language in ("no-bm", "no-nn"):
+        word = word.replace("ä", "æ").replace("ö", "ø")
+    return word
+
 
 @dataclass
 class MeaningEntry:
@@ -46,6 +124,10 @@ class WordFormEntry:
     gender: Optional[str] = None
     degree: Optional[str] = None
     tense: Optional[str] = None
+    person: Optional[str] = None
+    mood: Optional[str] = None
+    voice: Optional[str] = None
+    case: Optional[str] = None
     
     def to_dict(self) -> Dict[str, Any]:
         result = {
@@ -62,6 +144,47 @@ class WordFormEntry:
             result["degree"] = self.degree
         if self.tense:
             result["tense"] = self.tense
+        if self.person:
+            result["person"] = self.person
+        if self.mood:
+            result["mood"] = self.mood
+        if self.voice:
+            result["voice"] = self.voice
+        if self.case:
+            result["case"] = self.case
+        return result
+
+
+@dataclass
+class PronunciationEntry:
+    """Phonetic transcription, optionally with an audio recording."""
+    text: str
+    notation: Optional[str] = None  # "ipa" or "respelling"
+    audio: Optional[str] = None
+    variant: Optional[str] = None  # regional variant, e.g. "uk" or "us"
+    
+    def to_dict(self) -> Dict[str, Any]:
+        result = {"text": self.text}
+        if self.notation:
+            result["notation"] = self.notation
+        if self.audio:
+            result["audio"] = self.audio
+        if self.variant:
+            result["variant"] = self.variant
+        return result
+
+
+@dataclass
+class ReferenceEntry:
+    """Link to another entry the source points to ("se", "jf.")."""
+    word: str
+    relation: str  # "see", "compare", "origin" or "related"
+    article_id: Optional[str] = None
+    
+    def to_dict(self) -> Dict[str, Any]:
+        result = {"word": self.word, "relation": self.relation}
+        if self.article_id:
+            result["article_id"] = self.article_id
         return result
 
 
@@ -69,12 +192,18 @@ class WordFormEntry:
 class SenseEntry:
     """A single dictionary sense (e.g., one meaning of a multi-sense word)."""
     id: str
-    category: str  # noun, verb, adjective, etc.
+    category: str  # as the source words it, e.g. "substantiv hankjønn"
     meanings: List[MeaningEntry]
+    part_of_speech: Optional[str] = None  # normalized, e.g. "noun"
     gender: Optional[str] = None
     article: Optional[str] = None
     expressions: Optional[List[ExpressionEntry]] = None
     word_forms: Optional[List[WordFormEntry]] = None
+    pronunciations: Optional[List[PronunciationEntry]] = None
+    etymology: Optional[str] = None
+    synonyms: Optional[List[str]] = None
+    antonyms: Optional[List[str]] = None
+    references: Optional[List[ReferenceEntry]] = None
     
     def to_dict(self) -> Dict[str, Any]:
         result = {
@@ -82,6 +211,8 @@ class SenseEntry:
             "category": self.category,
             "meanings": [m.to_dict() for m in self.meanings]
         }
+        if self.part_of_speech:
+            result["part_of_speech"] = self.part_of_speech
         if self.gender:
             result["gender"] = self.gender
         if self.article:
@@ -90,6 +221,16 @@ class SenseEntry:
             result["expressions"] = [e.to_dict() for e in self.expressions]
         if self.word_forms:
             result["word_forms"] = [w.to_dict() for w in self.word_forms]
+        if self.pronunciations:
+            result["pronunciations"] = [p.to_dict() for p in self.pronunciations]
+        if self.etymology:
+            result["etymology"] = self.etymology
+        if self.synonyms:
+            result["synonyms"] = self.synonyms
+        if self.antonyms:
+            result["antonyms"] = self.antonyms
+        if self.references:
+            result["references"] = [r.to_dict() for r in self.references]
         return result
 
 
@@ -100,14 +241,27 @@ class WordEntry:
     language: str
     senses: List[SenseEntry]
     source: str  # Source dictionary (e.g., "ordbokene.no", "duden.de")
+    pronunciations: Optional[List[PronunciationEntry]] = None
+    source_url: Optional[str] = None  # page the entry was read from
+    license: Optional[str] = None  # e.g. "CC BY-SA 4.0"
+    # When the source was read (ISO 8601, UTC)
+    scraped_at: str = field(default_factory=lambda: datetime.now(timezone.utc).isoformat(timespec="seconds"))
     
     def to_dict(self) -> Dict[str, Any]:
-        return {
+        result = {
             "word": self.word,
             "language": self.language,
             "source": self.source,
+            "scraped_at": self.scraped_at,
             "senses": [s.to_dict() for s in self.senses]
         }
+        if self.pronunciations:
+            result["pronunciations"] = [p.to_dict() for p in self.pronunciations]
+        if self.source_url:
+            result["source_url"] = self.source_url
+        if self.license:
+            result["license"] = self.license
+        return result
 
 
 class BaseFetcher(ABC):
@@ -194,7 +348,7 @@ class FetcherRegistry:
         Returns:
             BaseFetcher instance if registered, None otherwise
         """
-        return self._fetchers.get(language_code)
+        return self._fetchers.get(db_language_code(language_code))
     
     def is_language_supported(self, language_code: str) -> bool:
         """
@@ -206,7 +360,7 @@ class FetcherRegistry:
         Returns:
             bool: True if language has a registered fetcher
         """
-        return language_code in self._fetchers
+        return db_language_code(language_code) in self._fetchers
     
     def get_supported_languages(self) -> List[str]:
         """
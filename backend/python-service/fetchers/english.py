@@ -2,9 +2,10 @@
 English dictionary fetcher using Free Dictionary API.
 Provides word definitions, phonetics, and examples for English words.
 """
-from typing import Optional
+from typing import Optional, List
+from urllib.parse import quote
 import requests
-from .base import BaseFetcher, WordEntry, SenseEntry, MeaningEntry
+from .base import BaseFetcher, WordEntry, SenseEntry, MeaningEntry, PronunciationEntry, normalize_part_of_speech
 
 
 class EnglishFetcher(BaseFetcher):
@@ -29,7 +30,7 @@ class EnglishFetcher(BaseFetcher):
             WordEntry if successful, None if word not found
         """
         try:
-            url = f"{self.api_url}/{word}"
+            url = f"{self.api_url}/{quote(word, safe='')}"
             
             self.logger.info(f"Fetching English word '{word}' from {self.source_name}")
             response = requests.get(url, timeout=10)
@@ -66,13 +67,22 @@ class EnglishFetcher(BaseFetcher):
             WordEntry object
         """
         senses = []
+        pronunciations = []
         
         # Process all entries (usually just one)
         for entry_idx, entry in enumerate(data):
+            for pronunciation in self._parse_phonetics(entry):
+                if pronunciation not in pronunciations:
+                    pronunciations.append(pronunciation)
+            
             # Each entry can have multiple meanings
             for meaning_data in entry.get("meanings", []):
                 part_of_speech = meaning_data.get("partOfSpeech", "unknown")
                 
+                # Synonyms and antonyms are listed per part of speech and per definition
+                synonyms = list(meaning_data.get("synonyms", []))
+                antonyms = list(meaning_data.get("antonyms", []))
+                
                 # Parse definitions
                 meanings = []
                 for def_idx, definition_data in enumerate(meaning_data.get("definitions", [])):
@@ -81,10 +91,12 @@ class EnglishFetcher(BaseFetcher):
                     
                     examples = [example] if example else []
                     
-                    # Add synonyms as additional context
-                    synonyms = definition_data.get("synonyms", [])
-                    if synonyms:
-                        meaning_text += f" (Synonyms: {', '.join(synonyms[:3])})"
+                    for synonym in definition_data.get("synonyms", []):
+                        if synonym not in synonyms:
+                            synonyms.append(synonym)
+                    for antonym in definition_data.get("antonyms", []):
+                        if antonym not in antonyms:
+                            antonyms.append(antonym)
                     
                     meaning = MeaningEntry(
                         description=meaning_text,
@@ -98,17 +110,59 @@ class EnglishFetcher(BaseFetcher):
                     sense = SenseEntry(
                         id=sense_id,
                         category=part_of_speech,
-                        meanings=meanings
+                        meanings=meanings,
+                        part_of_speech=normalize_part_of_speech(part_of_speech),
+                        etymology=entry.get("origin") or None,
+                        synonyms=synonyms if synonyms else None,
+                        antonyms=antonyms if antonyms else None
                     )
                     senses.append(sense)
         
+        # Attribution: the API names the Wiktionary page and its license
+        source_urls = data[0].get("sourceUrls") or [None]
+        license_info = data[0].get("license") or {}
         return WordEntry(
             word=word,
             language="English",
             senses=senses,
-            source=self.source_name
+            source=self.source_name,
+            pronunciations=pronunciations if pronunciations else None,
+            source_url=source_urls[0],
+            license=license_info.get("name")
         )
     
+    def _parse_phonetics(self, entry: dict) -> List[PronunciationEntry]:
+        """
+        Parse IPA transcriptions and audio from an API entry.
+        
+        The API lists them under "phonetics" as {text, audio}; the variant is
+        only encoded in the audio file name (e.g. "hello-uk.mp3").
+        
+        Args:
+            entry: One entry from the API response
+            
+        Returns:
+            List of PronunciationEntry objects
+        """
+        pronunciations = []
+        for phonetic in entry.get("phonetics", []):
+            text = phonetic.get("text")
+            if not text:
+                continue
+            audio = phonetic.get("audio") or None
+            variant = None
+            if audio:
+                for suffix in ("uk", "us", "au"):
+                    if audio.endswith(f"-{suffix}.mp3"):
+                        variant = suffix
+                        break
+            pronunciations.append(PronunciationEntry(text=text, notation="ipa", audio=audio, variant=variant))
+        
+        # Older entries only carry a single top-level transcription
+        if not pronunciations and entry.get("phonetic"):
+            pronunciations.append(PronunciationEntry(text=entry["phonetic"], notation="ipa"))
+        return pronunciations
+    
     def is_available(self) -> bool:
         """
         Check if Free Dictionary API is available.
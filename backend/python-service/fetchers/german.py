@@ -5,9 +5,14 @@ Provides German word definitions and basic grammatical information.
 Note: For production use, consider implementing duden.de scraping for more comprehensive data.
 This implementation uses Wiktionary as a starting point since it has a public API.
 """
-from typing import Optional
+from typing import Optional, List
+from urllib.parse import quote
+import re
 import requests
-from .base import BaseFetcher, WordEntry, SenseEntry, MeaningEntry
+from .base import BaseFetcher, WordEntry, SenseEntry, MeaningEntry, PronunciationEntry, normalize_part_of_speech
+
+# Wiktionary's text is published under CC BY-SA 4.0
+WIKTIONARY_LICENSE = "CC BY-SA 4.0"
 
 
 class GermanFetcher(BaseFetcher):
@@ -29,6 +34,7 @@ class GermanFetcher(BaseFetcher):
         """Initialize German fetcher."""
         super().__init__(language_code="de", source_name="Wiktionary (German)")
         self.api_url = "https://de.wiktionary.org/api/rest_v1/page/definition"
+        self.action_api_url = "https://de.wiktionary.org/w/api.php"
     
     def fetch_word(self, word: str) -> Optional[WordEntry]:
         """
@@ -42,7 +48,7 @@ class GermanFetcher(BaseFetcher):
         """
         try:
             # Wiktionary API endpoint for definitions
-            url = f"{self.api_url}/{word}"
+            url = f"{self.api_url}/{quote(word, safe='')}"
             
             self.logger.info(f"Fetching German word '{word}' from {self.source_name}")
             response = requests.get(url, timeout=10)
@@ -57,7 +63,20 @@ class GermanFetcher(BaseFetcher):
             if not data:
                 return None
             
-            return self._parse_response(data, word)
+            entry = self._parse_response(data, word)
+            if entry.source != "fallback":
+                # The definition API lacks pronunciation and origin; read them from the wikitext
+                wikitext = self._fetch_wikitext(word)
+                pronunciations = self._parse_pronunciations(wikitext)
+                entry.pronunciations = pronunciations if pronunciations else None
+                etymology = self._parse_etymology(wikitext)
+                synonyms = self._parse_related(wikitext, "Synonyme")
+                antonyms = self._parse_related(wikitext, "Gegenwörter")
+                for sense in entry.senses:
+                    sense.etymology = etymology
+                    sense.synonyms = synonyms or None
+                    sense.antonyms = antonyms or None
+            return entry
             
         except requests.exceptions.RequestException as e:
             self.logger.error(f"Network error fetching word '{word}': {e}")
@@ -95,7 +114,6 @@ class GermanFetcher(BaseFetcher):
                     definition_text = definition_item.get("definition", "")
                     
                     # Clean HTML tags from definition
-                    import re
                     definition_text = re.sub(r'<[^>]+>', '', definition_text)
                     
                     # Extract examples if present
@@ -113,7 +131,8 @@ class GermanFetcher(BaseFetcher):
                     sense = SenseEntry(
                         id=f"{sense_idx}_{part_of_speech}",
                         category=part_of_speech,
-                        meanings=meanings
+                        meanings=meanings,
+                        part_of_speech=normalize_part_of_speech(part_of_speech)
                     )
                     senses.append(sense)
         
@@ -125,9 +144,115 @@ class GermanFetcher(BaseFetcher):
             word=word,
             language="German",
             senses=senses,
-            source=self.source_name
+            source=self.source_name,
+            source_url=f"https://de.wiktionary.org/wiki/{quote(word)}",
+            license=WIKTIONARY_LICENSE
         )
     
+    def _fetch_wikitext(self, word: str) -> str:
+        """
+        Fetch the page's wikitext from the action API.
+        
+        Failures are logged and yield an empty string, so the entry is still
+        returned without pronunciation or etymology.
+        
+        Args:
+            word: The word being fetched
+            
+        Returns:
+            The wikitext, or "" if it couldn't be fetched
+        """
+        try:
+            params = {"action": "parse", "page": word, "prop": "wikitext", "format": "json", "formatversion": "2"}
+            response = requests.get(self.action_api_url, params=params, timeout=10)
+            response.raise_for_status()
+            return response.json().get("parse", {}).get("wikitext", "")
+        except (requests.exceptions.RequestException, ValueError) as e:
+            self.logger.warning(f"Could not fetch wikitext for '{word}': {e}")
+            return ""
+    
+    def _parse_pronunciations(self, wikitext: str) -> List[PronunciationEntry]:
+        """
+        Parse IPA transcriptions from the {{Lautschrift|...}} templates of the
+        Aussprache section.
+        
+        Args:
+            wikitext: The page's wikitext
+            
+        Returns:
+            List of PronunciationEntry objects
+        """
+        pronunciations = []
+        for ipa in re.findall(r'\{\{Lautschrift\|([^}|]+)\}\}', wikitext):
+            ipa = ipa.strip()
+            if ipa and ipa != "…" and all(p.text != ipa for p in pronunciations):
+                pronunciations.append(PronunciationEntry(text=ipa, notation="ipa"))
+        return pronunciations
+    
+    def _parse_etymology(self, wikitext: str) -> Optional[str]:
+        """
+        Parse the Herkunft section into plain text.
+        
+        The section is a {{Herkunft}} line followed by ":[1] ..." items;
+        links and the common templates are reduced to their text.
+        
+        Args:
+            wikitext: The page's wikitext
+            
+        Returns:
+            The etymology, or None if the page has none
+        """
+        lines = self._section_lines(wikitext, "Herkunft")
+        if not lines:
+            return None
+        
+        items = []
+        for text in lines:
+            text = re.sub(r'\[\[(?:[^|\]]*\|)?([^\]]*)\]\]', r'\1', text)  # [[target|label]] -> label
+            text = re.sub(r'\{\{(?:Ü|Üt|L)\|[^|}]*\|([^|}]*)[^}]*\}\}', r'\1', text)  # {{Ü|la|casa}} -> casa
+            text = re.sub(r'\{\{[^}]*\}\}', '', text)
+            text = re.sub(r'<ref[^>]*/>|<ref[^>]*>.*?</ref>|<[^>]+>', '', text)
+            text = text.replace("'''", "").replace("''", "").strip()
+            if text and text != "…":
+                items.append(text)
+        return "; ".join(items) if items else None
+    
+    def _parse_related(self, wikitext: str, section: str) -> List[str]:
+        """
+        Parse the linked words of a Synonyme or Gegenwörter section.
+        
+        Args:
+            wikitext: The page's wikitext
+            section: The section template name
+            
+        Returns:
+            The words in page order, without duplicates
+        """
+        words = []
+        for line in self._section_lines(wikitext, section):
+            # Link targets are the lemmas; labels may be inflected
+            for target in re.findall(r'\[\[([^|\]]+)', line):
+                related = target.strip()
+                if related and related not in words:
+                    words.append(related)
+        return words
+    
+    def _section_lines(self, wikitext: str, section: str) -> List[str]:
+        """
+        Return the items of a {{section}} block with their ":[1]" markers removed.
+        
+        Args:
+            wikitext: The page's wikitext
+            section: The section template name, e.g. "Herkunft"
+            
+        Returns:
+            The item lines, or [] if the page has no such section
+        """
+        match = re.search(r'\{\{' + re.escape(section) + r'\}\}\n((?::.*\n?)+)', wikitext)
+        if not match:
+            return []
+        return [re.sub(r'^:\s*(\[[^\]]*\])?\s*', '', line) for line in match.group(1).splitlines()]
+    
     def _create_fallback_entry(self, word: str) -> WordEntry:
         """
         Create a basic fallback entry when API fails or returns no data.
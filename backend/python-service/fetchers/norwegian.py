@@ -4,7 +4,7 @@ Delegates to the existing Go scraper for Norwegian words.
 """
 from typing import Optional
 import requests
-from .base import BaseFetcher, WordEntry, SenseEntry, MeaningEntry, ExpressionEntry, WordFormEntry
+from .base import BaseFetcher, WordEntry, SenseEntry, MeaningEntry, ExpressionEntry, WordFormEntry, PronunciationEntry, ReferenceEntry
 
 
 class NorwegianFetcher(BaseFetcher):
@@ -35,7 +35,8 @@ class NorwegianFetcher(BaseFetcher):
         """
         try:
             url = f"{self.go_service_url}/api/scrape"
-            params = {"word": word}
+            # Name the dictionary; without one the Go service would detect it
+            params = {"word": word, "language": "no-bm"}
             
             self.logger.info(f"Fetching Norwegian word '{word}' from {self.source_name}")
             response = requests.get(url, params=params, timeout=30)
@@ -99,28 +100,70 @@ class NorwegianFetcher(BaseFetcher):
                     definiteness=form_data.get("definiteness"),
                     gender=form_data.get("gender"),
                     degree=form_data.get("degree"),
-                    tense=form_data.get("tense")
+                    tense=form_data.get("tense"),
+                    person=form_data.get("person"),
+                    mood=form_data.get("mood"),
+                    voice=form_data.get("voice"),
+                    case=form_data.get("case")
                 )
                 word_forms.append(word_form)
             
+            references = [
+                ReferenceEntry(
+                    word=ref.get("word", ""),
+                    relation=ref.get("relation", "related"),
+                    article_id=ref.get("article_id")
+                )
+                for ref in sense_data.get("references") or []
+                if ref.get("word")
+            ]
+            
             # Create sense entry
+            pronunciations = self._parse_pronunciations(sense_data)
             sense = SenseEntry(
                 id=sense_data.get("id", ""),
                 category=sense_data.get("category", ""),
                 meanings=meanings,
+                part_of_speech=sense_data.get("part_of_speech") or None,
                 gender=sense_data.get("gender"),
                 article=sense_data.get("article"),
                 expressions=expressions if expressions else None,
-                word_forms=word_forms if word_forms else None
+                word_forms=word_forms if word_forms else None,
+                pronunciations=pronunciations if pronunciations else None,
+                etymology=sense_data.get("etymology") or None,
+                synonyms=sense_data.get("synonyms") or None,
+                antonyms=sense_data.get("antonyms") or None,
+                references=references if references else None
             )
             senses.append(sense)
         
-        return WordEntry(
+        pronunciations = self._parse_pronunciations(data)
+        entry = WordEntry(
             word=word,
             language="Norwegian",
             senses=senses,
-            source=self.source_name
+            source=self.source_name,
+            pronunciations=pronunciations if pronunciations else None,
+            source_url=data.get("source_url"),
+            license=data.get("license")
         )
+        if data.get("scraped_at"):
+            # Keep the Go service's time; a cached entry may be older than this call
+            entry.scraped_at = data["scraped_at"]
+        return entry
+    
+    def _parse_pronunciations(self, data: dict) -> list:
+        """Parse the pronunciations of an entry or sense from the Go service."""
+        return [
+            PronunciationEntry(
+                text=p.get("text", ""),
+                notation=p.get("notation"),
+                audio=p.get("audio"),
+                variant=p.get("variant")
+            )
+            for p in data.get("pronunciations") or []
+            if p.get("text")
+        ]
     
     def is_available(self) -> bool:
         """
@@ -0,0 +1,145 @@
+"""
+gRPC server for the WordIngest service (see backend/proto/wordingest.proto;
+the entries themselves are defined in backend/proto/wordentry.proto).
+
+Runs next to the FastAPI app and stores entries through the same code path
+as the HTTP ingest endpoints. Calls must carry the shared service key in
+the "x-service-key" metadata. Client deadlines are honoured: if the caller
+has given up by the time a batch is ready to commit, it is rolled back.
+"""
+import hmac
+import os
+from concurrent import futures
+from typing import Dict, List, Optional
+
+import grpc
+import mysql.connector
+
+import wordentry_pb2
+import wordingest_pb2
+import wordingest_pb2_grpc
+from db_utils import logger
+from routes.ingest import (
+    MAX_BULK_ENTRIES, SERVICE_API_KEY, IngestCancelled,
+    CefrLevel, Frequency, MeaningEntry, PronunciationEntry, SenseEntry, WordEntry, WordForm, ingest_entries,
+)
+
+STATUSES = {
+    "created": wordingest_pb2.INGEST_STATUS_CREATED,
+    "exists": wordingest_pb2.INGEST_STATUS_EXISTS,
+    "rejected": wordingest_pb2.INGEST_STATUS_REJECTED,
+}
+
+
+def _pronunciations_from_proto(pronunciations) -> List[PronunciationEntry]:
+    return [
+        PronunciationEntry(
+            text=p.text,
+            notation=p.notation or None,
+            audio=p.audio or None,
+            variant=p.variant or None,
+        )
+        for p in pronunciations
+    ]
+
+
+def _from_proto(entry: wordentry_pb2.WordEntry) -> WordEntry:
+    return WordEntry(
+        word=entry.word,
+        language=entry.language or None,
+        idempotency_key=entry.idempotency_key or None,
+        pronunciations=_pronunciations_from_proto(entry.pronunciations),
+        source=entry.source or None,
+        source_url=entry.source_url or None,
+        scraped_at=entry.scraped_at or None,
+        license=entry.license or None,
+        frequency=Frequency(rank=entry.frequency.rank, band=entry.frequency.band) if entry.HasField("frequency") else None,
+        cefr=CefrLevel(level=entry.cefr.level, basis=entry.cefr.basis) if entry.HasField("cefr") else None,
+        senses=[
+            SenseEntry(
+                id=sense.id,
+                category=sense.category,
+                part_of_speech=sense.part_of_speech or None,
+                meanings=[
+                    MeaningEntry(description=m.description, examples=list(m.examples))
+                    for m in sense.meanings
+                ],
+                pronunciations=_pronunciations_from_proto(sense.pronunciations),
+                etymology=sense.etymology or None,
+                word_forms=[WordForm(label=f.label, forms=list(f.forms)) for f in sense.word_forms],
+            )
+            for sense in entry.senses
+        ],
+    )
+
+
+def _to_proto(result: Dict) -> wordingest_pb2.IngestResponse:
+    return wordingest_pb2.IngestResponse(
+        word=result["word"],
+        language=result.get("language") or "",
+        status=STATUSES[result["status"]],
+        error=result.get("error", ""),
+        word_id=result.get("word_id", 0),
+    )
+
+
+class WordIngestServicer(wordingest_pb2_grpc.WordIngestServicer):
+    """Stores entries delivered by the Go service."""
+
+    def _authorize(self, context) -> Optional[str]:
+        metadata = dict(context.invocation_metadata())
+        key = metadata.get("x-service-key")
+        if not SERVICE_API_KEY or not key or not hmac.compare_digest(key, SERVICE_API_KEY):
+            context.abort(grpc.StatusCode.UNAUTHENTICATED, "Invalid service key")
+        return metadata.get("x-request-id")
+
+    def _ingest(self, entries, request_id, context) -> Dict:
+        try:
+            return ingest_entries(entries, request_id, cancelled=lambda: not context.is_active())
+        except IngestCancelled:
+            logger.info(f"[request_id={request_id}] Ingest cancelled by client, rolled back {len(entries)} entries")
+            context.abort(grpc.StatusCode.DEADLINE_EXCEEDED, "Client deadline passed before commit")
+        except mysql.connector.Error as e:
+            logger.error(f"Database error ingesting {len(entries)} entries over gRPC: {e}")
+            context.abort(grpc.StatusCode.UNAVAILABLE, "Database error occurred")
+
+    def Ingest(self, request, context):
+        request_id = self._authorize(context)
+        summary = self._ingest([_from_proto(request.entry)], request_id, context)
+        return _to_proto(summary["results"][0])
+
+    def IngestStream(self, request_iterator, context):
+        request_id = self._authorize(context)
+        entries = []
+        for request in request_iterator:
+            entries.append(_from_proto(request.entry))
+            if len(entries) > MAX_BULK_ENTRIES:
+                context.abort(grpc.StatusCode.INVALID_ARGUMENT, f"At most {MAX_BULK_ENTRIES} entries per stream")
+
+        summary = self._ingest(entries, request_id, context)
+        return wordingest_pb2.IngestSummary(
+            results=[_to_proto(r) for r in summary["results"]],
+            created=summary["created"],
+            exists=summary["exists"],
+            rejected=summary["rejected"],
+        )
+
+
+def start_grpc_server() -> Optional[grpc.Server]:
+    """
+    Start the WordIngest server on GRPC_PORT (default 50051) in background
+    threads. Set GRPC_PORT to an empty string to disable it.
+
+    Returns:
+        grpc.Server: The running server, or None if disabled
+    """
+    port = os.getenv("GRPC_PORT", "50051")
+    if not port:
+        return None
+
+    server = grpc.server(futures.ThreadPoolExecutor(max_workers=4))
+    wordingest_pb2_grpc.add_WordIngestServicer_to_server(WordIngestServicer(), server)
+    server.add_insecure_port(f"[::]:{port}")
+    server.start()
+    logger.info(f"WordIngest gRPC server listening on port {port}")
+    return server
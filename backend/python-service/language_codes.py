@@ -0,0 +1,55 @@
+"""
+Language code normalization, matching the Go service's langcode package.
+
+Requests may name a language by BCP-47 tag ("nb-NO"), ISO 639 code ("nob")
+or name ("norwegian bokmål"). Normalize once where input arrives: the
+canonical code ("no-bm") is what the Go service scrapes, and the database
+code ("no") is what the languages table and the fetchers use.
+"""
+from typing import Optional
+
+# Canonical codes -> codes in the languages table
+DB_CODES = {
+    "no-bm": "no",
+    "no-nn": "no",
+    "en": "en",
+    "es": "es",
+    "de": "de",
+}
+
+# Lowercased codes and names -> canonical codes. Plain "no" and "nor" mean
+# Bokmål, the standard most learners use.
+ALIASES = {
+    "no-bm": "no-bm", "nb": "no-bm", "nob": "no-bm", "no": "no-bm", "nor": "no-bm",
+    "no-nb": "no-bm", "bokmal": "no-bm", "bokmål": "no-bm",
+    "norwegian": "no-bm", "norwegian bokmal": "no-bm", "norwegian bokmål": "no-bm",
+    "norsk": "no-bm", "norsk bokmål": "no-bm",
+    "no-nn": "no-nn", "nn": "no-nn", "nno": "no-nn", "nynorsk": "no-nn",
+    "norwegian nynorsk": "no-nn", "norsk nynorsk": "no-nn",
+    "en": "en", "eng": "en", "english": "en",
+    "es": "es", "spa": "es", "spanish": "es", "español": "es", "espanol": "es",
+    "de": "de", "deu": "de", "ger": "de", "german": "de", "deutsch": "de",
+}
+
+
+def canonical_language(code: Optional[str]) -> Optional[str]:
+    """
+    Map a language code, BCP-47 tag or name to its canonical code.
+
+    Case, surrounding spaces and "_" for "-" don't matter, and region and
+    other subtags are dropped ("nb-NO", "en_GB").
+
+    Returns:
+        The canonical code, or None for an unsupported language
+    """
+    code = (code or "").strip().lower().replace("_", "-")
+    if code in ALIASES:
+        return ALIASES[code]
+    primary, _, rest = code.partition("-")
+    return ALIASES.get(primary) if rest else None
+
+
+def db_language_code(code: Optional[str]) -> Optional[str]:
+    """The languages table's code for a language, or None if unsupported."""
+    canonical = canonical_language(code)
+    return DB_CODES.get(canonical) if canonical else None
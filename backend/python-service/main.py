@@ -1,11 +1,17 @@
 import os
+import uuid
 import mysql.connector
 from dotenv import load_dotenv
-from fastapi import FastAPI
+from fastapi import FastAPI, Request
 from fastapi.middleware.cors import CORSMiddleware
 from database import get_connection
-from routes import auth, words, root, languages, word_types, review, fetch
+from routes import auth, oauth, admin, audit, ingest, words, root, languages, word_types, grammar_topics, decks, review, fetch
 import fetchers
+import spellcheck
+import seed_corpus
+import grpc_server
+import queue_consumer
+from db_utils import logger
 
 # Load env variables first
 load_dotenv()
@@ -30,16 +36,50 @@ app.add_middleware(
 )
 print("CORS middleware added ✅")
 
+# Request IDs: accept the one forwarded by the Go service (or generate one),
+# log it, and echo it back so calls can be traced across both services
+@app.middleware("http")
+async def request_id_middleware(request: Request, call_next):
+    request_id = request.headers.get("X-Request-ID") or uuid.uuid4().hex[:16]
+    request.state.request_id = request_id
+    response = await call_next(request)
+    response.headers["X-Request-ID"] = request_id
+    logger.info(f"[request_id={request_id}] {request.method} {request.url.path} → {response.status_code}")
+    return response
+
 # Initialize dictionary fetchers
 fetchers.initialize_fetchers()
 print("Dictionary fetchers initialized ✅")
 
+# Initialize spell checkers for free-text exercises
+spellcheck.initialize_spell_checkers()
+print("Spell checkers initialized ✅")
+
+# WordIngest gRPC endpoint for the Go service (alongside the HTTP ingest routes)
+grpc_server.start_grpc_server()
+print("gRPC ingest server started ✅")
+
+# Consume scraped entries published to NATS by the Go service (if NATS_URL is set)
+@app.on_event("startup")
+async def start_queue_consumer():
+    if queue_consumer.start_queue_consumer():
+        print("NATS queue consumer started ✅")
+
+# Give fresh installs a starter vocabulary (no-op once words exist)
+seed_corpus.seed_on_first_boot()
+
 # Routers
 app.include_router(root.router)
 app.include_router(words.router)
 app.include_router(auth.router)
+app.include_router(oauth.router)
+app.include_router(admin.router)
+app.include_router(audit.router)
+app.include_router(ingest.router)
 app.include_router(languages.router)      
 app.include_router(word_types.router)
+app.include_router(grammar_topics.router)
+app.include_router(decks.router)
 app.include_router(review.router)
 app.include_router(fetch.router)
 
@@ -0,0 +1,321 @@
+"""
+Rule-based practice generators for numbers, dates, and clock times.
+
+Each generator produces a practice card with a prompt (digits, an ISO date,
+or a 24-hour time) and the expected answer written out in the target
+language. Cards are mixed into review sessions with card_type "practice"
+so they can be shown alongside regular vocabulary cards.
+
+Supported languages use the same codes as the languages table: "no", "en", "de".
+"""
+import random
+import unicodedata
+from datetime import date, timedelta
+from typing import Callable, Dict, List, Optional
+
+
+PRACTICE_KINDS = ("number", "date", "time")
+
+# Most cards one request may generate
+MAX_PRACTICE_CARDS = 100
+
+
+# ---- Numbers ----
+
+NUMBER_WORDS = {
+    "no": {
+        "units": ["null", "en", "to", "tre", "fire", "fem", "seks", "sju", "åtte", "ni",
+                  "ti", "elleve", "tolv", "tretten", "fjorten", "femten", "seksten",
+                  "sytten", "atten", "nitten"],
+        "tens": ["", "", "tjue", "tretti", "førti", "femti", "seksti", "sytti", "åtti", "nitti"],
+    },
+    "en": {
+        "units": ["zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+                  "ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen",
+                  "seventeen", "eighteen", "nineteen"],
+        "tens": ["", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"],
+    },
+    "de": {
+        "units": ["null", "eins", "zwei", "drei", "vier", "fünf", "sechs", "sieben", "acht", "neun",
+                  "zehn", "elf", "zwölf", "dreizehn", "vierzehn", "fünfzehn", "sechzehn",
+                  "siebzehn", "achtzehn", "neunzehn"],
+        "tens": ["", "", "zwanzig", "dreißig", "vierzig", "fünfzig", "sechzig", "siebzig", "achtzig", "neunzig"],
+    },
+}
+
+
+def _below_hundred(n: int, language: str) -> str:
+    words = NUMBER_WORDS[language]
+    if n < 20:
+        return words["units"][n]
+
+    tens, unit = divmod(n, 10)
+    if unit == 0:
+        return words["tens"][tens]
+
+    if language == "en":
+        return f"{words['tens'][tens]}-{words['units'][unit]}"
+    if language == "de":
+        # German puts the unit first: 21 -> einundzwanzig
+        unit_word = "ein" if unit == 1 else words["units"][unit]
+        return f"{unit_word}und{words['tens'][tens]}"
+    # Norwegian (modern counting): 21 -> tjueen
+    return words["tens"][tens] + words["units"][unit]
+
+
+def number_to_words(n: int, language: str) -> str:
+    """
+    Spell out a number between 0 and 9999 in the target language.
+
+    Args:
+        n: The number to spell out
+        language: Language code ("no", "en", "de")
+
+    Returns:
+        str: The number written in words
+    """
+    if language not in NUMBER_WORDS:
+        raise ValueError(f"Unsupported language for number practice: {language}")
+    if n < 0 or n > 9999:
+        raise ValueError("Number practice supports values between 0 and 9999")
+
+    if n < 100:
+        return _below_hundred(n, language)
+
+    thousands, rest = divmod(n, 1000)
+    hundreds, remainder = divmod(rest, 100)
+    parts = []
+
+    if language == "de":
+        if thousands:
+            parts.append(("ein" if thousands == 1 else _below_hundred(thousands, language)) + "tausend")
+        if hundreds:
+            parts.append(("ein" if hundreds == 1 else _below_hundred(hundreds, language)) + "hundert")
+        if remainder:
+            parts.append("eins" if remainder == 1 else _below_hundred(remainder, language))
+        return "".join(parts)
+
+    if language == "en":
+        if thousands:
+            parts.append(f"{_below_hundred(thousands, language)} thousand")
+        if hundreds:
+            parts.append(f"{_below_hundred(hundreds, language)} hundred")
+        if remainder:
+            if parts:
+                parts.append("and")
+            parts.append(_below_hundred(remainder, language))
+        return " ".join(parts)
+
+    # Norwegian: "ett tusen to hundre og tretti"
+    if thousands:
+        parts.append("ett tusen" if thousands == 1 else f"{_below_hundred(thousands, language)} tusen")
+    if hundreds:
+        parts.append("ett hundre" if hundreds == 1 else f"{_below_hundred(hundreds, language)} hundre")
+    if remainder:
+        parts.append("og")
+        parts.append(_below_hundred(remainder, language))
+    return " ".join(parts)
+
+
+# ---- Dates ----
+
+MONTHS = {
+    "no": ["januar", "februar", "mars", "april", "mai", "juni", "juli",
+           "august", "september", "oktober", "november", "desember"],
+    "en": ["January", "February", "March", "April", "May", "June", "July",
+           "August", "September", "October", "November", "December"],
+    "de": ["Januar", "Februar", "März", "April", "Mai", "Juni", "Juli",
+           "August", "September", "Oktober", "November", "Dezember"],
+}
+
+WEEKDAYS = {
+    "no": ["mandag", "tirsdag", "onsdag", "torsdag", "fredag", "lørdag", "søndag"],
+    "en": ["Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"],
+    "de": ["Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag", "Sonntag"],
+}
+
+
+def format_date(d: date, language: str) -> str:
+    """
+    Write a date the way it is said in the target language, including weekday.
+
+    Args:
+        d: The date to format
+        language: Language code ("no", "en", "de")
+
+    Returns:
+        str: e.g. "torsdag 15. oktober 2026", "Thursday, 15 October 2026"
+    """
+    if language not in MONTHS:
+        raise ValueError(f"Unsupported language for date practice: {language}")
+
+    weekday = WEEKDAYS[language][d.weekday()]
+    month = MONTHS[language][d.month - 1]
+
+    if language == "en":
+        return f"{weekday}, {d.day} {month} {d.year}"
+    if language == "de":
+        return f"{weekday}, {d.day}. {month} {d.year}"
+    return f"{weekday} {d.day}. {month} {d.year}"
+
+
+# ---- Clock times ----
+
+def _hour_word(hour: int, language: str) -> str:
+    # Colloquial clock times use the 12-hour dial
+    h = hour % 12 or 12
+    if language == "de" and h == 1:
+        return "eins"
+    if language == "no" and h == 1:
+        return "ett"
+    return number_to_words(h, language)
+
+
+def time_to_words(hour: int, minute: int, language: str) -> str:
+    """
+    Write a clock time colloquially in the target language.
+    Minutes are expected in five-minute steps.
+
+    Args:
+        hour: Hour (0-23)
+        minute: Minute (0-55, multiple of 5)
+        language: Language code ("no", "en", "de")
+
+    Returns:
+        str: e.g. "kvart over to", "half past two", "halb drei"
+    """
+    if language not in NUMBER_WORDS:
+        raise ValueError(f"Unsupported language for time practice: {language}")
+    if minute % 5 != 0:
+        raise ValueError("Time practice uses five-minute steps")
+
+    this_hour = _hour_word(hour, language)
+    next_hour = _hour_word(hour + 1, language)
+    n = lambda m: number_to_words(m, language)
+
+    if language == "en":
+        if minute == 0:
+            return f"{this_hour} o'clock"
+        if minute == 15:
+            return f"quarter past {this_hour}"
+        if minute == 30:
+            return f"half past {this_hour}"
+        if minute == 45:
+            return f"quarter to {next_hour}"
+        if minute < 30:
+            return f"{n(minute)} past {this_hour}"
+        return f"{n(60 - minute)} to {next_hour}"
+
+    if language == "de":
+        if minute == 0:
+            return "ein Uhr" if this_hour == "eins" else f"{this_hour} Uhr"
+        if minute == 15:
+            return f"Viertel nach {this_hour}"
+        if minute == 30:
+            return f"halb {next_hour}"
+        if minute == 45:
+            return f"Viertel vor {next_hour}"
+        if minute < 30:
+            return f"{n(minute)} nach {this_hour}"
+        return f"{n(60 - minute)} vor {next_hour}"
+
+    # Norwegian counts around the half hour: 2:20 -> "ti på halv tre"
+    if minute == 0:
+        return f"klokka {this_hour}"
+    if minute == 15:
+        return f"kvart over {this_hour}"
+    if minute == 30:
+        return f"halv {next_hour}"
+    if minute == 45:
+        return f"kvart på {next_hour}"
+    if minute < 20:
+        return f"{n(minute)} over {this_hour}"
+    if minute < 30:
+        return f"{n(30 - minute)} på halv {next_hour}"
+    if minute < 45:
+        return f"{n(minute - 30)} over halv {next_hour}"
+    return f"{n(60 - minute)} på {next_hour}"
+
+
+# ---- Card generation ----
+
+def _number_card(rng: random.Random, language: str) -> Dict:
+    # Bias towards smaller numbers, which come up far more often in practice
+    upper = rng.choice([20, 100, 1000, 9999])
+    n = rng.randint(0, upper)
+    return {"prompt": str(n), "answer": number_to_words(n, language)}
+
+
+def _date_card(rng: random.Random, language: str) -> Dict:
+    d = date.today() + timedelta(days=rng.randint(-365, 365))
+    return {"prompt": d.isoformat(), "answer": format_date(d, language)}
+
+
+def _time_card(rng: random.Random, language: str) -> Dict:
+    hour = rng.randint(0, 23)
+    minute = rng.randrange(0, 60, 5)
+    return {"prompt": f"{hour:02d}:{minute:02d}", "answer": time_to_words(hour, minute, language)}
+
+
+GENERATORS: Dict[str, Callable[[random.Random, str], Dict]] = {
+    "number": _number_card,
+    "date": _date_card,
+    "time": _time_card,
+}
+
+
+def generate_practice_cards(
+    language: str,
+    count: int = 5,
+    kinds: Optional[List[str]] = None,
+    seed: Optional[int] = None
+) -> List[Dict]:
+    """
+    Generate practice cards for the review session.
+
+    Args:
+        language: Language code ("no", "en", "de")
+        count: Number of cards to generate
+        kinds: Which practice kinds to draw from (defaults to all)
+        seed: Optional seed for reproducible sessions
+
+    Returns:
+        List[Dict]: Cards with card_type, kind, language, prompt and answer
+    """
+    if language not in NUMBER_WORDS:
+        raise ValueError(f"Unsupported language for practice: {language}")
+    if not 0 <= count <= MAX_PRACTICE_CARDS:
+        raise ValueError(f"count must be between 0 and {MAX_PRACTICE_CARDS}")
+
+    kinds = kinds or list(PRACTICE_KINDS)
+    for kind in kinds:
+        if kind not in GENERATORS:
+            raise ValueError(f"Unknown practice kind: {kind}")
+
+    rng = random.Random(seed)
+    cards = []
+    for _ in range(count):
+        kind = rng.choice(kinds)
+        card = GENERATORS[kind](rng, language)
+        cards.append({
+            "card_type": "practice",
+            "kind": kind,
+            "language": language,
+            **card,
+        })
+    return cards
+
+
+def _normalize_answer(text: str) -> str:
+    text = unicodedata.normalize("NFC", text).lower().strip()
+    for ch in ",.-":
+        text = text.replace(ch, " ")
+    return " ".join(text.split())
+
+
+def check_practice_answer(expected: str, given: str) -> bool:
+    """
+    Compare a user's answer with the expected one, ignoring case,
+    punctuation and spacing differences ("twenty-one" == "twenty one").
+    """
+    return _normalize_answer(expected) == _normalize_answer(given)
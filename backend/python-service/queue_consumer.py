@@ -0,0 +1,92 @@
+"""
+NATS JetStream consumer for scraped entries.
+
+The Go service publishes each scraped WordEntry to the WORDS stream on
+"words.scraped.<language>". This consumer pulls them with a durable
+consumer, so entries published while the Python service was offline are
+picked up when it comes back, and stores them through the same code path
+as the HTTP and gRPC ingest endpoints.
+
+Enabled by setting NATS_URL.
+"""
+import asyncio
+import os
+from typing import Optional
+
+import mysql.connector
+from pydantic import ValidationError
+
+from db_utils import logger
+from routes.ingest import WordEntry, ingest_entries
+
+STREAM_NAME = "WORDS"
+SCRAPED_SUBJECTS = "words.scraped.>"
+DURABLE_NAME = os.getenv("NATS_DURABLE_NAME", "python-ingest")
+FETCH_BATCH = 50
+
+
+async def _consume(nats_url: str) -> None:
+    import nats
+    from nats.errors import TimeoutError as NatsTimeoutError
+
+    nc = await nats.connect(nats_url, name="vocabulary-python-service", max_reconnect_attempts=-1)
+    js = nc.jetstream()
+    subscription = await js.pull_subscribe(SCRAPED_SUBJECTS, durable=DURABLE_NAME, stream=STREAM_NAME)
+    logger.info(f"Consuming {SCRAPED_SUBJECTS} from NATS as '{DURABLE_NAME}'")
+
+    while True:
+        try:
+            messages = await subscription.fetch(FETCH_BATCH, timeout=5)
+        except NatsTimeoutError:
+            continue
+
+        entries, valid = [], []
+        for msg in messages:
+            try:
+                entries.append(WordEntry.parse_raw(msg.data))
+                valid.append(msg)
+            except ValidationError as e:
+                # Malformed messages will never parse; drop them instead of redelivering forever
+                logger.error(f"Dropping malformed entry on {msg.subject}: {e}")
+                await msg.term()
+
+        if not entries:
+            continue
+        request_id = valid[0].headers.get("X-Request-ID") if valid[0].headers else None
+        try:
+            # The database calls block, so keep them off the event loop
+            await asyncio.to_thread(ingest_entries, entries, request_id)
+        except mysql.connector.Error as e:
+            logger.error(f"Database error ingesting {len(entries)} queued entries, will redeliver: {e}")
+            for msg in valid:
+                await msg.nak(delay=30)
+            continue
+
+        for msg in valid:
+            await msg.ack()
+
+
+def start_queue_consumer() -> Optional[asyncio.Task]:
+    """
+    Start the consumer on the running event loop if NATS_URL is set.
+    Must be called from within the loop (e.g. a FastAPI startup handler).
+
+    Returns:
+        asyncio.Task: The consumer task, or None if disabled
+    """
+    nats_url = os.getenv("NATS_URL")
+    if not nats_url:
+        return None
+
+    async def run():
+        # Keep retrying: NATS may start after us, or restart underneath us
+        while True:
+            try:
+                await _consume(nats_url)
+            except asyncio.CancelledError:
+                raise
+            except Exception as e:
+                logger.error(f"NATS consumer stopped: {e}; restarting in 10s")
+                await asyncio.sleep(10)
+
+    return asyncio.create_task(run())
@@ -0,0 +1,461 @@
+"""
+Admin routes for user management, the audit log, corpus seeding, the
+data quality report and purging stored words.
+All endpoints require a token with the "admin" role.
+"""
+from fastapi import APIRouter, HTTPException, Depends, Request
+from pydantic import BaseModel, validator
+from typing import List, Optional
+from datetime import datetime
+from db_utils import get_db_cursor, logger
+from auth_utils import require_role, ROLES
+from audit_utils import record_audit
+from language_codes import DB_CODES, db_language_code
+import seed_corpus
+import mysql.connector
+import hashlib
+import json
+
+router = APIRouter(prefix="/admin", dependencies=[Depends(require_role("admin"))])
+
+# Text mangled on the way in: UTF-8 read as Latin-1 ("Ã¸", "â€™"),
+# replacement characters, HTML entities or tags left in, control characters
+SUSPICIOUS_TEXT = "Ã|Â|â€|\ufffd|&(amp|quot|apos|lt|gt|nbsp|#[0-9]+);|</?[a-z]+[ />]|[[:cntrl:]]"
+
+# Word types whose entries should come with inflection tables
+INFLECTED_WORDTYPES = ("noun", "verb", "adjective")
+
+# Data quality problems -> (description, joins, condition, condition parameters)
+QUALITY_CHECKS = {
+    "missing_meanings": (
+        "Words stored without any meaning",
+        "",
+        "NOT EXISTS (SELECT 1 FROM meanings m WHERE m.word_id = w.id)",
+        (),
+    ),
+    "empty_inflections": (
+        "Nouns, verbs and adjectives scraped without inflections (words stored before they were counted are skipped)",
+        "JOIN word_types wt ON wt.id = w.wordtype",
+        "w.inflection_count = 0 AND wt.wordtype IN (%s, %s, %s)",
+        INFLECTED_WORDTYPES,
+    ),
+    "unparsed_categories": (
+        "Words whose category didn't map to a word type",
+        "",
+        "w.wordtype IS NULL",
+        (),
+    ),
+    "suspicious_characters": (
+        "Words or meanings with mojibake, HTML remnants or control characters",
+        "",
+        "(REGEXP_LIKE(w.word, %s, 'c') OR EXISTS ("
+        "SELECT 1 FROM meanings m WHERE m.word_id = w.id AND REGEXP_LIKE(m.definition, %s, 'c')))",
+        (SUSPICIOUS_TEXT, SUSPICIOUS_TEXT),
+    ),
+}
+
+
+class RoleUpdate(BaseModel):
+    """Model for changing a user's role."""
+    role: str
+    
+    @validator('role')
+    def validate_role(cls, v):
+        if v not in ROLES:
+            raise ValueError(f'Role must be one of: {", ".join(ROLES)}')
+        return v
+
+
+class PurgeRequest(BaseModel):
+    """Which stored words to delete; every given filter must match."""
+    language: Optional[str] = None
+    # Name of the source the words were scraped from, e.g. "ordbokene"
+    source: Optional[str] = None
+    # Version of that source's parser, as in the Go service's source profiles
+    source_version: Optional[str] = None
+    # Glob over the word: "*" for any run of characters, "?" for one
+    word_pattern: Optional[str] = None
+    scraped_after: Optional[datetime] = None
+    scraped_before: Optional[datetime] = None
+    # Count and sample the matching words without deleting them
+    dry_run: bool = False
+
+
+# Stored words purged per DELETE statement
+PURGE_CHUNK = 500
+
+
+def _idempotency_keys(word: str, code: str, source_version: str) -> set:
+    """
+    The idempotency keys the Go service gives the word in each canonical
+    language stored under the database code, for the source version
+    (matching models.IdempotencyKey).
+    """
+    normalized = word.strip().lower()
+    return {
+        hashlib.sha256(f"{normalized}\0{canonical}\0{source_version}".encode()).hexdigest()
+        for canonical, db_code in DB_CODES.items()
+        if db_code == code
+    }
+
+
+def _like_pattern(pattern: str) -> str:
+    """A LIKE pattern for a "*"/"?" glob, with LIKE's own wildcards escaped."""
+    escaped = pattern.replace("\\", "\\\\").replace("%", "\\%").replace("_", "\\_")
+    return escaped.replace("*", "%").replace("?", "_")
+
+
+class SeedRequest(BaseModel):
+    """Model for loading the starter corpus."""
+    languages: Optional[List[str]] = None
+
+
+@router.get("/users")
+def list_users(limit: int = 50, offset: int = 0):
+    """
+    List users with their roles.
+    
+    Args:
+        limit: Maximum number of users to return
+        offset: Number of users to skip (for pagination)
+        
+    Returns:
+        dict: Users without password hashes
+    """
+    try:
+        with get_db_cursor(commit=False) as (db, cursor):
+            cursor.execute("""
+                SELECT id, email, type, role, created_at
+                FROM users
+                ORDER BY id
+                LIMIT %s OFFSET %s
+            """, (limit, offset))
+            users = cursor.fetchall()
+            return {"users": users, "count": len(users)}
+            
+    except mysql.connector.Error as e:
+        logger.error(f"Database error listing users: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
+
+
+@router.put("/users/{user_id}/role")
+def update_user_role(
+    user_id: int,
+    data: RoleUpdate,
+    request: Request,
+    admin: dict = Depends(require_role("admin"))
+):
+    """
+    Change a user's role. Takes effect the next time the user logs in.
+    
+    Args:
+        user_id: ID of the user
+        data: New role
+        
+    Returns:
+        dict: Success message
+    """
+    try:
+        with get_db_cursor() as (db, cursor):
+            cursor.execute("UPDATE users SET role = %s WHERE id = %s", (data.role, user_id))
+            if cursor.rowcount == 0:
+                cursor.execute("SELECT id FROM users WHERE id = %s", (user_id,))
+                if not cursor.fetchone():
+                    raise HTTPException(status_code=404, detail="User not found")
+            
+            record_audit(
+                cursor, "update", "user", user_id, user=admin,
+                details={"role": data.role}, request_id=request.state.request_id
+            )
+            logger.info(f"Set role of user {user_id} to {data.role}")
+            return {"message": "Role updated successfully", "user_id": user_id, "role": data.role}
+            
+    except HTTPException:
+        raise
+    except mysql.connector.Error as e:
+        logger.error(f"Database error updating role for user {user_id}: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
+
+
+@router.delete("/users/{user_id}")
+def delete_user(
+    user_id: int,
+    request: Request,
+    admin: dict = Depends(require_role("admin"))
+):
+    """
+    Delete a user and (through cascading keys) their progress and statistics.
+    
+    Args:
+        user_id: ID of the user
+        
+    Returns:
+        dict: Success message
+    """
+    try:
+        with get_db_cursor() as (db, cursor):
+            cursor.execute("DELETE FROM users WHERE id = %s", (user_id,))
+            if cursor.rowcount == 0:
+                raise HTTPException(status_code=404, detail="User not found")
+            
+            record_audit(cursor, "delete", "user", user_id, user=admin, request_id=request.state.request_id)
+            logger.info(f"Deleted user {user_id}")
+            return {"message": "User deleted successfully"}
+            
+    except HTTPException:
+        raise
+    except mysql.connector.Error as e:
+        logger.error(f"Database error deleting user {user_id}: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
+
+
+@router.get("/audit")
+def list_audit_log(
+    user_id: Optional[int] = None,
+    action: Optional[str] = None,
+    entity_type: Optional[str] = None,
+    entity_id: Optional[str] = None,
+    since: Optional[datetime] = None,
+    limit: int = 100,
+    offset: int = 0
+):
+    """
+    Query the audit log, newest first.
+    
+    Args:
+        user_id: Only actions by this user
+        action: Only this action ("scrape", "create", "update", "delete", ...)
+        entity_type: Only this entity type ("word", "user", ...)
+        entity_id: Only this entity
+        since: Only actions at or after this time
+        limit: Maximum number of records to return
+        offset: Number of records to skip (for pagination)
+        
+    Returns:
+        dict: Matching audit records
+    """
+    filters = []
+    params = []
+    for column, value in (
+        ("user_id", user_id),
+        ("action", action),
+        ("entity_type", entity_type),
+        ("entity_id", entity_id),
+    ):
+        if value is not None:
+            filters.append(f"{column} = %s")
+            params.append(value)
+    if since:
+        filters.append("created_at >= %s")
+        params.append(since)
+    
+    where = f"WHERE {' AND '.join(filters)}" if filters else ""
+    
+    try:
+        with get_db_cursor(commit=False) as (db, cursor):
+            cursor.execute(f"""
+                SELECT id, user_id, user_email, action, entity_type, entity_id,
+                       details, request_id, service, created_at
+                FROM audit_log
+                {where}
+                ORDER BY created_at DESC, id DESC
+                LIMIT %s OFFSET %s
+            """, (*params, limit, offset))
+            records = cursor.fetchall()
+            return {"records": records, "count": len(records), "limit": limit, "offset": offset}
+            
+    except mysql.connector.Error as e:
+        logger.error(f"Database error querying audit log: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
+
+
+@router.get("/quality")
+def quality_report(language: Optional[str] = None, limit: int = 20):
+    """
+    Summarize stored words with data quality problems, so maintainers can
+    target re-scrapes: missing meanings, empty inflections, unparsed
+    categories and suspicious characters.
+    
+    Args:
+        language: Only words in this language (code, tag or name)
+        limit: Maximum number of sample words per problem
+        
+    Returns:
+        dict: Per problem, a description, the number of words and samples
+    """
+    filters = []
+    params = []
+    if language is not None:
+        code = db_language_code(language)
+        if not code:
+            raise HTTPException(status_code=400, detail=f"Unsupported language: {language}")
+        filters.append("l.code = %s")
+        params.append(code)
+    limit = max(1, min(limit, 200))
+    
+    try:
+        with get_db_cursor(commit=False) as (db, cursor):
+            cursor.execute(f"""
+                SELECT COUNT(*) AS total
+                FROM words w
+                JOIN languages l ON l.id = w.language
+                {"WHERE " + " AND ".join(filters) if filters else ""}
+            """, params)
+            report = {"language": language, "total_words": cursor.fetchone()["total"], "problems": {}}
+            
+            for problem, (description, joins, condition, args) in QUALITY_CHECKS.items():
+                where = " AND ".join([condition, *filters])
+                cursor.execute(f"""
+                    SELECT COUNT(*) AS count
+                    FROM words w
+                    JOIN languages l ON l.id = w.language
+                    {joins}
+                    WHERE {where}
+                """, (*args, *params))
+                count = cursor.fetchone()["count"]
+                cursor.execute(f"""
+                    SELECT w.id, w.word, l.code AS language, w.source, w.source_url, w.scraped_at
+                    FROM words w
+                    JOIN languages l ON l.id = w.language
+                    {joins}
+                    WHERE {where}
+                    ORDER BY w.id
+                    LIMIT %s
+                """, (*args, *params, limit))
+                report["problems"][problem] = {
+                    "description": description,
+                    "count": count,
+                    "samples": cursor.fetchall(),
+                }
+            return report
+            
+    except mysql.connector.Error as e:
+        logger.error(f"Database error building quality report: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
+
+
+@router.post("/words/purge")
+def purge_words(
+    data: PurgeRequest,
+    request: Request,
+    admin: dict = Depends(require_role("admin"))
+):
+    """
+    Delete stored words matching a filter, with their meanings and (through
+    cascading keys) their progress, e.g. everything scraped by a parser
+    version that turned out to be buggy, so the words can be scraped again.
+    
+    Args:
+        data: The filter (at least one of language, source, source_version,
+            word_pattern, scraped_after and scraped_before) and dry_run
+        
+    Returns:
+        dict: Number of matching words, samples, and the number deleted
+    """
+    filters = []
+    params = []
+    if data.language is not None:
+        code = db_language_code(data.language)
+        if not code:
+            raise HTTPException(status_code=400, detail=f"Unsupported language: {data.language}")
+        filters.append("l.code = %s")
+        params.append(code)
+    if data.source is not None:
+        filters.append("w.source = %s")
+        params.append(data.source)
+    if data.word_pattern is not None:
+        filters.append("w.word LIKE %s")
+        params.append(_like_pattern(data.word_pattern))
+    if data.scraped_after is not None:
+        filters.append("w.scraped_at >= %s")
+        params.append(data.scraped_after)
+    if data.scraped_before is not None:
+        filters.append("w.scraped_at < %s")
+        params.append(data.scraped_before)
+    if data.source_version is not None:
+        # The version isn't stored, but it is part of the idempotency key
+        filters.append("w.idempotency_key IS NOT NULL")
+    if not filters:
+        raise HTTPException(status_code=400, detail="Give at least one filter")
+    
+    try:
+        with get_db_cursor(commit=not data.dry_run) as (db, cursor):
+            cursor.execute(f"""
+                SELECT w.id, w.word, l.code AS language, w.source, w.scraped_at, w.idempotency_key
+                FROM words w
+                JOIN languages l ON l.id = w.language
+                WHERE {" AND ".join(filters)}
+                ORDER BY w.id
+            """, params)
+            matches = cursor.fetchall()
+            if data.source_version is not None:
+                matches = [
+                    row for row in matches
+                    if row["idempotency_key"] in _idempotency_keys(row["word"], row["language"], data.source_version)
+                ]
+            
+            deleted = 0
+            if not data.dry_run:
+                ids = [row["id"] for row in matches]
+                for start in range(0, len(ids), PURGE_CHUNK):
+                    chunk = ids[start:start + PURGE_CHUNK]
+                    cursor.execute(
+                        f"DELETE FROM words WHERE id IN ({', '.join(['%s'] * len(chunk))})", chunk
+                    )
+                    deleted += cursor.rowcount
+                record_audit(
+                    cursor, "purge", "word", None, user=admin,
+                    details={**json.loads(data.json(exclude={"dry_run"}, exclude_none=True)), "deleted": deleted},
+                    request_id=request.state.request_id
+                )
+                logger.info(f"Purged {deleted} stored words matching {data.json(exclude={'dry_run'}, exclude_none=True)}")
+            
+            samples = [{k: v for k, v in row.items() if k != "idempotency_key"} for row in matches[:20]]
+            return {"matched": len(matches), "deleted": deleted, "samples": samples, "dry_run": data.dry_run}
+            
+    except mysql.connector.Error as e:
+        logger.error(f"Database error purging words: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
+
+
+@router.get("/seed")
+def list_seed_corpora():
+    """
+    List the bundled starter corpora and which of them are enabled.
+    
+    Returns:
+        dict: Available and enabled language codes
+    """
+    return {
+        "available": seed_corpus.available_corpora(),
+        "enabled": seed_corpus.enabled_corpora(),
+    }
+
+
+@router.post("/seed")
+def load_seed_corpus(
+    data: SeedRequest,
+    request: Request,
+    admin: dict = Depends(require_role("admin"))
+):
+    """
+    Load the bundled starter corpus. Words that already exist are skipped,
+    so this is safe to run more than once.
+    
+    Args:
+        data: Languages to load (defaults to the enabled corpora)
+        
+    Returns:
+        dict: Per-language counts of inserted and skipped words
+    """
+    try:
+        summary = seed_corpus.load_seed_corpus(
+            data.languages, user=admin, request_id=request.state.request_id
+        )
+        return {"message": "Seed corpus loaded", "languages": summary}
+    
+    except ValueError as e:
+        raise HTTPException(status_code=400, detail=str(e))
+    except mysql.connector.Error as e:
+        logger.error(f"Database error loading seed corpus: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
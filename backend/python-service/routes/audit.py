@@ -0,0 +1,58 @@
+"""
+Audit routes.
+Lets the Go service report scrape actions into the shared audit log.
+"""
+from fastapi import APIRouter, HTTPException, Header
+from pydantic import BaseModel
+from typing import Optional
+from db_utils import get_db_cursor, logger
+from audit_utils import record_audit
+import mysql.connector
+import os
+import hmac
+
+# Shared secret for service-to-service calls from the Go service
+SERVICE_API_KEY = os.getenv("SERVICE_API_KEY")
+
+router = APIRouter(prefix="/audit")
+
+
+class AuditEvent(BaseModel):
+    """Model for an audit event reported by another service."""
+    action: str
+    entity_type: str
+    entity_id: Optional[str] = None
+    user_id: Optional[int] = None
+    user_email: Optional[str] = None
+    details: Optional[dict] = None
+    request_id: Optional[str] = None
+    service: str = "go"
+
+
+@router.post("/events")
+def ingest_audit_event(event: AuditEvent, x_service_key: Optional[str] = Header(None)):
+    """
+    Record an audit event reported by the Go service.
+    Requires the shared X-Service-Key header.
+    
+    Args:
+        event: The audit event
+        
+    Returns:
+        dict: Success message
+    """
+    if not SERVICE_API_KEY or not x_service_key or not hmac.compare_digest(x_service_key, SERVICE_API_KEY):
+        raise HTTPException(status_code=401, detail="Invalid service key")
+    
+    user = {"id": event.user_id, "email": event.user_email} if event.user_id else None
+    try:
+        with get_db_cursor() as (db, cursor):
+            record_audit(
+                cursor, event.action, event.entity_type, event.entity_id,
+                user=user, details=event.details,
+                request_id=event.request_id, service=event.service
+            )
+        return {"message": "Audit event recorded"}
+    except mysql.connector.Error as e:
+        logger.error(f"Database error recording audit event: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
@@ -24,6 +24,28 @@ class LoginRequest(BaseModel):
     password: str
 
 
+def create_access_token(user: dict) -> tuple[str, datetime]:
+    """
+    Issue the app's JWT for a user row. Shared by password and OAuth logins.
+
+    Returns:
+        tuple: (token, expiry_time)
+    """
+    expiry_time = datetime.utcnow() + timedelta(hours=TOKEN_EXPIRY_HOURS)
+    token = jwt.encode(
+        {
+            "id": user["id"], 
+            "email": user["email"], 
+            "type": user["type"],
+            "role": user.get("role") or "user",  # "user" or "admin", enforced by require_role
+            "exp": expiry_time  # Add expiration to token payload
+        },
+        SECRET_KEY,
+        algorithm="HS256"
+    )
+    return token, expiry_time
+
+
 ###Login
 @router.post("/login")
 def login(data: LoginRequest):
@@ -39,22 +61,13 @@ def login(data: LoginRequest):
     db.close()
 
     # Verify user exists and password is correct
-    if not user or not bcrypt.verify(data.password, user["password_hash"]):
+    # (accounts created through Google login have no password hash)
+    if not user or not user["password_hash"] or not bcrypt.verify(data.password, user["password_hash"]):
         print("Invalid login attempt")  # Debug log
         raise HTTPException(status_code=401, detail="Invalid email or password")
 
     # Generate JWT token with expiration time (24 hours)
-    expiry_time = datetime.utcnow() + timedelta(hours=TOKEN_EXPIRY_HOURS)
-    token = jwt.encode(
-        {
-            "id": user["id"], 
-            "email": user["email"], 
-            "type": user["type"],
-            "exp": expiry_time  # Add expiration to token payload
-        },
-        SECRET_KEY,
-        algorithm="HS256"
-    )
+    token, expiry_time = create_access_token(user)
     print("Generated token:", token)  # Debug log
 
     return {
@@ -128,5 +141,6 @@ def verify_token_endpoint(token_data: dict = Depends(verify_token)):
         "valid": True,
         "user_id": token_data.get("id"),
         "email": token_data.get("email"),
-        "type": token_data.get("type")
+        "type": token_data.get("type"),
+        "role": token_data.get("role", "user")
     }
@@ -0,0 +1,243 @@
+"""
+Deck routes.
+A deck is a user's named list of words, kept apart from the learning queue
+so a set of words can be put together and exported to spreadsheets or
+other apps.
+"""
+from fastapi import APIRouter, HTTPException, Depends, Request
+from pydantic import BaseModel, validator
+from db_utils import get_db_cursor, logger
+from auth_utils import get_current_user
+from audit_utils import record_audit
+from word_export import EXPORT_COLUMNS, export_columns, export_response
+import mysql.connector
+
+router = APIRouter(prefix="/api/decks")
+
+
+class DeckRequest(BaseModel):
+    """Model for creating a deck."""
+    name: str
+
+    @validator('name')
+    def name_not_empty(cls, v):
+        if not v or not v.strip():
+            raise ValueError('Name cannot be empty')
+        if len(v.strip()) > 255:
+            raise ValueError('Name must be at most 255 characters')
+        return v.strip()
+
+
+class DeckWordRequest(BaseModel):
+    """Model for adding a word to a deck."""
+    word_id: int
+
+
+def _check_deck(cursor, deck_id: int, user_id: int) -> None:
+    """Raise 404 unless the deck exists and belongs to the user."""
+    cursor.execute("SELECT id FROM decks WHERE id = %s AND user_id = %s", (deck_id, user_id))
+    if not cursor.fetchone():
+        raise HTTPException(status_code=404, detail="Deck not found")
+
+
+@router.get("")
+def list_decks(user_data: dict = Depends(get_current_user)):
+    """
+    List the user's decks.
+
+    Returns:
+        dict: Decks with their number of words
+    """
+    try:
+        with get_db_cursor(commit=False) as (db, cursor):
+            cursor.execute("""
+                SELECT d.id, d.name, d.created_at, COUNT(dw.word_id) as word_count
+                FROM decks d
+                LEFT JOIN deck_words dw ON dw.deck_id = d.id
+                WHERE d.user_id = %s
+                GROUP BY d.id
+                ORDER BY d.name
+            """, (user_data.get("id"),))
+            decks = cursor.fetchall()
+            return {"decks": decks, "count": len(decks)}
+
+    except mysql.connector.Error as e:
+        logger.error(f"Database error listing decks: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
+
+
+@router.post("")
+def create_deck(data: DeckRequest, request: Request, user_data: dict = Depends(get_current_user)):
+    """
+    Create an empty deck.
+
+    Args:
+        data: Deck name, unique among the user's decks
+
+    Returns:
+        dict: Success message with deck_id
+    """
+    try:
+        with get_db_cursor() as (db, cursor):
+            cursor.execute(
+                "INSERT INTO decks (user_id, name) VALUES (%s, %s)",
+                (user_data.get("id"), data.name)
+            )
+            deck_id = cursor.lastrowid
+            record_audit(
+                cursor, "create", "deck", deck_id, user=user_data,
+                details={"name": data.name}, request_id=request.state.request_id
+            )
+            return {"message": "Deck created successfully", "deck_id": deck_id}
+
+    except mysql.connector.IntegrityError:
+        raise HTTPException(status_code=409, detail="You already have a deck with this name")
+    except mysql.connector.Error as e:
+        logger.error(f"Database error creating deck: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
+
+
+@router.delete("/{deck_id}")
+def delete_deck(deck_id: int, request: Request, user_data: dict = Depends(get_current_user)):
+    """
+    Delete a deck. Its words stay stored and in the learning queue.
+
+    Args:
+        deck_id: ID of the deck
+
+    Returns:
+        dict: Success message
+    """
+    try:
+        with get_db_cursor() as (db, cursor):
+            cursor.execute("DELETE FROM decks WHERE id = %s AND user_id = %s", (deck_id, user_data.get("id")))
+            if cursor.rowcount == 0:
+                raise HTTPException(status_code=404, detail="Deck not found")
+            record_audit(cursor, "delete", "deck", deck_id, user=user_data, request_id=request.state.request_id)
+            return {"message": "Deck deleted successfully"}
+
+    except HTTPException:
+        raise
+    except mysql.connector.Error as e:
+        logger.error(f"Database error deleting deck {deck_id}: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
+
+
+@router.post("/{deck_id}/words")
+def add_deck_word(
+    deck_id: int,
+    data: DeckWordRequest,
+    request: Request,
+    user_data: dict = Depends(get_current_user)
+):
+    """
+    Add a word to a deck. Adding a word that is already in it does nothing.
+
+    Args:
+        deck_id: ID of the deck
+        data: Word ID to add
+
+    Returns:
+        dict: Success message
+    """
+    try:
+        with get_db_cursor() as (db, cursor):
+            _check_deck(cursor, deck_id, user_data.get("id"))
+            cursor.execute("SELECT id FROM words WHERE id = %s", (data.word_id,))
+            if not cursor.fetchone():
+                raise HTTPException(status_code=404, detail="Word not found")
+
+            cursor.execute(
+                "INSERT IGNORE INTO deck_words (deck_id, word_id) VALUES (%s, %s)",
+                (deck_id, data.word_id)
+            )
+            record_audit(
+                cursor, "create", "deck_word", f"{deck_id}:{data.word_id}",
+                user=user_data, request_id=request.state.request_id
+            )
+            return {"message": "Word added to deck successfully"}
+
+    except HTTPException:
+        raise
+    except mysql.connector.Error as e:
+        logger.error(f"Database error adding word to deck {deck_id}: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
+
+
+@router.delete("/{deck_id}/words/{word_id}")
+def remove_deck_word(
+    deck_id: int,
+    word_id: int,
+    request: Request,
+    user_data: dict = Depends(get_current_user)
+):
+    """
+    Remove a word from a deck.
+
+    Args:
+        deck_id: ID of the deck
+        word_id: ID of the word
+
+    Returns:
+        dict: Success message
+    """
+    try:
+        with get_db_cursor() as (db, cursor):
+            _check_deck(cursor, deck_id, user_data.get("id"))
+            cursor.execute("DELETE FROM deck_words WHERE deck_id = %s AND word_id = %s", (deck_id, word_id))
+            if cursor.rowcount == 0:
+                raise HTTPException(status_code=404, detail="Word is not in this deck")
+            record_audit(
+                cursor, "delete", "deck_word", f"{deck_id}:{word_id}",
+                user=user_data, request_id=request.state.request_id
+            )
+            return {"message": "Word removed from deck successfully"}
+
+    except HTTPException:
+        raise
+    except mysql.connector.Error as e:
+        logger.error(f"Database error removing word from deck {deck_id}: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
+
+
+@router.get("/{deck_id}/export")
+def export_deck(
+    deck_id: int,
+    format: str = "csv",
+    columns: str = "word,gender,meanings",
+    user_data: dict = Depends(get_current_user)
+):
+    """
+    Export a deck's words as CSV or TSV, for spreadsheets and other apps,
+    or as a Quizlet import. Words the user isn't learning have empty status
+    and next_review columns.
+
+    Args:
+        deck_id: ID of the deck
+        format: "csv" (default), "tsv" or "quizlet"
+        columns: Comma-separated columns in order, from EXPORT_COLUMNS
+        user_data: Authenticated user data from JWT token
+
+    Returns:
+        StreamingResponse: The file, with a header row, as an attachment
+    """
+    names = export_columns(format, columns)
+    user_id = user_data.get("id")
+    try:
+        with get_db_cursor(commit=False) as (db, cursor):
+            _check_deck(cursor, deck_id, user_id)
+    except mysql.connector.Error as e:
+        logger.error(f"Database error exporting deck {deck_id}: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
+
+    query = f"""
+        SELECT {", ".join(EXPORT_COLUMNS[name] for name in names)}
+        FROM deck_words dw
+        JOIN words w ON dw.word_id = w.id
+        LEFT JOIN word_types wt ON w.wordtype = wt.id
+        LEFT JOIN languages l ON w.language = l.id
+        LEFT JOIN user_progress up ON up.word_id = w.id AND up.user_id = %s
+        WHERE dw.deck_id = %s
+        ORDER BY w.word
+    """
+    return export_response(query, (user_id, deck_id), names, format, f"deck-{deck_id}")
@@ -0,0 +1,237 @@
+"""
+Grammar topic routes.
+Maintains a small taxonomy of grammar topics (e.g. "verbs taking dative")
+and the links between topics and words, so review sessions can be filtered
+by grammatical feature.
+"""
+from fastapi import APIRouter, HTTPException, Depends, Request
+from pydantic import BaseModel, validator
+from typing import Optional
+from db_utils import get_db_cursor, logger
+from auth_utils import get_optional_user
+from audit_utils import record_audit
+import mysql.connector
+
+router = APIRouter(prefix="/grammar_topics")
+
+
+class GrammarTopicRequest(BaseModel):
+    """Model for creating a grammar topic."""
+    name: str
+    description: Optional[str] = None
+    language_id: Optional[int] = None
+    parent_id: Optional[int] = None
+    
+    @validator('name')
+    def name_not_empty(cls, v):
+        if not v or not v.strip():
+            raise ValueError('Name cannot be empty')
+        return v.strip()
+
+
+class LinkWordRequest(BaseModel):
+    """Model for linking a word to a grammar topic."""
+    word_id: int
+
+
+@router.get("")
+def list_grammar_topics(language_id: Optional[int] = None):
+    """
+    List grammar topics, optionally filtered by language.
+    Topics without a language apply to all languages and are always included.
+    
+    Args:
+        language_id: Optional language ID to filter by
+        
+    Returns:
+        dict: Topics with their parent and number of linked words
+    """
+    try:
+        with get_db_cursor(commit=False) as (db, cursor):
+            query = """
+                SELECT gt.id, gt.name, gt.description, gt.language_id, gt.parent_id,
+                       l.language as language_name,
+                       COUNT(wgt.word_id) as word_count
+                FROM grammar_topics gt
+                LEFT JOIN languages l ON gt.language_id = l.id
+                LEFT JOIN word_grammar_topics wgt ON wgt.topic_id = gt.id
+            """
+            params = ()
+            if language_id:
+                query += " WHERE gt.language_id = %s OR gt.language_id IS NULL"
+                params = (language_id,)
+            query += " GROUP BY gt.id ORDER BY gt.name"
+            
+            cursor.execute(query, params)
+            topics = cursor.fetchall()
+            return {"topics": topics, "count": len(topics)}
+            
+    except mysql.connector.Error as e:
+        logger.error(f"Database error listing grammar topics: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
+
+
+@router.post("")
+def create_grammar_topic(
+    data: GrammarTopicRequest,
+    request: Request,
+    user: Optional[dict] = Depends(get_optional_user)
+):
+    """
+    Create a grammar topic.
+    
+    Args:
+        data: Topic name, optional description, language and parent topic
+        
+    Returns:
+        dict: Success message with topic_id
+    """
+    try:
+        with get_db_cursor() as (db, cursor):
+            if data.parent_id:
+                cursor.execute("SELECT id FROM grammar_topics WHERE id = %s", (data.parent_id,))
+                if not cursor.fetchone():
+                    raise HTTPException(status_code=400, detail=f"Parent topic ID {data.parent_id} does not exist")
+            
+            cursor.execute("""
+                INSERT INTO grammar_topics (name, description, language_id, parent_id)
+                VALUES (%s, %s, %s, %s)
+            """, (data.name, data.description, data.language_id, data.parent_id))
+            topic_id = cursor.lastrowid
+            record_audit(
+                cursor, "create", "grammar_topic", topic_id, user=user,
+                details={"name": data.name}, request_id=request.state.request_id
+            )
+            logger.info(f"Created grammar topic '{data.name}' with ID {topic_id}")
+            
+            return {"message": "Grammar topic created successfully", "topic_id": topic_id}
+            
+    except HTTPException:
+        raise
+    except mysql.connector.IntegrityError as e:
+        logger.error(f"Integrity error creating grammar topic: {e}")
+        raise HTTPException(status_code=400, detail="Grammar topic already exists or references invalid data")
+    except mysql.connector.Error as e:
+        logger.error(f"Database error creating grammar topic: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
+
+
+@router.get("/{topic_id}/words")
+def get_topic_words(topic_id: int):
+    """
+    List the words linked to a grammar topic.
+    
+    Args:
+        topic_id: ID of the grammar topic
+        
+    Returns:
+        dict: Linked words
+    """
+    try:
+        with get_db_cursor(commit=False) as (db, cursor):
+            cursor.execute("SELECT id, name FROM grammar_topics WHERE id = %s", (topic_id,))
+            topic = cursor.fetchone()
+            if not topic:
+                raise HTTPException(status_code=404, detail="Grammar topic not found")
+            
+            cursor.execute("""
+                SELECT w.id, w.word, wt.wordtype as wordtype_name, l.language as language_name
+                FROM word_grammar_topics wgt
+                JOIN words w ON wgt.word_id = w.id
+                LEFT JOIN word_types wt ON w.wordtype = wt.id
+                LEFT JOIN languages l ON w.language = l.id
+                WHERE wgt.topic_id = %s
+                ORDER BY w.word
+            """, (topic_id,))
+            words = cursor.fetchall()
+            
+            return {"topic": topic, "words": words, "count": len(words)}
+            
+    except HTTPException:
+        raise
+    except mysql.connector.Error as e:
+        logger.error(f"Database error fetching words for topic {topic_id}: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
+
+
+@router.post("/{topic_id}/words")
+def link_word(
+    topic_id: int,
+    data: LinkWordRequest,
+    request: Request,
+    user: Optional[dict] = Depends(get_optional_user)
+):
+    """
+    Link a word to a grammar topic.
+    
+    Args:
+        topic_id: ID of the grammar topic
+        data: Word ID to link
+        
+    Returns:
+        dict: Success message
+    """
+    try:
+        with get_db_cursor() as (db, cursor):
+            cursor.execute("SELECT id FROM grammar_topics WHERE id = %s", (topic_id,))
+            if not cursor.fetchone():
+                raise HTTPException(status_code=404, detail="Grammar topic not found")
+            
+            cursor.execute("SELECT id FROM words WHERE id = %s", (data.word_id,))
+            if not cursor.fetchone():
+                raise HTTPException(status_code=404, detail="Word not found")
+            
+            cursor.execute("""
+                INSERT IGNORE INTO word_grammar_topics (word_id, topic_id) VALUES (%s, %s)
+            """, (data.word_id, topic_id))
+            record_audit(
+                cursor, "create", "word_grammar_topic", f"{data.word_id}:{topic_id}",
+                user=user, request_id=request.state.request_id
+            )
+            logger.info(f"Linked word {data.word_id} to grammar topic {topic_id}")
+            
+            return {"message": "Word linked to grammar topic successfully"}
+            
+    except HTTPException:
+        raise
+    except mysql.connector.Error as e:
+        logger.error(f"Database error linking word to topic: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
+
+
+@router.delete("/{topic_id}/words/{word_id}")
+def unlink_word(
+    topic_id: int,
+    word_id: int,
+    request: Request,
+    user: Optional[dict] = Depends(get_optional_user)
+):
+    """
+    Remove the link between a word and a grammar topic.
+    
+    Args:
+        topic_id: ID of the grammar topic
+        word_id: ID of the word
+        
+    Returns:
+        dict: Success message
+    """
+    try:
+        with get_db_cursor() as (db, cursor):
+            cursor.execute("""
+                DELETE FROM word_grammar_topics WHERE word_id = %s AND topic_id = %s
+            """, (word_id, topic_id))
+            if cursor.rowcount == 0:
+                raise HTTPException(status_code=404, detail="Link not found")
+            
+            record_audit(
+                cursor, "delete", "word_grammar_topic", f"{word_id}:{topic_id}",
+                user=user, request_id=request.state.request_id
+            )
+            return {"message": "Word unlinked from grammar topic successfully"}
+            
+    except HTTPException:
+        raise
+    except mysql.connector.Error as e:
+        logger.error(f"Database error unlinking word from topic: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
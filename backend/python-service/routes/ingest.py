@@ -0,0 +1,351 @@
+"""
+Ingest routes for scraped dictionary entries.
+The Go service delivers WordEntry objects here, one at a time or in bulk.
+Requires the shared X-Service-Key header.
+"""
+from fastapi import APIRouter, HTTPException, Header, Request
+from pydantic import BaseModel, validator
+from typing import Callable, Dict, List, Optional
+from datetime import datetime, timezone
+from db_utils import get_db_cursor, logger
+from audit_utils import record_audit
+from language_codes import db_language_code
+import mysql.connector
+import os
+import hmac
+
+# Shared secret for service-to-service calls from the Go service
+SERVICE_API_KEY = os.getenv("SERVICE_API_KEY")
+
+# Largest batch accepted by the bulk endpoint
+MAX_BULK_ENTRIES = 500
+
+# Scraped sense categories (Norwegian or English) -> word_types.wordtype
+WORDTYPE_NAMES = {
+    "substantiv": "noun",
+    "verb": "verb",
+    "adjektiv": "adjective",
+    "adverb": "adverb",
+    "pronomen": "pronoun",
+    "preposisjon": "preposition",
+    "konjunksjon": "conjunction",
+    "subjunksjon": "conjunction",
+    "interjeksjon": "interjection",
+}
+
+# CEFR levels from easiest to hardest
+CEFR_LEVELS = ("A1", "A2", "B1", "B2", "C1", "C2")
+
+router = APIRouter(prefix="/api/words")
+
+
+class MeaningEntry(BaseModel):
+    description: str
+    examples: Optional[List[str]] = None
+
+
+class PronunciationEntry(BaseModel):
+    text: str
+    notation: Optional[str] = None
+    audio: Optional[str] = None
+    variant: Optional[str] = None
+
+
+class Frequency(BaseModel):
+    rank: int
+    band: int
+
+
+class CefrLevel(BaseModel):
+    level: str
+    basis: str
+
+
+class WordForm(BaseModel):
+    label: str = ""
+    forms: List[str] = []
+
+
+class SenseEntry(BaseModel):
+    id: str = ""
+    category: str = ""
+    # Normalized word class set by the Go service, e.g. "noun"
+    part_of_speech: Optional[str] = None
+    gender: Optional[str] = None
+    meanings: List[MeaningEntry] = []
+    pronunciations: List[PronunciationEntry] = []
+    etymology: Optional[str] = None
+    # Inflection table rows; counted for the data quality report, and
+    # their forms stored for exports
+    word_forms: List[WordForm] = []
+
+
+class WordEntry(BaseModel):
+    """A scraped entry as produced by the Go service (extra fields are ignored)."""
+    word: str
+    language: Optional[str] = None
+    senses: List[SenseEntry] = []
+    # Hash of word, language and source version; repeated deliveries share it
+    idempotency_key: Optional[str] = None
+    pronunciations: List[PronunciationEntry] = []
+    # Attribution and freshness
+    source: Optional[str] = None
+    source_url: Optional[str] = None
+    scraped_at: Optional[datetime] = None
+    license: Optional[str] = None
+    # Set when the word is on its language's frequency list
+    frequency: Optional[Frequency] = None
+    # Estimated CEFR level (A1-C2)
+    cefr: Optional[CefrLevel] = None
+
+
+class BulkIngestRequest(BaseModel):
+    """Body of the bulk ingest endpoint."""
+    entries: List[WordEntry]
+
+    @validator('entries')
+    def entries_within_limit(cls, v):
+        if len(v) > MAX_BULK_ENTRIES:
+            raise ValueError(f'At most {MAX_BULK_ENTRIES} entries per request')
+        return v
+
+
+def _check_service_key(x_service_key: Optional[str]) -> None:
+    if not SERVICE_API_KEY or not x_service_key or not hmac.compare_digest(x_service_key, SERVICE_API_KEY):
+        raise HTTPException(status_code=401, detail="Invalid service key")
+
+
+def _wordtype_name(sense: SenseEntry) -> Optional[str]:
+    """The sense's wordtype, from its normalized part of speech or else its category."""
+    if sense.part_of_speech in WORDTYPE_NAMES.values():
+        return sense.part_of_speech
+    for part in sense.category.lower().split():
+        if part in WORDTYPE_NAMES:
+            return WORDTYPE_NAMES[part]
+        if part in WORDTYPE_NAMES.values():
+            return part
+    return None
+
+
+def _pronunciation(entry: WordEntry) -> Optional[str]:
+    """The first transcription of the word, preferring entry-level ones over per-sense ones."""
+    candidates = entry.pronunciations + [p for sense in entry.senses for p in sense.pronunciations]
+    for pronunciation in candidates:
+        text = pronunciation.text.strip()
+        if text:
+            return text[:255]
+    return None
+
+
+def _etymology(entry: WordEntry) -> Optional[str]:
+    """The origin given on the first sense that has one."""
+    for sense in entry.senses:
+        if sense.etymology and sense.etymology.strip():
+            return sense.etymology.strip()
+    return None
+
+
+def _cefr_level(entry: WordEntry) -> Optional[str]:
+    """The entry's CEFR level, if it is one of A1-C2."""
+    if entry.cefr and entry.cefr.level.upper() in CEFR_LEVELS:
+        return entry.cefr.level.upper()
+    return None
+
+
+def _gender(entry: WordEntry) -> Optional[str]:
+    """The gender given on the first sense that has one."""
+    for sense in entry.senses:
+        if sense.gender and sense.gender.strip():
+            return sense.gender.strip()[:50]
+    return None
+
+
+def _forms(entry: WordEntry) -> Optional[str]:
+    """The entry's distinct inflected forms in table order, joined with ", "."""
+    forms = []
+    for sense in entry.senses:
+        for row in sense.word_forms:
+            for form in row.forms:
+                form = form.strip()
+                if form and form not in forms:
+                    forms.append(form)
+    return ", ".join(forms) or None
+
+
+def _inflection_count(entry: WordEntry) -> int:
+    """The number of inflection rows with forms across the entry's senses."""
+    return min(sum(1 for sense in entry.senses for row in sense.word_forms if row.forms), 32767)
+
+
+def _scraped_at(entry: WordEntry) -> Optional[datetime]:
+    """When the entry was scraped, as naive UTC for the DATETIME column."""
+    if entry.scraped_at is None:
+        return None
+    if entry.scraped_at.tzinfo is None:
+        return entry.scraped_at
+    return entry.scraped_at.astimezone(timezone.utc).replace(tzinfo=None)
+
+
+def store_entry(cursor, entry: WordEntry, language_ids: Dict[str, int], wordtype_ids: Dict[str, int]) -> Dict:
+    """
+    Store one entry using the caller's cursor.
+
+    Returns:
+        dict: The word with status "created", "exists" or "rejected"
+    """
+    result = {"word": entry.word, "language": entry.language}
+    code = db_language_code(entry.language)
+    if code not in language_ids:
+        return {**result, "status": "rejected", "error": f"Unsupported language: {entry.language}"}
+
+    # Each definition with its examples joined with "; ", or None
+    definitions = [
+        (meaning.description.strip(), "; ".join(e.strip() for e in meaning.examples or [] if e.strip()) or None)
+        for sense in entry.senses
+        for meaning in sense.meanings
+        if meaning.description and meaning.description.strip()
+    ]
+    if not entry.word.strip() or not definitions:
+        return {**result, "status": "rejected", "error": "Entry has no word or no definitions"}
+
+    wordtype = None
+    for sense in entry.senses:
+        wordtype = _wordtype_name(sense)
+        if wordtype:
+            break
+
+    language_id = language_ids[code]
+    # A clash on either the word or the idempotency key means it was stored already
+    cursor.execute(
+        "INSERT IGNORE INTO words (word, wordtype, language, idempotency_key, pronunciation, etymology, "
+        "source, source_url, scraped_at, license, frequency_rank, frequency_band, cefr_level, inflection_count, "
+        "gender, forms) "
+        "VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)",
+        (
+            entry.word.strip(), wordtype_ids.get(wordtype), language_id, entry.idempotency_key or None,
+            _pronunciation(entry), _etymology(entry),
+            entry.source or None, (entry.source_url or "")[:2048] or None, _scraped_at(entry), entry.license or None,
+            entry.frequency.rank if entry.frequency else None, entry.frequency.band if entry.frequency else None,
+            _cefr_level(entry), _inflection_count(entry), _gender(entry), _forms(entry),
+        ),
+    )
+    if cursor.rowcount == 0:
+        return {**result, "status": "exists"}
+
+    word_id = cursor.lastrowid
+    for definition, examples in definitions:
+        cursor.execute(
+            "INSERT INTO meanings (word_id, language_id, definition, examples) VALUES (%s, %s, %s, %s)",
+            (word_id, language_id, definition, examples),
+        )
+    return {**result, "status": "created", "word_id": word_id}
+
+
+class IngestCancelled(Exception):
+    """Raised when the caller gave up before the batch was committed."""
+
+
+def ingest_entries(
+    entries: List[WordEntry],
+    request_id: Optional[str] = None,
+    cancelled: Optional[Callable[[], bool]] = None,
+    dry_run: bool = False
+) -> Dict:
+    """
+    Store entries in one transaction. Shared by the HTTP and gRPC endpoints.
+
+    Args:
+        entries: Scraped entries
+        request_id: Request ID for the audit record
+        cancelled: Checked before committing; if it returns True the
+            transaction is rolled back and IngestCancelled is raised
+        dry_run: Validate and store the entries, then roll back, so the
+            results say what would have been saved
+
+    Returns:
+        dict: Per-entry results plus created/exists/rejected counts
+    """
+    with get_db_cursor(commit=not dry_run) as (db, cursor):
+        cursor.execute("SELECT id, code FROM languages")
+        language_ids = {row["code"]: row["id"] for row in cursor.fetchall()}
+        cursor.execute("SELECT id, wordtype FROM word_types")
+        wordtype_ids = {row["wordtype"]: row["id"] for row in cursor.fetchall()}
+
+        results = [store_entry(cursor, entry, language_ids, wordtype_ids) for entry in entries]
+        counts = {status: sum(1 for r in results if r["status"] == status)
+                  for status in ("created", "exists", "rejected")}
+
+        if dry_run:
+            db.rollback()
+            # Rolled-back IDs were never stored
+            for result in results:
+                result.pop("word_id", None)
+            logger.info(f"Dry run of {len(entries)} entries: {counts}")
+            return {"results": results, **counts, "dry_run": True}
+
+        record_audit(
+            cursor, "ingest", "word", None, details=counts,
+            request_id=request_id, service="go"
+        )
+
+        if cancelled and cancelled():
+            db.rollback()
+            raise IngestCancelled()
+
+    logger.info(f"Ingested {len(entries)} entries: {counts}")
+    return {"results": results, **counts}
+
+
+@router.post("")
+def ingest_word(
+    entry: WordEntry,
+    request: Request,
+    dry_run: bool = False,
+    x_service_key: Optional[str] = Header(None)
+):
+    """
+    Store a single scraped entry.
+
+    Args:
+        entry: The scraped entry
+        dry_run: Report what would be stored without storing it
+
+    Returns:
+        dict: Outcome for the entry
+    """
+    _check_service_key(x_service_key)
+    try:
+        return ingest_entries([entry], request.state.request_id, dry_run=dry_run)
+    except mysql.connector.Error as e:
+        logger.error(f"Database error ingesting entry: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
+
+
+@router.post("/bulk")
+def ingest_words_bulk(
+    data: BulkIngestRequest,
+    request: Request,
+    dry_run: bool = False,
+    x_service_key: Optional[str] = Header(None)
+):
+    """
+    Store a batch of scraped entries in one transaction.
+
+    Entries that can't be stored (unsupported language, no definitions) are
+    reported as "rejected" without failing the batch; words that already
+    exist are reported as "exists". Only database errors fail the request,
+    in which case nothing from the batch is stored and the sender retries.
+
+    Args:
+        data: Up to MAX_BULK_ENTRIES entries
+        dry_run: Report what would be stored without storing it
+
+    Returns:
+        dict: Per-entry results plus created/exists/rejected counts
+    """
+    _check_service_key(x_service_key)
+    try:
+        return ingest_entries(data.entries, request.state.request_id, dry_run=dry_run)
+    except mysql.connector.Error as e:
+        logger.error(f"Database error ingesting {len(data.entries)} entries: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
@@ -0,0 +1,186 @@
+"""
+OAuth2 login routes (authorization code flow) for Google.
+
+The flow ends by issuing the app's own JWT, exactly like /auth/login, so the
+rest of the app does not need to know how the user signed in. Existing users
+registered with email/password are linked to their Google account when Google
+reports the same verified email address.
+"""
+from fastapi import APIRouter, HTTPException, Request
+from fastapi.responses import RedirectResponse
+from datetime import datetime, timedelta
+from urllib.parse import urlencode
+import hmac
+import jwt
+import os
+import secrets
+import requests
+import mysql.connector
+from db_utils import get_db_cursor, logger
+from routes.auth import create_access_token
+
+SECRET_KEY = os.getenv("SECRET_KEY")
+GOOGLE_CLIENT_ID = os.getenv("GOOGLE_CLIENT_ID")
+GOOGLE_CLIENT_SECRET = os.getenv("GOOGLE_CLIENT_SECRET")
+GOOGLE_REDIRECT_URI = os.getenv("GOOGLE_REDIRECT_URI", "http://localhost:8000/auth/google/callback")
+# Where the browser is sent after login; the token is passed in the URL fragment
+FRONTEND_OAUTH_REDIRECT = os.getenv("FRONTEND_OAUTH_REDIRECT", "http://localhost:3000/auth/google/callback")
+
+GOOGLE_AUTH_URL = "https://accounts.google.com/o/oauth2/v2/auth"
+GOOGLE_TOKEN_URL = "https://oauth2.googleapis.com/token"
+GOOGLE_USERINFO_URL = "https://openidconnect.googleapis.com/v1/userinfo"
+
+# The state parameter is a short-lived signed token, so no server-side session is needed
+STATE_EXPIRY_MINUTES = 10
+
+# The state carries a nonce that must match this cookie, set on the browser
+# that started the login, so a state and code obtained by someone else can't
+# be used to log a victim into their account (login CSRF)
+NONCE_COOKIE = "google_oauth_nonce"
+
+router = APIRouter(prefix="/auth/google")
+
+
+def _require_config():
+    if not GOOGLE_CLIENT_ID or not GOOGLE_CLIENT_SECRET:
+        raise HTTPException(status_code=503, detail="Google login is not configured")
+
+
+@router.get("/login")
+def google_login():
+    """
+    Redirect the browser to Google's consent screen.
+    """
+    _require_config()
+
+    nonce = secrets.token_urlsafe(32)
+    state = jwt.encode(
+        {
+            "purpose": "google_oauth",
+            "nonce": nonce,
+            "exp": datetime.utcnow() + timedelta(minutes=STATE_EXPIRY_MINUTES),
+        },
+        SECRET_KEY,
+        algorithm="HS256"
+    )
+    params = {
+        "client_id": GOOGLE_CLIENT_ID,
+        "redirect_uri": GOOGLE_REDIRECT_URI,
+        "response_type": "code",
+        "scope": "openid email profile",
+        "state": state,
+        "prompt": "select_account",
+    }
+    response = RedirectResponse(f"{GOOGLE_AUTH_URL}?{urlencode(params)}")
+    # Lax, not Strict: the callback is a top-level redirect from Google
+    response.set_cookie(
+        NONCE_COOKIE, nonce,
+        max_age=STATE_EXPIRY_MINUTES * 60,
+        path="/auth/google",
+        httponly=True,
+        samesite="lax",
+        secure=GOOGLE_REDIRECT_URI.startswith("https://"),
+    )
+    return response
+
+
+@router.get("/callback")
+def google_callback(request: Request, code: str = None, state: str = None, error: str = None):
+    """
+    Handle Google's redirect: check the state belongs to this browser,
+    exchange the code, find or link the user, and hand the app's JWT to the
+    frontend.
+    """
+    _require_config()
+
+    if error:
+        return RedirectResponse(f"{FRONTEND_OAUTH_REDIRECT}#{urlencode({'error': error})}")
+    if not code or not state:
+        raise HTTPException(status_code=400, detail="Missing code or state")
+
+    try:
+        payload = jwt.decode(state, SECRET_KEY, algorithms=["HS256"])
+        if payload.get("purpose") != "google_oauth":
+            raise jwt.InvalidTokenError("wrong purpose")
+    except jwt.InvalidTokenError:
+        raise HTTPException(status_code=400, detail="Invalid or expired OAuth state")
+    cookie_nonce = request.cookies.get(NONCE_COOKIE) or ""
+    if not cookie_nonce or not hmac.compare_digest(str(payload.get("nonce", "")), cookie_nonce):
+        logger.warning("Google login rejected: OAuth state was not started by this browser")
+        raise HTTPException(status_code=400, detail="Invalid or expired OAuth state")
+
+    profile = _fetch_google_profile(code)
+    if not profile.get("email") or not profile.get("email_verified"):
+        raise HTTPException(status_code=400, detail="Google account has no verified email")
+
+    try:
+        user = _find_or_link_user(profile["sub"], profile["email"])
+    except mysql.connector.Error as e:
+        logger.error(f"Database error during Google login: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
+
+    token, expiry_time = create_access_token(user)
+    fragment = urlencode({"token": token, "expires_at": expiry_time.isoformat()})
+    response = RedirectResponse(f"{FRONTEND_OAUTH_REDIRECT}#{fragment}")
+    # One login per nonce
+    response.delete_cookie(NONCE_COOKIE, path="/auth/google")
+    return response
+
+
+def _fetch_google_profile(code: str) -> dict:
+    """Exchange the authorization code and fetch the OpenID profile."""
+    try:
+        token_resp = requests.post(GOOGLE_TOKEN_URL, data={
+            "code": code,
+            "client_id": GOOGLE_CLIENT_ID,
+            "client_secret": GOOGLE_CLIENT_SECRET,
+            "redirect_uri": GOOGLE_REDIRECT_URI,
+            "grant_type": "authorization_code",
+        }, timeout=10)
+        if token_resp.status_code != 200:
+            logger.error(f"Google token exchange failed: {token_resp.status_code} {token_resp.text}")
+            raise HTTPException(status_code=401, detail="Google login failed")
+
+        access_token = token_resp.json()["access_token"]
+        info_resp = requests.get(
+            GOOGLE_USERINFO_URL,
+            headers={"Authorization": f"Bearer {access_token}"},
+            timeout=10
+        )
+        if info_resp.status_code != 200:
+            logger.error(f"Google userinfo failed: {info_resp.status_code} {info_resp.text}")
+            raise HTTPException(status_code=401, detail="Google login failed")
+        return info_resp.json()
+    except requests.RequestException as e:
+        logger.error(f"Error talking to Google: {e}")
+        raise HTTPException(status_code=502, detail="Could not reach Google")
+
+
+def _find_or_link_user(google_sub: str, email: str) -> dict:
+    """
+    Return the user for a Google account, in order of preference:
+    1. a user already linked to this Google subject
+    2. an email-registered user with the same email (linked now)
+    3. a newly created user without a password
+    """
+    with get_db_cursor() as (db, cursor):
+        cursor.execute("SELECT * FROM users WHERE google_sub = %s", (google_sub,))
+        user = cursor.fetchone()
+        if user:
+            return user
+
+        cursor.execute("SELECT * FROM users WHERE email = %s", (email,))
+        user = cursor.fetchone()
+        if user:
+            cursor.execute("UPDATE users SET google_sub = %s WHERE id = %s", (google_sub, user["id"]))
+            logger.info(f"Linked Google account to existing user {user['id']}")
+            user["google_sub"] = google_sub
+            return user
+
+        cursor.execute(
+            "INSERT INTO users (email, password_hash, type, google_sub) VALUES (%s, NULL, %s, %s)",
+            (email, "basic", google_sub)
+        )
+        user_id = cursor.lastrowid
+        logger.info(f"Created user {user_id} from Google login")
+        return {"id": user_id, "email": email, "type": "basic", "role": "user", "google_sub": google_sub}
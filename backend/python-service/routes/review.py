@@ -2,7 +2,7 @@
 Review API routes for spaced repetition learning system.
 Handles fetching words for review and recording review results.
 """
-from fastapi import APIRouter, HTTPException, Depends
+from fastapi import APIRouter, HTTPException, Depends, File, Form, Query, Request, UploadFile
 from pydantic import BaseModel, validator
 from datetime import datetime, date
 from typing import Optional, List
@@ -15,10 +15,33 @@ from spaced_repetition import (
     quality_from_user_response,
     calculate_accuracy
 )
+from practice_generators import MAX_PRACTICE_CARDS, generate_practice_cards, check_practice_answer
+from spellcheck import check_spelling, spell_checker_registry
+from audit_utils import record_audit
+from routes.ingest import CEFR_LEVELS, MeaningEntry, SenseEntry, WordEntry, store_entry
+from anki_import import MAX_UPLOAD_BYTES as MAX_ANKI_UPLOAD_BYTES, ExportTooLarge, parse_anki_export
+from language_codes import canonical_language, db_language_code
+from word_export import EXPORT_COLUMNS, export_columns, export_response
+import fetchers
+import json
 import mysql.connector
+import os
+import requests
 
 router = APIRouter(prefix="/review")
 
+# The Go service, which splits analyzed text into words and lemmatizes them
+GO_SERVICE_URL = os.getenv("GO_SERVICE_URL", "http://vocabulary-app-go-service:8080")
+
+# Most notes one Anki import takes, as each new word may be looked up
+MAX_ANKI_NOTES = 1000
+
+# Orders for new words: most recently added, or most useful (most frequent) first
+NEW_WORD_ORDERS = {
+    "recent": "w.created_at DESC",
+    "frequency": "w.frequency_rank IS NULL, w.frequency_rank ASC, w.created_at DESC",
+}
+
 
 class ReviewSubmission(BaseModel):
     """Model for submitting a review result."""
@@ -38,9 +61,31 @@ class AddToLearningRequest(BaseModel):
     word_id: int
 
 
+class ExerciseSubmission(BaseModel):
+    """Model for submitting a free-text production exercise."""
+    word_id: int
+    answer: str
+    exercise_type: str = "sentence"  # e.g. "write a sentence using the word"
+    
+    @validator('answer')
+    def answer_not_empty(cls, v):
+        if not v or not v.strip():
+            raise ValueError('Answer cannot be empty')
+        return v.strip()
+
+
+class PracticeAnswer(BaseModel):
+    """Model for checking an answer to a generated practice card."""
+    expected: str
+    answer: str
+
+
 @router.get("/due")
 def get_due_words(
     limit: int = 20,
+    grammar_topic_id: Optional[int] = None,
+    practice: int = Query(0, ge=0, le=MAX_PRACTICE_CARDS),
+    practice_language: Optional[str] = None,
     user_data: dict = Depends(get_current_user)
 ):
     """
@@ -48,6 +93,9 @@ def get_due_words(
     
     Args:
         limit: Maximum number of words to return
+        grammar_topic_id: Optional filter to words linked to a grammar topic
+        practice: Number of number/date/time practice cards to mix in, up to 100
+        practice_language: Language code for practice cards (e.g. "no")
         user_data: Authenticated user data from JWT token
         
     Returns:
@@ -68,6 +116,7 @@ def get_due_words(
                     up.repetitions,
                     up.next_review,
                     w.word,
+                    w.pronunciation,
                     wt.wordtype as wordtype_name,
                     l.language as language_name,
                     w.language as language_id
@@ -78,11 +127,19 @@ def get_due_words(
                 WHERE up.user_id = %s 
                 AND (up.next_review IS NULL OR up.next_review <= NOW())
                 AND up.status != 'mastered'
+                {topic_filter}
                 ORDER BY up.next_review ASC, up.created_at ASC
                 LIMIT %s
             """
             
-            cursor.execute(query, (user_id, limit))
+            if grammar_topic_id:
+                query = query.format(topic_filter="AND up.word_id IN (SELECT word_id FROM word_grammar_topics WHERE topic_id = %s)")
+                params = (user_id, grammar_topic_id, limit)
+            else:
+                query = query.format(topic_filter="")
+                params = (user_id, limit)
+            
+            cursor.execute(query, params)
             words = cursor.fetchall()
             
             # For each word, get its meanings
@@ -96,22 +153,84 @@ def get_due_words(
                 """, (word['word_id'],))
                 
                 word['meanings'] = cursor.fetchall()
+                word['card_type'] = "word"
+            
+            practice_cards = []
+            if practice > 0 and practice_language:
+                try:
+                    practice_cards = generate_practice_cards(practice_language, practice)
+                except ValueError as e:
+                    raise HTTPException(status_code=400, detail=str(e))
             
             return {
                 "words": words,
                 "count": len(words),
+                "practice_cards": practice_cards,
                 "user_id": user_id
             }
             
+    except HTTPException:
+        raise
     except mysql.connector.Error as e:
         logger.error(f"Database error fetching due words: {e}")
         raise HTTPException(status_code=500, detail="Database error occurred")
 
 
+@router.get("/practice")
+def get_practice_cards(
+    language: str,
+    count: int = Query(10, ge=1, le=MAX_PRACTICE_CARDS),
+    kind: Optional[str] = None,
+    user_data: dict = Depends(get_current_user)
+):
+    """
+    Generate number, date, and clock-time practice cards.
+    
+    Args:
+        language: Language code ("no", "en", "de")
+        count: Number of cards to generate, 1 to 100
+        kind: Optional single kind ("number", "date", or "time")
+        user_data: Authenticated user data from JWT token
+        
+    Returns:
+        dict: Generated practice cards
+    """
+    try:
+        cards = generate_practice_cards(language, count, [kind] if kind else None)
+    except ValueError as e:
+        raise HTTPException(status_code=400, detail=str(e))
+    
+    return {"cards": cards, "count": len(cards)}
+
+
+@router.post("/practice/check")
+def check_practice(
+    data: PracticeAnswer,
+    user_data: dict = Depends(get_current_user)
+):
+    """
+    Check the user's answer to a practice card.
+    
+    Args:
+        data: Expected answer from the card and the user's answer
+        user_data: Authenticated user data from JWT token
+        
+    Returns:
+        dict: Whether the answer was correct and the expected answer
+    """
+    return {
+        "correct": check_practice_answer(data.expected, data.answer),
+        "expected": data.expected
+    }
+
+
 @router.get("/new")
 def get_new_words(
     language_id: Optional[int] = None,
+    grammar_topic_id: Optional[int] = None,
     limit: int = 10,
+    order: str = "recent",
+    cefr_levels: Optional[str] = None,
     user_data: dict = Depends(get_current_user)
 ):
     """
@@ -119,13 +238,23 @@ def get_new_words(
     
     Args:
         language_id: Optional filter by language
+        grammar_topic_id: Optional filter to words linked to a grammar topic
         limit: Maximum number of words to return
+        order: "recent" (newest first) or "frequency" (most common first;
+            unranked words last)
+        cefr_levels: Optional comma-separated CEFR levels to keep, e.g. "A1,A2"
         user_data: Authenticated user data from JWT token
         
     Returns:
         dict: List of new words not yet in user's learning queue
     """
     user_id = user_data.get("id")
+    if order not in NEW_WORD_ORDERS:
+        raise HTTPException(status_code=400, detail=f"Unknown order: {order}")
+    levels = [level.strip().upper() for level in (cefr_levels or "").split(",") if level.strip()]
+    unknown = [level for level in levels if level not in CEFR_LEVELS]
+    if unknown:
+        raise HTTPException(status_code=400, detail=f"Unknown CEFR level: {', '.join(unknown)}")
     
     try:
         with get_db_cursor(commit=False) as (db, cursor):
@@ -133,7 +262,8 @@ def get_new_words(
             if language_id:
                 query = """
                     SELECT w.id, w.word, wt.wordtype as wordtype_name, 
-                           l.language as language_name, w.language as language_id
+                           l.language as language_name, w.language as language_id,
+                           w.frequency_rank, w.frequency_band, w.cefr_level
                     FROM words w
                     LEFT JOIN word_types wt ON w.wordtype = wt.id
                     LEFT JOIN languages l ON w.language = l.id
@@ -148,7 +278,8 @@ def get_new_words(
             else:
                 query = """
                     SELECT w.id, w.word, wt.wordtype as wordtype_name, 
-                           l.language as language_name, w.language as language_id
+                           l.language as language_name, w.language as language_id,
+                           w.frequency_rank, w.frequency_band, w.cefr_level
                     FROM words w
                     LEFT JOIN word_types wt ON w.wordtype = wt.id
                     LEFT JOIN languages l ON w.language = l.id
@@ -160,6 +291,24 @@ def get_new_words(
                 """
                 params = (user_id, limit)
             
+            if grammar_topic_id:
+                # Narrow either query to words linked to the topic
+                query = query.replace(
+                    "ORDER BY w.created_at DESC",
+                    "AND w.id IN (SELECT word_id FROM word_grammar_topics WHERE topic_id = %s)\n                    ORDER BY w.created_at DESC"
+                )
+                params = params[:-1] + (grammar_topic_id, limit)
+            
+            if levels:
+                placeholders = ", ".join(["%s"] * len(levels))
+                query = query.replace(
+                    "ORDER BY w.created_at DESC",
+                    f"AND w.cefr_level IN ({placeholders})\n                    ORDER BY w.created_at DESC"
+                )
+                params = params[:-1] + tuple(levels) + (limit,)
+            
+            query = query.replace("ORDER BY w.created_at DESC", f"ORDER BY {NEW_WORD_ORDERS[order]}")
+            
             cursor.execute(query, params)
             words = cursor.fetchall()
             
@@ -375,6 +524,66 @@ def submit_review(
         raise HTTPException(status_code=500, detail="Database error occurred")
 
 
+@router.post("/exercise")
+def submit_exercise(
+    data: ExerciseSubmission,
+    user_data: dict = Depends(get_current_user)
+):
+    """
+    Submit a free-text production exercise and get spelling feedback.
+    The feedback is stored together with the exercise result.
+    
+    Args:
+        data: Word ID, the user's written answer, and exercise type
+        user_data: Authenticated user data from JWT token
+        
+    Returns:
+        dict: Spelling issues, whether the word was used, and the result ID
+    """
+    user_id = user_data.get("id")
+    
+    try:
+        with get_db_cursor() as (db, cursor):
+            cursor.execute("""
+                SELECT w.word, l.code as language_code
+                FROM words w
+                LEFT JOIN languages l ON w.language = l.id
+                WHERE w.id = %s
+            """, (data.word_id,))
+            word = cursor.fetchone()
+            if not word:
+                raise HTTPException(status_code=404, detail="Word not found")
+            
+            language = word['language_code'] or ""
+            issues = [issue.to_dict() for issue in check_spelling(data.answer, language)]
+            used_word = word['word'].lower() in data.answer.lower()
+            
+            cursor.execute("""
+                INSERT INTO exercise_results
+                (user_id, word_id, exercise_type, answer, used_word, spelling_errors)
+                VALUES (%s, %s, %s, %s, %s, %s)
+            """, (
+                user_id, data.word_id, data.exercise_type, data.answer,
+                used_word, json.dumps(issues)
+            ))
+            result_id = cursor.lastrowid
+            
+            logger.info(f"User {user_id} submitted {data.exercise_type} exercise for word {data.word_id}: {len(issues)} spelling issues")
+            
+            return {
+                "id": result_id,
+                "used_word": used_word,
+                "spelling_checked": language in spell_checker_registry.get_supported_languages(),
+                "spelling_errors": issues
+            }
+            
+    except HTTPException:
+        raise
+    except mysql.connector.Error as e:
+        logger.error(f"Database error submitting exercise: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
+
+
 @router.get("/stats")
 def get_user_stats(user_data: dict = Depends(get_current_user)):
     """
@@ -435,3 +644,453 @@ def get_user_stats(user_data: dict = Depends(get_current_user)):
     except mysql.connector.Error as e:
         logger.error(f"Database error fetching user stats: {e}")
         raise HTTPException(status_code=500, detail="Database error occurred")
+
+
+@router.get("/export")
+def export_words(
+    format: str = "csv",
+    columns: str = "word,wordtype,meanings",
+    grammar_topic_id: Optional[int] = None,
+    user_data: dict = Depends(get_current_user)
+):
+    """
+    Export the words in the user's learning queue as CSV or TSV, for
+    spreadsheets and other apps, or as a Quizlet import. Decks are exported
+    by GET /api/decks/{deck_id}/export.
+
+    Args:
+        format: "csv" (default), "tsv" or "quizlet"
+        columns: Comma-separated columns in order, from EXPORT_COLUMNS
+        grammar_topic_id: Optional filter to words linked to a grammar topic
+        user_data: Authenticated user data from JWT token
+
+    Returns:
+        StreamingResponse: The file, with a header row, as an attachment
+    """
+    names = export_columns(format, columns)
+    query = f"""
+        SELECT {", ".join(EXPORT_COLUMNS[name] for name in names)}
+        FROM user_progress up
+        JOIN words w ON up.word_id = w.id
+        LEFT JOIN word_types wt ON w.wordtype = wt.id
+        LEFT JOIN languages l ON w.language = l.id
+        WHERE up.user_id = %s
+    """
+    params = [user_data.get("id")]
+    if grammar_topic_id:
+        query += " AND w.id IN (SELECT word_id FROM word_grammar_topics WHERE topic_id = %s)"
+        params.append(grammar_topic_id)
+    query += " ORDER BY w.word"
+    return export_response(query, params, names, format, "words")
+
+
+def _dictionary_entry(word: str, language: str) -> Optional[WordEntry]:
+    """Look a word up with the language's fetcher, or None if it has no definitions."""
+    try:
+        fetched = fetchers.fetch_word(word, language)
+    except Exception as e:
+        logger.warning(f"Looking up imported word '{word}' failed: {e}")
+        return None
+    if not fetched:
+        return None
+    entry = WordEntry(**{**fetched.to_dict(), "language": language})
+    if not any(meaning.description.strip() for sense in entry.senses for meaning in sense.meanings):
+        return None
+    return entry
+
+
+def _stored_word_id(cursor, entry: WordEntry, language_ids: dict, wordtype_ids: dict):
+    """
+    Store an entry unless its word is stored already.
+
+    Returns:
+        tuple: The word's ID and None, or None and why it wasn't stored
+    """
+    stored = store_entry(cursor, entry, language_ids, wordtype_ids)
+    if stored["status"] == "rejected":
+        return None, stored["error"]
+    if stored.get("word_id"):
+        return stored["word_id"], None
+    # The dictionary's headword (e.g. the lemma) was stored already
+    cursor.execute(
+        "SELECT id FROM words WHERE word = %s AND language = %s",
+        (entry.word.strip(), language_ids[db_language_code(entry.language)])
+    )
+    row = cursor.fetchone()
+    if not row:
+        return None, "Stored word not found"
+    return row["id"], None
+
+
+@router.post("/import/anki")
+def import_anki(
+    request: Request,
+    file: UploadFile = File(...),
+    language: str = Form(...),
+    enrich: bool = Form(True),
+    user_data: dict = Depends(get_current_user)
+):
+    """
+    Import an Anki export (.apkg package or .txt notes) into the user's
+    learning queue.
+
+    Each note's first field is the word and its second the meaning. Words
+    not stored yet are looked up in the dictionary for the language and
+    stored from there; with enrich off, or when the lookup finds nothing,
+    they are stored with the note's meaning. Cards a package has scheduled
+    keep their interval, ease and due date; other words are queued as new.
+
+    Args:
+        file: The Anki export
+        language: Language of the notes' words, e.g. "no" or "de"
+        enrich: Whether to look new words up in the dictionary (default true)
+        user_data: Authenticated user data from JWT token
+
+    Returns:
+        dict: Per-note results plus added/in_queue counts
+    """
+    user_id = user_data.get("id")
+    canonical = canonical_language(language)
+    if not canonical:
+        raise HTTPException(status_code=400, detail=f"Unsupported language: {language}")
+    code = db_language_code(canonical)
+
+    data = file.file.read(MAX_ANKI_UPLOAD_BYTES + 1)
+    if len(data) > MAX_ANKI_UPLOAD_BYTES:
+        raise HTTPException(status_code=413, detail=f"Export must be at most {MAX_ANKI_UPLOAD_BYTES // (1024 * 1024)} MB")
+    try:
+        notes = parse_anki_export(file.filename or "", data, MAX_ANKI_NOTES)
+    except ExportTooLarge as e:
+        raise HTTPException(status_code=413, detail=str(e))
+    except ValueError as e:
+        raise HTTPException(status_code=400, detail=str(e))
+    if len(notes) > MAX_ANKI_NOTES:
+        raise HTTPException(status_code=400, detail=f"At most {MAX_ANKI_NOTES} notes per import")
+    # A word may be on several notes (e.g. reversed cards); the first counts
+    seen = set()
+    notes = [n for n in notes if not (n.word.lower() in seen or seen.add(n.word.lower()))]
+    if not notes:
+        raise HTTPException(status_code=400, detail="No notes with a word and a meaning found")
+
+    try:
+        with get_db_cursor(commit=False) as (db, cursor):
+            placeholders = ", ".join(["%s"] * len(notes))
+            cursor.execute(f"""
+                SELECT w.id, w.word FROM words w
+                JOIN languages l ON w.language = l.id
+                WHERE l.code = %s AND w.word IN ({placeholders})
+            """, (code, *[n.word for n in notes]))
+            existing = {row["word"].lower(): row["id"] for row in cursor.fetchall()}
+
+        # Dictionary lookups happen before the transaction, which they'd hold open
+        entries = {}
+        for note in notes:
+            if note.word.lower() in existing:
+                continue
+            entry = _dictionary_entry(note.word, canonical) if enrich else None
+            entries[note.word] = entry or WordEntry(
+                word=note.word, language=canonical, source="anki",
+                senses=[SenseEntry(meanings=[MeaningEntry(description=note.meaning)])],
+            )
+
+        results = []
+        with get_db_cursor() as (db, cursor):
+            cursor.execute("SELECT id, code FROM languages")
+            language_ids = {row["code"]: row["id"] for row in cursor.fetchall()}
+            cursor.execute("SELECT id, wordtype FROM word_types")
+            wordtype_ids = {row["wordtype"]: row["id"] for row in cursor.fetchall()}
+
+            for note in notes:
+                result = {"word": note.word, "scheduled": note.schedule is not None}
+                word_id = existing.get(note.word.lower())
+                if word_id:
+                    result["source"] = "existing"
+                else:
+                    entry = entries[note.word]
+                    word_id, error = _stored_word_id(cursor, entry, language_ids, wordtype_ids)
+                    if error:
+                        results.append({**result, "status": "failed", "error": error})
+                        continue
+                    result["source"] = "anki" if entry.source == "anki" else "dictionary"
+
+                schedule = note.schedule
+                cursor.execute("""
+                    INSERT IGNORE INTO user_progress
+                    (user_id, word_id, ease_factor, interval_days, repetitions,
+                     review_count, correct_count, next_review, status)
+                    VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s)
+                """, (
+                    user_id, word_id,
+                    schedule.ease_factor if schedule else 2.5,
+                    schedule.interval_days if schedule else 0,
+                    schedule.repetitions if schedule else 0,
+                    schedule.review_count if schedule else 0,
+                    schedule.correct_count if schedule else 0,
+                    schedule.next_review if schedule else datetime.now(),
+                    determine_status(schedule.interval_days, schedule.ease_factor, schedule.repetitions)
+                    if schedule else "new",
+                ))
+                status = "added" if cursor.rowcount else "in_queue"
+                results.append({**result, "word_id": word_id, "status": status})
+
+            counts = {status: sum(1 for r in results if r["status"] == status)
+                      for status in ("added", "in_queue", "failed")}
+            if counts["added"]:
+                cursor.execute("""
+                    INSERT INTO user_statistics (user_id, words_learned)
+                    VALUES (%s, %s)
+                    ON DUPLICATE KEY UPDATE words_learned = words_learned + %s
+                """, (user_id, counts["added"], counts["added"]))
+            record_audit(
+                cursor, "import", "word", None, user=user_data,
+                details={"format": "anki", "language": canonical, **counts},
+                request_id=request.state.request_id
+            )
+
+        logger.info(f"User {user_id} imported {len(notes)} Anki notes: {counts}")
+        return {"results": results, **counts}
+
+    except mysql.connector.Error as e:
+        logger.error(f"Database error importing Anki notes: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
+
+
+class AnalyzeRequest(BaseModel):
+    """Model for finding the words of a text the user isn't learning."""
+    text: str
+    language: str
+
+
+class LearnWordRequest(BaseModel):
+    """Model for adding a word, looked up if not stored yet, to the learning queue."""
+    word: str
+    language: str
+
+
+def _go_analysis(path: str, **kwargs) -> dict:
+    """POST to one of the Go service's analysis endpoints, passing on its client errors."""
+    try:
+        response = requests.post(f"{GO_SERVICE_URL}{path}", timeout=60, **kwargs)
+    except requests.exceptions.RequestException as e:
+        logger.error(f"Text analysis request failed: {e}")
+        raise HTTPException(status_code=502, detail="Text analysis is unavailable")
+    if response.status_code in (400, 413):
+        raise HTTPException(status_code=response.status_code, detail=response.text.strip())
+    if not response.ok:
+        logger.error(f"Text analysis failed with {response.status_code}: {response.text.strip()}")
+        raise HTTPException(status_code=502, detail="Text analysis is unavailable")
+    return response.json()
+
+
+def _unknown_words(user_id: int, canonical: str, words: List[dict]) -> List[dict]:
+    """
+    The analyzed words that aren't in the user's learning queue, each with
+    its stored word_id, or None if not stored yet.
+
+    A word counts as known when its dictionary form, or a form the text
+    used, is in the queue.
+    """
+    candidates = {form.lower() for w in words for form in [w["word"], *w.get("forms", [])]}
+    stored = {}
+    try:
+        if candidates:
+            with get_db_cursor(commit=False) as (db, cursor):
+                placeholders = ", ".join(["%s"] * len(candidates))
+                cursor.execute(f"""
+                    SELECT w.id, w.word, up.id IS NOT NULL AS learning
+                    FROM words w
+                    JOIN languages l ON w.language = l.id
+                    LEFT JOIN user_progress up ON up.word_id = w.id AND up.user_id = %s
+                    WHERE l.code = %s AND w.word IN ({placeholders})
+                """, (user_id, db_language_code(canonical), *candidates))
+                stored = {row["word"].lower(): row for row in cursor.fetchall()}
+    except mysql.connector.Error as e:
+        logger.error(f"Database error analyzing text: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
+
+    unknown = []
+    for w in words:
+        rows = [stored[form.lower()] for form in [w["word"], *w.get("forms", [])] if form.lower() in stored]
+        if any(row["learning"] for row in rows):
+            continue
+        unknown.append({
+            "word": w["word"],
+            "forms": w.get("forms", []),
+            "count": w["count"],
+            "frequency": w.get("frequency"),
+            "cefr": w.get("cefr"),
+            "word_id": rows[0]["id"] if rows else None,
+        })
+    return unknown
+
+
+@router.post("/analyze")
+def analyze_text(
+    data: AnalyzeRequest,
+    user_data: dict = Depends(get_current_user)
+):
+    """
+    Find the words of a text that aren't in the user's learning queue.
+
+    The Go service splits the text into words and maps each to its
+    dictionary form; a word counts as known when that form, or a form the
+    text used, is in the user's learning queue.
+
+    Args:
+        data: The text and its language
+        user_data: Authenticated user data from JWT token
+
+    Returns:
+        dict: The unknown words in order of first use, each with its stored
+        word_id (or null if not stored yet), plus known/total counts
+    """
+    canonical = canonical_language(data.language)
+    if not canonical:
+        raise HTTPException(status_code=400, detail=f"Unsupported language: {data.language}")
+
+    analysis = _go_analysis("/api/analyze", json={"text": data.text, "language": canonical})
+    words = analysis.get("words") or []
+    unknown = _unknown_words(user_data.get("id"), canonical, words)
+    return {
+        "language": canonical,
+        "total": len(words),
+        "known_count": len(words) - len(unknown),
+        "partial": analysis.get("partial", False),
+        "unknown": unknown,
+    }
+
+
+@router.post("/analyze/upload")
+def analyze_upload(
+    file: UploadFile = File(...),
+    language: str = Form(...),
+    limit: int = Form(50),
+    user_data: dict = Depends(get_current_user)
+):
+    """
+    Suggest words to learn from an uploaded e-book or subtitle file.
+
+    The Go service extracts and analyzes the text of the file (.epub, .srt,
+    .vtt or .txt). Words the user is learning are left out, and the rest
+    ranked: words on the language's frequency list before others (which are
+    mostly names and rarities), then the ones the text uses most, then the
+    more common in the language.
+
+    Args:
+        file: The e-book or subtitle file
+        language: Language of the text, e.g. "no" or "de"
+        limit: How many words to suggest (default 50, at most 500)
+        user_data: Authenticated user data from JWT token
+
+    Returns:
+        dict: The ranked words, each with its stored word_id (or null if
+        not stored yet), plus known/total counts
+    """
+    canonical = canonical_language(language)
+    if not canonical:
+        raise HTTPException(status_code=400, detail=f"Unsupported language: {language}")
+    if not 1 <= limit <= 500:
+        raise HTTPException(status_code=400, detail="limit must be between 1 and 500")
+
+    analysis = _go_analysis(
+        "/api/analyze/upload",
+        params={"language": canonical},
+        files={"file": (file.filename or "upload.txt", file.file, file.content_type)},
+    )
+    words = analysis.get("words") or []
+    unknown = _unknown_words(user_data.get("id"), canonical, words)
+    unknown.sort(key=lambda w: (
+        w["frequency"] is None,
+        -w["count"],
+        w["frequency"]["rank"] if w["frequency"] else 0,
+    ))
+    return {
+        "language": canonical,
+        "source": file.filename,
+        "total": len(words),
+        "known_count": len(words) - len(unknown),
+        "partial": analysis.get("partial", False),
+        "unknown": unknown[:limit],
+    }
+
+
+@router.post("/learn-word")
+def learn_word(
+    request: Request,
+    data: LearnWordRequest,
+    user_data: dict = Depends(get_current_user)
+):
+    """
+    Add a word to the user's learning queue by name, looking it up in the
+    dictionary for the language and storing it first if it isn't stored yet.
+
+    Args:
+        data: The word and its language
+        user_data: Authenticated user data from JWT token
+
+    Returns:
+        dict: The word_id, whether the word was looked up, and "added" or
+        "in_queue"
+    """
+    user_id = user_data.get("id")
+    canonical = canonical_language(data.language)
+    if not canonical:
+        raise HTTPException(status_code=400, detail=f"Unsupported language: {data.language}")
+    word = data.word.strip()
+    if not word:
+        raise HTTPException(status_code=400, detail="Missing word")
+
+    try:
+        with get_db_cursor(commit=False) as (db, cursor):
+            cursor.execute("""
+                SELECT w.id FROM words w
+                JOIN languages l ON w.language = l.id
+                WHERE l.code = %s AND w.word = %s
+            """, (db_language_code(canonical), word))
+            row = cursor.fetchone()
+
+        # The lookup happens before the transaction, which it would hold open
+        entry = None
+        if not row:
+            entry = _dictionary_entry(word, canonical)
+            if not entry:
+                raise HTTPException(status_code=404, detail=f"No dictionary entry found for '{word}'")
+
+        with get_db_cursor() as (db, cursor):
+            if row:
+                word_id = row["id"]
+            else:
+                cursor.execute("SELECT id, code FROM languages")
+                language_ids = {r["code"]: r["id"] for r in cursor.fetchall()}
+                cursor.execute("SELECT id, wordtype FROM word_types")
+                wordtype_ids = {r["wordtype"]: r["id"] for r in cursor.fetchall()}
+                word_id, error = _stored_word_id(cursor, entry, language_ids, wordtype_ids)
+                if error:
+                    raise HTTPException(status_code=422, detail=error)
+
+            cursor.execute("""
+                INSERT IGNORE INTO user_progress
+                (user_id, word_id, status, next_review)
+                VALUES (%s, %s, 'new', NOW())
+            """, (user_id, word_id))
+            status = "added" if cursor.rowcount else "in_queue"
+            if status == "added":
+                cursor.execute("""
+                    INSERT INTO user_statistics (user_id, words_learned)
+                    VALUES (%s, 1)
+                    ON DUPLICATE KEY UPDATE words_learned = words_learned + 1
+                """, (user_id,))
+                record_audit(
+                    cursor, "create", "user_progress", word_id, user=user_data,
+                    details={"word": word, "language": canonical, "looked_up": entry is not None},
+                    request_id=request.state.request_id
+                )
+
+        logger.info(f"User {user_id} learned word '{word}' ({word_id}): {status}")
+        return {"word_id": word_id, "looked_up": entry is not None, "status": status}
+
+    except HTTPException:
+        raise
+    except mysql.connector.Error as e:
+        logger.error(f"Database error learning word: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
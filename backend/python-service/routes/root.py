@@ -1,7 +1,31 @@
 from fastapi import APIRouter
+from fastapi.responses import JSONResponse
+from db_utils import get_db_cursor
+import mysql.connector
 
 router = APIRouter()
 
 @router.get("/")
 def read_root():
     return {"message": "Hello from the Python service!"}
+
+
+@router.get("/healthz")
+def healthz():
+    """
+    Health probe used by orchestration and the Go service's /readyz.
+
+    Returns:
+        dict: Overall status and per-dependency status; 503 when the
+            database is unreachable
+    """
+    try:
+        with get_db_cursor(commit=False) as (db, cursor):
+            cursor.execute("SELECT 1")
+            cursor.fetchall()
+    except mysql.connector.Error as e:
+        return JSONResponse(
+            status_code=503,
+            content={"status": "unavailable", "checks": {"database": {"status": "fail", "error": str(e)}}},
+        )
+    return {"status": "ok", "checks": {"database": {"status": "ok"}}}
@@ -1,11 +1,24 @@
-from fastapi import APIRouter, HTTPException
+from fastapi import APIRouter, HTTPException, Depends, Request
+from fastapi.responses import StreamingResponse
 from pydantic import BaseModel, validator
+from typing import Optional
 from database import get_connection
 from db_utils import get_db_cursor, validate_required_fields, check_duplicate, logger
+from auth_utils import get_optional_user
+from audit_utils import record_audit
+from language_codes import db_language_code
+from routes.ingest import CEFR_LEVELS
+import json
 import mysql.connector
 
 router = APIRouter(prefix="/words")
 
+# Most words one random draw returns
+MAX_RANDOM_WORDS = 50
+
+# Rows the export reads from the database at a time
+EXPORT_BATCH_SIZE = 500
+
 class Meaning(BaseModel):
     language_id: int
     definition: str
@@ -36,7 +49,11 @@ class AddWordRequest(BaseModel):
         return v
 
 @router.post("/add")
-def add_word(data: AddWordRequest):
+def add_word(
+    data: AddWordRequest,
+    request: Request,
+    user: Optional[dict] = Depends(get_optional_user)
+):
     """
     Add a new word with meanings to the database.
     Uses transaction management to ensure all-or-nothing insertion.
@@ -100,6 +117,12 @@ def add_word(data: AddWordRequest):
                 )
                 logger.info(f"Inserted meaning {idx+1} for word ID {word_id}")
             
+            record_audit(
+                cursor, "create", "word", word_id, user=user,
+                details={"word": data.word, "language_id": data.language_id, "meanings": len(data.meanings)},
+                request_id=request.state.request_id
+            )
+            
             # Transaction is automatically committed by the context manager
             return {
                 "message": "Word added successfully", 
@@ -121,6 +144,140 @@ def add_word(data: AddWordRequest):
         raise HTTPException(status_code=500, detail="An unexpected error occurred")
 
 
+@router.get("/random")
+def random_words(
+    language: Optional[str] = None,
+    pos: Optional[str] = None,
+    level: Optional[str] = None,
+    count: int = 1,
+    exclude_id: Optional[int] = None
+):
+    """
+    Draw random words from stored vocabulary, for "surprise me" learning and
+    quiz distractors.
+
+    Args:
+        language: Optional language code, e.g. "no", "no-bm" or "en"
+        pos: Optional word type, e.g. "noun"
+        level: Optional CEFR level or comma-separated levels, e.g. "A2" or "A1,A2"
+        count: Number of words to draw (1-50, default 1)
+        exclude_id: Optional word ID to leave out, e.g. the quiz answer
+
+    Returns:
+        dict: Up to count random words with their first definition
+    """
+    if not 1 <= count <= MAX_RANDOM_WORDS:
+        raise HTTPException(status_code=400, detail=f"count must be between 1 and {MAX_RANDOM_WORDS}")
+    levels = [l.strip().upper() for l in (level or "").split(",") if l.strip()]
+    unknown = [l for l in levels if l not in CEFR_LEVELS]
+    if unknown:
+        raise HTTPException(status_code=400, detail=f"Unknown CEFR level: {', '.join(unknown)}")
+
+    conditions, params = [], []
+    if language:
+        code = db_language_code(language)
+        if not code:
+            raise HTTPException(status_code=400, detail=f"Unsupported language: {language}")
+        conditions.append("l.code = %s")
+        params.append(code)
+    if pos:
+        conditions.append("wt.wordtype = %s")
+        params.append(pos.strip().lower())
+    if levels:
+        conditions.append(f"w.cefr_level IN ({', '.join(['%s'] * len(levels))})")
+        params.extend(levels)
+    if exclude_id:
+        conditions.append("w.id <> %s")
+        params.append(exclude_id)
+    where = f"WHERE {' AND '.join(conditions)}" if conditions else ""
+
+    try:
+        with get_db_cursor(commit=False) as (db, cursor):
+            cursor.execute(f"""
+                SELECT w.id, w.word, wt.wordtype as wordtype_name, l.code as language_code,
+                       l.language as language_name, w.cefr_level, w.frequency_rank,
+                       (SELECT m.definition FROM meanings m WHERE m.word_id = w.id
+                        ORDER BY m.id LIMIT 1) as definition
+                FROM words w
+                LEFT JOIN word_types wt ON w.wordtype = wt.id
+                LEFT JOIN languages l ON w.language = l.id
+                {where}
+                ORDER BY RAND()
+                LIMIT %s
+            """, (*params, count))
+            return {"words": cursor.fetchall()}
+
+    except mysql.connector.Error as e:
+        logger.error(f"Database error drawing random words: {e}")
+        raise HTTPException(status_code=500, detail="Database error occurred")
+
+
+@router.get("/export")
+def export_words(language: Optional[str] = None):
+    """
+    Stream stored vocabulary as NDJSON: one JSON object per word and line,
+    with its meanings. Rows are read in batches as the response is written,
+    so large vocabularies are never held in memory.
+
+    Args:
+        language: Optional language code, e.g. "no", "no-bm" or "en"
+
+    Returns:
+        StreamingResponse: application/x-ndjson, words ordered by ID
+    """
+    conditions, params = "", ()
+    if language:
+        code = db_language_code(language)
+        if not code:
+            raise HTTPException(status_code=400, detail=f"Unsupported language: {language}")
+        conditions, params = "WHERE l.code = %s", (code,)
+
+    def lines():
+        # Words and their meanings come joined and ordered by word, so each
+        # word is complete once the next one starts.
+        current = None
+        with get_db_cursor(commit=False) as (db, cursor):
+            cursor.execute(f"""
+                SELECT w.id, w.word, wt.wordtype as wordtype_name, l.code as language_code,
+                       w.pronunciation, w.etymology, w.cefr_level, w.frequency_rank,
+                       w.source, w.source_url, w.license, w.scraped_at,
+                       m.definition, m.note, ml.code as meaning_language_code
+                FROM words w
+                LEFT JOIN word_types wt ON w.wordtype = wt.id
+                LEFT JOIN languages l ON w.language = l.id
+                LEFT JOIN meanings m ON m.word_id = w.id
+                LEFT JOIN languages ml ON m.language_id = ml.id
+                {conditions}
+                ORDER BY w.id, m.id
+            """, params)
+            while True:
+                rows = cursor.fetchmany(EXPORT_BATCH_SIZE)
+                if not rows:
+                    break
+                for row in rows:
+                    if current is None or current["id"] != row["id"]:
+                        if current is not None:
+                            yield json.dumps(current, ensure_ascii=False, default=str) + "\n"
+                        current = {
+                            key: row[key] for key in (
+                                "id", "word", "wordtype_name", "language_code",
+                                "pronunciation", "etymology", "cefr_level", "frequency_rank",
+                                "source", "source_url", "license", "scraped_at",
+                            )
+                        }
+                        current["meanings"] = []
+                    if row["definition"] is not None:
+                        current["meanings"].append({
+                            "definition": row["definition"],
+                            "note": row["note"],
+                            "language_code": row["meaning_language_code"],
+                        })
+        if current is not None:
+            yield json.dumps(current, ensure_ascii=False, default=str) + "\n"
+
+    return StreamingResponse(lines(), media_type="application/x-ndjson")
+
+
 @router.get("/{word_id}")
 def get_word(word_id: int):
     """
@@ -136,7 +293,9 @@ def get_word(word_id: int):
         with get_db_cursor(commit=False) as (db, cursor):
             # Get word details
             cursor.execute("""
-                SELECT w.id, w.word, w.wordtype, w.language,
+                SELECT w.id, w.word, w.wordtype, w.language, w.pronunciation, w.etymology,
+                       w.source, w.source_url, w.scraped_at, w.license,
+                       w.frequency_rank, w.frequency_band, w.cefr_level,
                        wt.wordtype as wordtype_name,
                        l.language as language_name
                 FROM words w
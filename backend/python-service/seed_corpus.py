@@ -0,0 +1,154 @@
+"""
+Starter corpus loader for new deployments.
+
+Loads the bundled word lists in seed_data/<language code>.json into the
+words and meanings tables, so a fresh install has vocabulary to review
+while live scraping warms up. Each file lists the most frequent words of a
+language, in frequency order, with their word type and meanings.
+
+Loading is deterministic and idempotent: files and words are processed in a
+fixed order, and words that already exist for a language are left untouched.
+
+Runs automatically on first boot (when the words table is empty) unless
+SEED_CORPUS_ON_BOOT=false, and can be run by hand:
+
+    python seed_corpus.py [--language no] [--force]
+"""
+import argparse
+import json
+import os
+from pathlib import Path
+from typing import Dict, List, Optional
+from db_utils import get_db_cursor, logger
+from audit_utils import record_audit
+
+
+SEED_DIR = Path(__file__).parent / "seed_data"
+
+
+def available_corpora() -> List[str]:
+    """
+    List the language codes that have a bundled corpus file.
+
+    Returns:
+        List[str]: Sorted language codes
+    """
+    return sorted(path.stem for path in SEED_DIR.glob("*.json"))
+
+
+def enabled_corpora() -> List[str]:
+    """
+    Language codes to seed, from SEED_CORPUS_LANGUAGES (comma-separated)
+    or every bundled corpus if unset.
+    """
+    configured = os.getenv("SEED_CORPUS_LANGUAGES", "")
+    codes = [code.strip() for code in configured.split(",") if code.strip()]
+    return sorted(codes) if codes else available_corpora()
+
+
+def _load_file(code: str) -> Dict:
+    path = SEED_DIR / f"{code}.json"
+    if not path.exists():
+        raise ValueError(f"No seed corpus for language '{code}'")
+    with open(path, encoding="utf-8") as f:
+        return json.load(f)
+
+
+def load_seed_corpus(
+    languages: Optional[List[str]] = None,
+    user: Optional[Dict] = None,
+    request_id: Optional[str] = None
+) -> Dict:
+    """
+    Insert the starter corpus for the given languages.
+
+    Args:
+        languages: Language codes to load (defaults to enabled_corpora())
+        user: Token payload of the admin triggering the load, if any
+        request_id: Request ID for the audit record
+
+    Returns:
+        dict: Per-language counts of inserted and skipped words
+
+    Raises:
+        ValueError: If a corpus file or one of its languages/word types is unknown
+    """
+    summary = {}
+    for code in sorted(languages or enabled_corpora()):
+        corpus = _load_file(code)
+
+        with get_db_cursor() as (db, cursor):
+            cursor.execute("SELECT id, code FROM languages")
+            language_ids = {row["code"]: row["id"] for row in cursor.fetchall()}
+            cursor.execute("SELECT id, wordtype FROM word_types")
+            wordtype_ids = {row["wordtype"]: row["id"] for row in cursor.fetchall()}
+
+            language_id = language_ids.get(corpus["language"])
+            definition_language_id = language_ids.get(corpus.get("definition_language", corpus["language"]))
+            if language_id is None or definition_language_id is None:
+                raise ValueError(f"Seed corpus '{code}' refers to a language missing from the languages table")
+
+            inserted = skipped = 0
+            for entry in corpus["words"]:
+                wordtype_id = wordtype_ids.get(entry["wordtype"])
+                if wordtype_id is None:
+                    raise ValueError(f"Seed corpus '{code}': unknown word type '{entry['wordtype']}'")
+
+                cursor.execute(
+                    "INSERT IGNORE INTO words (word, wordtype, language) VALUES (%s, %s, %s)",
+                    (entry["word"], wordtype_id, language_id),
+                )
+                if cursor.rowcount == 0:
+                    skipped += 1
+                    continue
+
+                word_id = cursor.lastrowid
+                for definition in entry["meanings"]:
+                    cursor.execute(
+                        "INSERT INTO meanings (word_id, language_id, definition) VALUES (%s, %s, %s)",
+                        (word_id, definition_language_id, definition),
+                    )
+                inserted += 1
+
+            record_audit(
+                cursor, "seed", "corpus", code, user=user,
+                details={"inserted": inserted, "skipped": skipped},
+                request_id=request_id
+            )
+
+        logger.info(f"Seed corpus '{code}': inserted {inserted} words, skipped {skipped} existing")
+        summary[code] = {"inserted": inserted, "skipped": skipped}
+
+    return summary
+
+
+def seed_on_first_boot() -> None:
+    """
+    Load the starter corpus if the words table is empty. Failures are logged
+    rather than raised so a missing database doesn't stop the service starting.
+    """
+    if os.getenv("SEED_CORPUS_ON_BOOT", "true").lower() in ("0", "false", "no"):
+        return
+    try:
+        with get_db_cursor(commit=False) as (db, cursor):
+            cursor.execute("SELECT COUNT(*) AS count FROM words")
+            if cursor.fetchone()["count"] > 0:
+                return
+        load_seed_corpus()
+    except Exception as e:
+        logger.error(f"Seeding starter corpus failed: {e}")
+
+
+if __name__ == "__main__":
+    from dotenv import load_dotenv
+    load_dotenv()
+
+    parser = argparse.ArgumentParser(description="Load the bundled starter corpus.")
+    parser.add_argument("--language", action="append", help="language code to load (repeatable)")
+    parser.add_argument("--force", action="store_true", help="load even if words already exist")
+    args = parser.parse_args()
+
+    if args.force or args.language:
+        print(load_seed_corpus(args.language))
+    else:
+        seed_on_first_boot()
@@ -0,0 +1,154 @@
+"""
+Pluggable spell checking for user-written answers.
+
+Spell checkers implement the SpellChecker interface and are looked up per
+language code. The default implementation talks to the hunspell binary in
+pipe mode, so adding a language is a matter of installing its dictionary and
+mapping it in HUNSPELL_DICTIONARIES, e.g. "no=nb_NO,en=en_US,de=de_DE".
+Languages without a dictionary fall back to a checker that reports nothing.
+"""
+from abc import ABC, abstractmethod
+from dataclasses import dataclass, field
+from typing import Dict, List, Optional
+import logging
+import os
+import shutil
+import subprocess
+
+logger = logging.getLogger(__name__)
+
+DEFAULT_DICTIONARIES = "no=nb_NO,en=en_US,de=de_DE"
+
+
+@dataclass
+class SpellingIssue:
+    """A misspelled word with its position in the text and suggestions."""
+    word: str
+    offset: int
+    suggestions: List[str] = field(default_factory=list)
+
+    def to_dict(self) -> Dict:
+        return {"word": self.word, "offset": self.offset, "suggestions": self.suggestions}
+
+
+class SpellChecker(ABC):
+    """Interface for language-specific spell checkers."""
+
+    @abstractmethod
+    def check(self, text: str) -> List[SpellingIssue]:
+        """Return the spelling issues found in text (empty if none)."""
+        pass
+
+    @property
+    def available(self) -> bool:
+        """Whether the checker can actually check spelling."""
+        return True
+
+
+class NullSpellChecker(SpellChecker):
+    """Fallback used when no dictionary is available for a language."""
+
+    def check(self, text: str) -> List[SpellingIssue]:
+        return []
+
+    @property
+    def available(self) -> bool:
+        return False
+
+
+class HunspellChecker(SpellChecker):
+    """
+    Spell checker backed by the hunspell command line tool in pipe (-a) mode.
+    """
+
+    def __init__(self, dictionary: str, binary: str = "hunspell", timeout: float = 5.0):
+        self.dictionary = dictionary
+        self.binary = binary
+        self.timeout = timeout
+
+    def check(self, text: str) -> List[SpellingIssue]:
+        # Prefix every line with "^" so hunspell never treats input as a command
+        lines = [f"^{line}" for line in text.splitlines() if line.strip()]
+        if not lines:
+            return []
+
+        try:
+            result = subprocess.run(
+                [self.binary, "-a", "-d", self.dictionary],
+                input="\n".join(lines) + "\n",
+                capture_output=True,
+                text=True,
+                timeout=self.timeout,
+                check=True,
+            )
+        except (OSError, subprocess.SubprocessError) as e:
+            logger.error(f"hunspell ({self.dictionary}) failed: {e}")
+            return []
+
+        return parse_hunspell_output(result.stdout)
+
+
+def parse_hunspell_output(output: str) -> List[SpellingIssue]:
+    """
+    Parse hunspell pipe-mode output.
+
+    Relevant line formats:
+        & <word> <count> <offset>: <suggestion>, <suggestion>
+        # <word> <offset>
+    Correct words ("*", "+", "-") and the version banner are ignored.
+    """
+    issues = []
+    for line in output.splitlines():
+        if line.startswith("& "):
+            head, _, suggestions = line.partition(": ")
+            parts = head.split()
+            issues.append(SpellingIssue(
+                word=parts[1],
+                offset=int(parts[3]),
+                suggestions=[s.strip() for s in suggestions.split(",") if s.strip()],
+            ))
+        elif line.startswith("# "):
+            parts = line.split()
+            issues.append(SpellingIssue(word=parts[1], offset=int(parts[2])))
+    return issues
+
+
+class SpellCheckerRegistry:
+    """Registry mapping language codes to spell checkers."""
+
+    def __init__(self):
+        self._checkers: Dict[str, SpellChecker] = {}
+
+    def register(self, language: str, checker: SpellChecker):
+        self._checkers[language] = checker
+        logger.info(f"Registered spell checker for language: {language}")
+
+    def get(self, language: str) -> SpellChecker:
+        return self._checkers.get(language, NullSpellChecker())
+
+    def get_supported_languages(self) -> List[str]:
+        return [lang for lang, checker in self._checkers.items() if checker.available]
+
+
+spell_checker_registry = SpellCheckerRegistry()
+
+
+def initialize_spell_checkers(mapping: Optional[str] = None):
+    """
+    Register hunspell checkers for the configured language → dictionary mapping.
+    Call this once on application startup.
+    """
+    if not shutil.which("hunspell"):
+        logger.warning("hunspell not installed; spelling feedback disabled")
+        return
+
+    mapping = mapping or os.getenv("HUNSPELL_DICTIONARIES", DEFAULT_DICTIONARIES)
+    for pair in mapping.split(","):
+        language, _, dictionary = pair.partition("=")
+        if language.strip() and dictionary.strip():
+            spell_checker_registry.register(language.strip(), HunspellChecker(dictionary.strip()))
+
+
+def check_spelling(text: str, language: str) -> List[SpellingIssue]:
+    """Check text with the spell checker registered for language."""
+    return spell_checker_registry.get(language).check(text)
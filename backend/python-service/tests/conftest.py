@@ -0,0 +1,8 @@
+"""
+Test setup: makes the service's modules importable as they are when the
+service runs from backend/python-service.
+"""
+import os
+import sys
+
+sys.path.insert(0, os.path.dirname(os.path.dirname(os.path.abspath(__file__))))
@@ -0,0 +1,52 @@
+"""
+Anki exports are read with bounded memory: oversized collections are
+refused before unpacking, and no more notes are read than the limit needs.
+"""
+import io
+import sqlite3
+import tempfile
+import zipfile
+
+import pytest
+
+import anki_import
+from anki_import import ExportTooLarge, parse_anki_export
+
+
+def build_package(words):
+    """Zip a minimal Anki collection holding a note per word."""
+    with tempfile.NamedTemporaryFile(suffix=".anki2") as db_file:
+        db = sqlite3.connect(db_file.name)
+        db.executescript("""
+            CREATE TABLE col (crt INTEGER);
+            CREATE TABLE notes (id INTEGER PRIMARY KEY, flds TEXT);
+            CREATE TABLE cards (id INTEGER PRIMARY KEY, nid INTEGER, ord INTEGER, type INTEGER,
+                                due INTEGER, ivl INTEGER, factor INTEGER, reps INTEGER, lapses INTEGER);
+            INSERT INTO col VALUES (0);
+        """)
+        db.executemany("INSERT INTO notes (flds) VALUES (?)", [(f"{w}\x1fmeaning",) for w in words])
+        db.commit()
+        db.close()
+        collection = open(db_file.name, "rb").read()
+    buf = io.BytesIO()
+    with zipfile.ZipFile(buf, "w", zipfile.ZIP_DEFLATED) as package:
+        package.writestr("collection.anki2", collection)
+    return buf.getvalue()
+
+
+def test_package_stops_after_limit():
+    data = build_package([f"ord{i}" for i in range(20)])
+    assert len(parse_anki_export("deck.apkg", data, 20)) == 20
+    assert len(parse_anki_export("deck.apkg", data, 5)) == 6
+
+
+def test_text_stops_after_limit():
+    data = "\n".join(f"ord{i}\tmeaning" for i in range(20)).encode()
+    assert len(parse_anki_export("deck.txt", data, 5)) == 6
+
+
+def test_package_refuses_large_collection(monkeypatch):
+    data = build_package(["hund"])
+    monkeypatch.setattr(anki_import, "MAX_COLLECTION_BYTES", 1024)
+    with pytest.raises(ExportTooLarge):
+        parse_anki_export("deck.apkg", data, 10)
@@ -0,0 +1,79 @@
+"""
+Deck exports cover only the user's own decks, and gender, examples and
+forms are stored from scraped entries so they can be exported.
+"""
+from contextlib import contextmanager
+
+import pytest
+from fastapi import FastAPI
+from fastapi.testclient import TestClient
+
+import word_export
+from auth_utils import get_current_user
+from routes import decks
+from routes.ingest import WordEntry, _forms, _gender
+
+app = FastAPI()
+app.include_router(decks.router)
+app.dependency_overrides[get_current_user] = lambda: {"id": 1, "email": "test@example.com"}
+client = TestClient(app)
+
+
+class FakeCursor:
+    """Answers the deck lookup with deck 7 of user 1, and exports rows."""
+
+    def __init__(self, rows):
+        self.rows = rows
+        self.params = None
+
+    def execute(self, query, params=()):
+        self.params = params
+
+    def fetchone(self):
+        return {"id": 7} if self.params == (7, 1) else None
+
+    def fetchmany(self, size):
+        rows, self.rows = self.rows[:size], self.rows[size:]
+        return rows
+
+
+@pytest.fixture
+def rows(monkeypatch):
+    rows = [("hus", "neuter", "bygning til å bo i", "huset, hus, husene")]
+
+    @contextmanager
+    def fake_cursor(dictionary=True, commit=True):
+        yield None, FakeCursor(list(rows))
+
+    monkeypatch.setattr(decks, "get_db_cursor", fake_cursor)
+    monkeypatch.setattr(word_export, "get_db_cursor", fake_cursor)
+    return rows
+
+
+def test_export_deck(rows):
+    response = client.get("/api/decks/7/export", params={"columns": "word,gender,meanings,forms"})
+    assert response.status_code == 200
+    assert response.headers["content-disposition"] == 'attachment; filename="deck-7.csv"'
+    assert response.text.splitlines() == ["word,gender,meanings,forms", 'hus,neuter,bygning til å bo i,"huset, hus, husene"']
+
+
+def test_export_other_users_deck(rows):
+    response = client.get("/api/decks/8/export")
+    assert response.status_code == 404
+
+
+def test_export_unknown_column(rows):
+    response = client.get("/api/decks/7/export", params={"columns": "word,colour"})
+    assert response.status_code == 400
+
+
+def test_gender_and_forms_from_entry():
+    entry = WordEntry(word="hus", senses=[
+        {"category": "substantiv", "meanings": [{"description": "bygning"}], "word_forms": [
+            {"label": "entall", "forms": ["hus", "huset"]},
+            {"label": "flertall", "forms": ["hus", "husa", "husene"]},
+        ]},
+        {"category": "substantiv", "gender": "nøytrum", "meanings": [{"description": "hjem"}]},
+    ])
+    assert _gender(entry) == "nøytrum"
+    assert _forms(entry) == "hus, huset, husa, husene"
@@ -0,0 +1,34 @@
+"""
+Practice card counts are bounded, so one request can't generate millions
+of cards.
+"""
+import pytest
+from fastapi import FastAPI
+from fastapi.testclient import TestClient
+
+from auth_utils import get_current_user
+from routes import review
+
+app = FastAPI()
+app.include_router(review.router)
+app.dependency_overrides[get_current_user] = lambda: {"id": 1, "email": "test@example.com"}
+client = TestClient(app)
+
+
+@pytest.mark.parametrize("count", [0, -1, 101, 1000000])
+def test_practice_count_out_of_range(count):
+    response = client.get("/review/practice", params={"language": "no", "count": count})
+    assert response.status_code == 422
+
+
+def test_practice_count_in_range():
+    response = client.get("/review/practice", params={"language": "no", "count": 100})
+    assert response.status_code == 200
+    assert response.json()["count"] == 100
+
+
+@pytest.mark.parametrize("practice", [-1, 101, 1000000])
+def test_due_practice_out_of_range(practice):
+    # Rejected before the database is reached
+    response = client.get("/review/due", params={"practice": practice, "practice_language": "no"})
+    assert response.status_code == 422
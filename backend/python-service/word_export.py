@@ -0,0 +1,109 @@
+"""
+CSV, TSV and Quizlet exports of a user's words, shared by the learning
+queue export and the deck export.
+
+Queries select the chosen columns' EXPORT_COLUMNS expressions over words
+(w), word_types (wt), languages (l) and the user's user_progress row (up).
+"""
+from fastapi import HTTPException
+from fastapi.responses import StreamingResponse
+from typing import List, Sequence
+from db_utils import get_db_cursor
+from routes.words import EXPORT_BATCH_SIZE
+import csv
+import io
+
+# Columns an export can include -> SQL expressions over the user's words
+EXPORT_COLUMNS = {
+    "word": "w.word",
+    "wordtype": "wt.wordtype",
+    "gender": "w.gender",
+    "pronunciation": "w.pronunciation",
+    "meanings": "(SELECT GROUP_CONCAT(m.definition ORDER BY m.id SEPARATOR '; ') FROM meanings m WHERE m.word_id = w.id)",
+    "examples": "(SELECT GROUP_CONCAT(m.examples ORDER BY m.id SEPARATOR '; ') FROM meanings m WHERE m.word_id = w.id)",
+    "forms": "w.forms",
+    "language": "l.language",
+    "cefr_level": "w.cefr_level",
+    "frequency_rank": "w.frequency_rank",
+    "status": "up.status",
+    "next_review": "up.next_review",
+}
+
+# Export formats -> (delimiter, media type)
+EXPORT_FORMATS = {
+    "csv": (",", "text/csv"),
+    "tsv": ("\t", "text/tab-separated-values"),
+    # Quizlet's import default: term, tab, definition, one card per line
+    "quizlet": ("\t", "text/plain"),
+}
+
+
+def export_columns(format: str, columns: str) -> List[str]:
+    """
+    Check an export's format and parse its comma-separated columns.
+
+    Raises:
+        HTTPException: 400 for an unknown format or column, or a Quizlet
+            export without a definition column
+    """
+    if format not in EXPORT_FORMATS:
+        raise HTTPException(status_code=400, detail=f"Unknown format: {format}")
+    names = [name.strip().lower() for name in columns.split(",") if name.strip()]
+    unknown = [name for name in names if name not in EXPORT_COLUMNS]
+    if not names or unknown:
+        raise HTTPException(
+            status_code=400,
+            detail=f"Unknown columns: {', '.join(unknown) or '(none given)'}. Available: {', '.join(EXPORT_COLUMNS)}"
+        )
+    if format == "quizlet" and len(names) < 2:
+        raise HTTPException(status_code=400, detail="Quizlet exports need a term column and at least one definition column")
+    return names
+
+
+def export_response(query: str, params: Sequence, names: List[str], format: str, filename: str) -> StreamingResponse:
+    """
+    Stream the rows of query as an attachment named filename plus the
+    format's extension.
+
+    The Quizlet format has no header row and two fields per card: the first
+    column is the term and the others, joined, the definition. Tabs and line
+    breaks inside values become spaces, as they would split the card.
+
+    Rows are streamed as they are read, so a database error partway through
+    cuts the file short rather than returning an error status.
+    """
+    delimiter, media_type = EXPORT_FORMATS[format]
+
+    def field(value):
+        return "" if value is None else " ".join(str(value).split())
+
+    def lines():
+        # Rows are read in batches as the response is written, so large
+        # exports are never held in memory
+        out = io.StringIO()
+        writer = csv.writer(out, delimiter=delimiter)
+        if format != "quizlet":
+            writer.writerow(names)
+        with get_db_cursor(commit=False, dictionary=False) as (db, cursor):
+            cursor.execute(query, tuple(params))
+            while True:
+                rows = cursor.fetchmany(EXPORT_BATCH_SIZE)
+                if not rows:
+                    break
+                for row in rows:
+                    if format == "quizlet":
+                        definition = " – ".join(v for v in map(field, row[1:]) if v)
+                        out.write(f"{field(row[0])}{delimiter}{definition}\n")
+                    else:
+                        writer.writerow("" if value is None else value for value in row)
+                yield out.getvalue()
+                out.seek(0)
+                out.truncate()
+        yield out.getvalue()
+
+    filename = f"{filename}-quizlet.txt" if format == "quizlet" else f"{filename}.{format}"
+    return StreamingResponse(
+        lines(),
+        media_type=f"{media_type}; charset=utf-8",
+        headers={"Content-Disposition": f'attachment; filename="{filename}"'},
+    )
@@ -1,5 +1,6 @@
 -- Vocabulary App Database Schema
--- Run this script to create or update the database structure
+-- Run this script to create the database structure. Databases created
+-- before a column was added are brought up to date by backend/migrations.
 
 -- Create languages table
 CREATE TABLE IF NOT EXISTS languages (
@@ -18,8 +19,10 @@ CREATE TABLE IF NOT EXISTS word_types (
 CREATE TABLE IF NOT EXISTS users (
     id INT PRIMARY KEY AUTO_INCREMENT,
     email VARCHAR(255) UNIQUE NOT NULL,
-    password_hash VARCHAR(255) NOT NULL,
+    password_hash VARCHAR(255) NULL,
     type VARCHAR(50) DEFAULT 'basic',
+    google_sub VARCHAR(255) UNIQUE NULL,
+    role ENUM('user', 'admin') DEFAULT 'user',
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
@@ -29,6 +32,19 @@ CREATE TABLE IF NOT EXISTS words (
     word VARCHAR(255) NOT NULL,
     wordtype INT,
     language INT,
+    idempotency_key CHAR(64) UNIQUE NULL,
+    pronunciation VARCHAR(255) NULL,
+    etymology TEXT NULL,
+    source VARCHAR(100) NULL,
+    source_url VARCHAR(2048) NULL,
+    scraped_at DATETIME NULL,
+    license VARCHAR(100) NULL,
+    frequency_rank INT NULL,
+    frequency_band TINYINT NULL,
+    cefr_level CHAR(2) NULL,
+    inflection_count SMALLINT NULL,
+    gender VARCHAR(50) NULL,
+    forms TEXT NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     FOREIGN KEY (wordtype) REFERENCES word_types(id),
     FOREIGN KEY (language) REFERENCES languages(id),
@@ -43,11 +59,34 @@ CREATE TABLE IF NOT EXISTS meanings (
     language_id INT NOT NULL,
     definition TEXT NOT NULL,
     note TEXT,
+    examples TEXT NULL,
     FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
     FOREIGN KEY (language_id) REFERENCES languages(id),
     INDEX idx_word_id (word_id)
 );
 
+-- Create grammar_topics table (small taxonomy, e.g. "verbs taking dative")
+CREATE TABLE IF NOT EXISTS grammar_topics (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    language_id INT NULL,
+    parent_id INT NULL,
+    FOREIGN KEY (language_id) REFERENCES languages(id),
+    FOREIGN KEY (parent_id) REFERENCES grammar_topics(id) ON DELETE SET NULL,
+    UNIQUE KEY unique_topic_language (name, language_id)
+);
+
+-- Create word_grammar_topics link table
+CREATE TABLE IF NOT EXISTS word_grammar_topics (
+    word_id INT NOT NULL,
+    topic_id INT NOT NULL,
+    PRIMARY KEY (word_id, topic_id),
+    INDEX idx_topic_id (topic_id),
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
+    FOREIGN KEY (topic_id) REFERENCES grammar_topics(id) ON DELETE CASCADE
+);
+
 -- Create user_progress table for spaced repetition
 CREATE TABLE IF NOT EXISTS user_progress (
     id INT PRIMARY KEY AUTO_INCREMENT,
@@ -70,6 +109,27 @@ CREATE TABLE IF NOT EXISTS user_progress (
     FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE
 );
 
+-- Create decks table (a user's named word lists, e.g. for exporting)
+CREATE TABLE IF NOT EXISTS decks (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    user_id INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY unique_user_deck (user_id, name),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create deck_words link table
+CREATE TABLE IF NOT EXISTS deck_words (
+    deck_id INT NOT NULL,
+    word_id INT NOT NULL,
+    added_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (deck_id, word_id),
+    INDEX idx_deck_word_id (word_id),
+    FOREIGN KEY (deck_id) REFERENCES decks(id) ON DELETE CASCADE,
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE
+);
+
 -- Create user_statistics table
 CREATE TABLE IF NOT EXISTS user_statistics (
     id INT PRIMARY KEY AUTO_INCREMENT,
@@ -85,6 +145,38 @@ CREATE TABLE IF NOT EXISTS user_statistics (
     FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 );
 
+-- Create exercise_results table for free-text production exercises
+CREATE TABLE IF NOT EXISTS exercise_results (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    user_id INT NOT NULL,
+    word_id INT NOT NULL,
+    exercise_type VARCHAR(50) NOT NULL DEFAULT 'sentence',
+    answer TEXT NOT NULL,
+    used_word BOOLEAN DEFAULT FALSE,
+    spelling_errors JSON,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_user_word (user_id, word_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE
+);
+
+-- Create audit_log table (who scraped/created/updated/deleted what, and when)
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    user_id INT NULL,
+    user_email VARCHAR(255) NULL,
+    action VARCHAR(50) NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id VARCHAR(255) NULL,
+    details JSON,
+    request_id VARCHAR(64) NULL,
+    service VARCHAR(20) NOT NULL DEFAULT 'python',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_audit_created (created_at),
+    INDEX idx_audit_user (user_id, created_at),
+    INDEX idx_audit_entity (entity_type, entity_id)
+);
+
 -- Insert default languages if they don't exist
 INSERT IGNORE INTO languages (language, code) VALUES 
     ('Norwegian', 'no'),
@@ -0,0 +1,211 @@
+"use client";
+
+import { useState } from "react";
+import { analyzeFile, analyzeText, getFrequencyProfile, learnWord } from "@/lib/api";
+
+type UnknownWord = {
+  word: string;
+  forms: string[];
+  count: number;
+  frequency?: { rank: number; band: number } | null;
+  cefr?: { level: string; basis: string } | null;
+  word_id: number | null;
+};
+
+type Analysis = {
+  language: string;
+  total: number;
+  known_count: number;
+  partial: boolean;
+  source?: string;
+  unknown: UnknownWord[];
+};
+
+type BandShare = {
+  band: number;
+  up_to_rank: number;
+  tokens: number;
+  coverage: number;
+  cumulative_coverage: number;
+};
+
+type FrequencyProfile = {
+  tokens: number;
+  bands: BandShare[];
+  unranked: BandShare;
+};
+
+export default function AnalyzePage() {
+  const [text, setText] = useState("");
+  const [file, setFile] = useState<File | null>(null);
+  const [language, setLanguage] = useState("no-bm"); // Default to Norwegian Bokmål
+  const [analysis, setAnalysis] = useState<Analysis | null>(null);
+  const [profile, setProfile] = useState<FrequencyProfile | null>(null);
+  const [loading, setLoading] = useState(false);
+  const [error, setError] = useState<string | null>(null);
+  // Per word: "adding", "added" or an error message
+  const [added, setAdded] = useState<Record<string, string>>({});
+
+  const handleAnalyze = async () => {
+    if (!file && !text.trim()) {
+      setError("Please paste some text or choose a file.");
+      return;
+    }
+    setLoading(true);
+    setError(null);
+    setAdded({});
+    setProfile(null);
+
+    try {
+      // A file (e-book or subtitles) gets a ranked list of words to learn
+      setAnalysis(file ? await analyzeFile(file, language) : await analyzeText(text, language));
+      // Languages without a frequency list have no profile
+      if (!file) setProfile(await getFrequencyProfile(text, language).catch(() => null));
+    } catch (err: any) {
+      setError(err.message);
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  // Look the word up if it isn't stored yet, and add it to the learning queue
+  const handleAdd = async (word: string) => {
+    setAdded((prev) => ({ ...prev, [word]: "adding" }));
+    try {
+      await learnWord(word, analysis?.language || language);
+      setAdded((prev) => ({ ...prev, [word]: "added" }));
+    } catch (err: any) {
+      setAdded((prev) => ({ ...prev, [word]: err.message }));
+    }
+  };
+
+  // Add every suggested word not added yet, one after another
+  const handleAddAll = async () => {
+    if (!analysis) return;
+    for (const w of analysis.unknown) {
+      if (!added[w.word]) await handleAdd(w.word);
+    }
+  };
+
+  return (
+    <div className="p-6 max-w-4xl mx-auto">
+      <h1 className="text-3xl font-bold mb-6">Analyze Text</h1>
+
+      <textarea
+        placeholder="Paste a text to find the words you aren't learning yet..."
+        value={text}
+        onChange={(e) => setText(e.target.value)}
+        rows={8}
+        className="w-full rounded border px-3 py-2 shadow-sm mb-3"
+        disabled={file !== null}
+      />
+      <div className="flex items-center gap-2 mb-3 text-sm text-gray-600">
+        <span>or upload an e-book or subtitles:</span>
+        <input
+          type="file"
+          accept=".epub,.srt,.vtt,.txt"
+          onChange={(e) => setFile(e.target.files?.[0] || null)}
+        />
+      </div>
+      <div className="flex gap-2 mb-6">
+        <select
+          value={language}
+          onChange={(e) => setLanguage(e.target.value)}
+          className="rounded border px-3 py-2 shadow-sm bg-white"
+        >
+          <option value="no-bm">Norwegian Bokmål</option>
+          <option value="no-nn">Norwegian Nynorsk</option>
+          <option value="en">English</option>
+          <option value="es">Spanish</option>
+          <option value="de">German</option>
+        </select>
+        <button
+          onClick={handleAnalyze}
+          className="border rounded bg-ci_turquoise px-4 py-2 font-semibold text-ci_linen shadow"
+          disabled={loading}
+        >
+          {loading ? "Analyzing..." : "Analyze"}
+        </button>
+      </div>
+
+      {error && <p className="text-red-600 mb-4">{error}</p>}
+
+      {profile && (
+        <div className="mb-6">
+          <h2 className="text-xl font-semibold mb-2">Frequency profile</h2>
+          {profile.bands.map((band) => (
+            <div key={band.band} className="flex items-center gap-2 text-sm mb-1">
+              <span className="w-32 text-gray-600">
+                {band.up_to_rank ? `Top ${band.up_to_rank.toLocaleString()}` : "Rarer"}
+              </span>
+              <div className="flex-1 h-3 rounded bg-gray-100">
+                <div className="h-3 rounded bg-ci_turquoise" style={{ width: `${band.coverage * 100}%` }} />
+              </div>
+              <span className="w-40 text-right text-gray-600">
+                {Math.round(band.coverage * 100)}% ({Math.round(band.cumulative_coverage * 100)}% so far)
+              </span>
+            </div>
+          ))}
+          <p className="text-sm text-gray-500 mt-2">
+            {Math.round(profile.unranked.coverage * 100)}% of the text is names, rare words or not on the list.
+          </p>
+        </div>
+      )}
+
+      {analysis && (
+        <div className="mt-6">
+          {analysis.source ? (
+            <div className="flex items-center justify-between mb-4">
+              <p className="text-gray-600">
+                The {analysis.unknown.length} most useful of the {analysis.total - analysis.known_count} words
+                in {analysis.source} you aren't learning yet.
+              </p>
+              {analysis.unknown.length > 0 && (
+                <button
+                  onClick={handleAddAll}
+                  className="border rounded bg-green-600 px-3 py-1 text-sm font-semibold text-white shadow"
+                >
+                  Add all
+                </button>
+              )}
+            </div>
+          ) : (
+            <p className="text-gray-600 mb-4">
+              {analysis.unknown.length} of {analysis.total} words are new to you
+              {analysis.partial && " (the text was long, so some words are shown as written)"}.
+            </p>
+          )}
+          {analysis.unknown.length > 0 ? (
+            <ul className="divide-y rounded border">
+              {analysis.unknown.map((w) => (
+                <li key={w.word} className="flex items-center justify-between px-4 py-2">
+                  <div>
+                    <span className="font-semibold">{w.word}</span>
+                    {w.forms.length > 0 && <span className="ml-2 text-gray-500">({w.forms.join(", ")})</span>}
+                    <span className="ml-2 text-sm text-gray-400">×{w.count}</span>
+                    {w.cefr && <span className="ml-2 rounded bg-blue-100 px-1 text-xs text-blue-700">{w.cefr.level}</span>}
+                  </div>
+                  {added[w.word] === "added" ? (
+                    <span className="text-green-600">✅ Added</span>
+                  ) : added[w.word] && added[w.word] !== "adding" ? (
+                    <span className="text-red-600 text-sm">{added[w.word]}</span>
+                  ) : (
+                    <button
+                      onClick={() => handleAdd(w.word)}
+                      className="border rounded bg-green-600 px-3 py-1 text-sm font-semibold text-white shadow"
+                      disabled={added[w.word] === "adding"}
+                    >
+                      {added[w.word] === "adding" ? "Adding..." : w.word_id ? "Add" : "Fetch & Add"}
+                    </button>
+                  )}
+                </li>
+              ))}
+            </ul>
+          ) : (
+            <p className="text-green-600">You're learning every word in this text.</p>
+          )}
+        </div>
+      )}
+    </div>
+  );
+}
@@ -0,0 +1,17 @@
+import { NextRequest, NextResponse } from "next/server";
+
+export async function POST(req: NextRequest) {
+  const body = await req.text();
+  const res = await fetch(`http://vocabulary-app-go-service:8080/api/analyze/profile`, {
+    method: "POST",
+    headers: { "Content-Type": "application/json" },
+    body,
+  });
+  if (!res.ok) {
+    const errText = await res.text();
+    return NextResponse.json({ error: errText || "Frequency profile failed" }, { status: res.status });
+  }
+
+  const data = await res.json();
+  return NextResponse.json(data);
+}
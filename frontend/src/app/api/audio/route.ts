@@ -0,0 +1,27 @@
+import { NextResponse } from "next/server";
+
+// Proxies pronunciation recordings through the Go service, which caches them
+// and avoids CORS restrictions on the dictionaries' audio hosts.
+export async function GET(req: Request) {
+  const { searchParams } = new URL(req.url);
+  const url = searchParams.get("url");
+
+  if (!url) {
+    return NextResponse.json({ error: "Missing url" }, { status: 400 });
+  }
+
+  const res = await fetch(
+    `http://vocabulary-app-go-service:8080/api/audio?url=${encodeURIComponent(url)}`
+  );
+  if (!res.ok) {
+    const errText = await res.text();
+    return NextResponse.json({ error: errText || "Audio unavailable" }, { status: res.status });
+  }
+
+  return new NextResponse(res.body, {
+    headers: {
+      "Content-Type": res.headers.get("Content-Type") || "audio/mpeg",
+      "Cache-Control": res.headers.get("Cache-Control") || "public, max-age=604800",
+    },
+  });
+}
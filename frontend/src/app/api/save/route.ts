@@ -1,12 +1,21 @@
 import { NextRequest, NextResponse } from "next/server";
 
+// word_types ids in seed order (backend/schema.sql)
+const WORDTYPE_IDS: Record<string, number> = {
+  noun: 1,
+  verb: 2,
+  adjective: 3,
+  adverb: 4,
+  pronoun: 5,
+  preposition: 6,
+  conjunction: 7,
+  interjection: 8,
+};
+
 // Dummy mapping functions—replace with your real logic/data!
-const getWordtypeId = (category: string) => {
-  // Map scraped category to your DB wordtype_id
-  if (category === "substantiv") return 1; // Noun
-  if (category === "verb") return 2;
-  // ...etc
-  return 1; // default to noun
+const getWordtypeId = (partOfSpeech?: string) => {
+  // Map the normalized part of speech to your DB wordtype_id
+  return WORDTYPE_IDS[partOfSpeech ?? ""] ?? 1; // default to noun
 };
 const getLanguageId = (lang: string) => 1; // e.g. 1 for Norwegian
 
@@ -19,7 +28,7 @@ export async function POST(req: NextRequest) {
   }
 
   // Use first sense for wordtype/category (customize as needed)
-  const wordtype_id = getWordtypeId(senses[0].category);
+  const wordtype_id = getWordtypeId(senses[0].part_of_speech);
   const language_id = getLanguageId("no"); // or get from UI/context
 
   // Flatten meanings from all senses
@@ -3,15 +3,18 @@ import { NextResponse } from "next/server";
 export async function GET(req: Request) {
   const { searchParams } = new URL(req.url);
   const word = searchParams.get("word");
-  const language = searchParams.get("language") || "no-bm"; // Default to Norwegian Bokmål
+  // Without a language the Go service detects it, trying ?languages= in order
+  const language = searchParams.get("language");
+  const languages = searchParams.get("languages");
 
   if (!word) {
     return NextResponse.json({ error: "Missing word" }, { status: 400 });
   }
 
-  const res = await fetch(
-    `http://vocabulary-app-go-service:8080/api/scrape?word=${encodeURIComponent(word)}&language=${encodeURIComponent(language)}`
-  );
+  const params = new URLSearchParams({ word });
+  if (language) params.set("language", language);
+  if (languages) params.set("languages", languages);
+  const res = await fetch(`http://vocabulary-app-go-service:8080/api/scrape?${params}`);
   if (!res.ok) {
     const errText = await res.text();
     return NextResponse.json({ error: errText || "Scraper failed" }, { status: res.status });
@@ -0,0 +1,22 @@
+import { NextResponse } from "next/server";
+
+export async function GET(req: Request) {
+  const { searchParams } = new URL(req.url);
+  const q = searchParams.get("q");
+  const language = searchParams.get("language") || "no-bm";
+
+  if (!q) {
+    return NextResponse.json({ error: "Missing q" }, { status: 400 });
+  }
+
+  const res = await fetch(
+    `http://vocabulary-app-go-service:8080/api/suggest?q=${encodeURIComponent(q)}&language=${encodeURIComponent(language)}`
+  );
+  if (!res.ok) {
+    const errText = await res.text();
+    return NextResponse.json({ error: errText || "Suggest failed" }, { status: res.status });
+  }
+
+  const data = await res.json();
+  return NextResponse.json(data, { headers: { "Cache-Control": "public, max-age=300" } });
+}
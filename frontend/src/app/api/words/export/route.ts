@@ -0,0 +1,22 @@
+import { NextResponse } from "next/server";
+
+// Streams the stored vocabulary export from the Python service through
+// unchanged, one JSON object per line, without buffering it here.
+export async function GET(req: Request) {
+  const { searchParams } = new URL(req.url);
+  const language = searchParams.get("language");
+  const query = language ? `?language=${encodeURIComponent(language)}` : "";
+
+  const res = await fetch(`http://vocabulary-app-python-service:8000/words/export${query}`);
+  if (!res.ok) {
+    const data = await res.json().catch(() => ({}));
+    return NextResponse.json({ error: data.detail || "Export failed" }, { status: res.status });
+  }
+
+  return new NextResponse(res.body, {
+    headers: {
+      "Content-Type": "application/x-ndjson",
+      "Content-Disposition": 'attachment; filename="words.ndjson"',
+    },
+  });
+}
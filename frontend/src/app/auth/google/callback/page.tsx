@@ -0,0 +1,36 @@
+// app/auth/google/callback/page.tsx
+"use client";
+import { useEffect, useState } from "react";
+import { useRouter } from "next/navigation";
+import { saveAuthToken, setupAutoLogout } from "@/lib/auth";
+
+export default function GoogleCallbackPage() {
+  const [error, setError] = useState("");
+  const router = useRouter();
+
+  useEffect(() => {
+    // The backend passes the token in the URL fragment so it never reaches server logs
+    const params = new URLSearchParams(window.location.hash.slice(1));
+    const token = params.get("token");
+    const expiresAt = params.get("expires_at");
+
+    if (!token || !expiresAt) {
+      setError(params.get("error") || "Google login failed.");
+      return;
+    }
+
+    saveAuthToken(token, expiresAt);
+    window.dispatchEvent(new Event("authStateChanged"));
+    setupAutoLogout(() => {
+      alert("Your session has expired. Please log in again.");
+      router.push("/auth/login");
+    });
+    router.push("/dashboard");
+  }, [router]);
+
+  return (
+    <div className="flex h-screen items-center justify-center bg-ci_linen text-ci_black">
+      {error ? <p className="text-red-400">{error}</p> : <p>Signing you in...</p>}
+    </div>
+  );
+}
@@ -81,6 +81,12 @@ export default function LoginPage() {
           Log in
         </button>
       </form>
+      <a
+        href={`${API_BASE_URL}/auth/google/login`}
+        className="mt-4 block rounded bg-foam py-2 text-center text-ci_black font-semibold"
+      >
+        Sign in with Google
+      </a>
     </div>
   </div>
 );
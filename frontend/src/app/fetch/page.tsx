@@ -1,7 +1,7 @@
 "use client";
 
-import { useState } from "react";
-import { fetchWord, saveWord } from "@/lib/api";
+import { useEffect, useState } from "react";
+import { fetchWord, saveWord, playAudio, suggestWords } from "@/lib/api";
 import SenseCard from "@/components/SenseCard";
 
 
@@ -13,11 +13,16 @@ type WordForm = {
   gender?: string;
   degree?: string;
   tense?: string;
+  person?: string;
+  mood?: string;
+  voice?: string;
+  case?: string;
 };
 
 type Sense = {
   id: string;
   category: string;
+  part_of_speech?: string;
   gender?: string;
   article?: string;
   meanings: { description: string; examples?: string[] }[];
@@ -25,9 +30,21 @@ type Sense = {
   word_forms?: WordForm[];
 };
 
+type AudioClip = { url: string; source: string; variant?: string; speaker?: string };
+
 type WordEntry = {
   word: string;
+  query?: string;
+  language?: string;
+  language_detected?: boolean;
   senses: Sense[];
+  audio?: AudioClip[];
+  source?: string;
+  source_url?: string;
+  scraped_at?: string;
+  license?: string;
+  frequency?: { rank: number; band: number };
+  cefr?: { level: string; basis: string };
 };
 
 export default function FetchPage() {
@@ -37,9 +54,28 @@ export default function FetchPage() {
   const [loading, setLoading] = useState(false);
   const [error, setError] = useState<string | null>(null);
   const [saved, setSaved] = useState(false);
+  const [suggestions, setSuggestions] = useState<string[]>([]);
 
-  const handleFetch = async () => {
-    if (!word.trim()) {
+  // Suggest words as the user types, once typing pauses
+  useEffect(() => {
+    const prefix = word.trim();
+    if (prefix.length < 2) {
+      setSuggestions([]);
+      return;
+    }
+    let cancelled = false;
+    const timer = setTimeout(async () => {
+      const result = await suggestWords(prefix, language);
+      if (!cancelled) setSuggestions(result);
+    }, 200);
+    return () => {
+      cancelled = true;
+      clearTimeout(timer);
+    };
+  }, [word, language]);
+
+  const lookUp = async (target: string) => {
+    if (!target.trim()) {
       setError("Please enter a word.");
       return;
     }
@@ -48,7 +84,7 @@ export default function FetchPage() {
     setSaved(false);
 
     try {
-      const result: WordEntry = await fetchWord(word, language);
+      const result: WordEntry = await fetchWord(target, language);
       console.log("Fetched data:", result);
       setData(result);
     } catch (err: any) {
@@ -58,10 +94,18 @@ export default function FetchPage() {
     }
   };
 
+  const handleFetch = () => lookUp(word);
+
+  // Follow a cross-reference to the entry it names
+  const handleReference = (target: string) => {
+    setWord(target);
+    lookUp(target);
+  };
+
   const handleSave = async () => {
     if (!data) return;
     try {
-      await saveWord(data, language);
+      await saveWord(data, data.language || language);
       setSaved(true);
     } catch (err: any) {
       setError(err.message);
@@ -78,13 +122,20 @@ export default function FetchPage() {
           placeholder="Enter a word..."
           value={word}
           onChange={(e) => setWord(e.target.value)}
+          list="word-suggestions"
           className="flex-1 rounded border px-3 py-2 shadow-sm"
         />
+        <datalist id="word-suggestions">
+          {suggestions.map((s) => (
+            <option key={s} value={s} />
+          ))}
+        </datalist>
         <select
           value={language}
           onChange={(e) => setLanguage(e.target.value)}
           className="rounded border px-3 py-2 shadow-sm bg-white"
         >
+          <option value="">Detect language</option>
           <option value="no-bm">Norwegian Bokmål</option>
           <option value="no-nn">Norwegian Nynorsk</option>
           <option value="en">English</option>
@@ -104,14 +155,73 @@ export default function FetchPage() {
 
       {data && (
         <div className="mt-6">
-          <h2 className="text-3xl font-bold mb-4">{data.word}</h2>
+          <h2 className="text-3xl font-bold mb-4">
+            {data.word}
+            {data.frequency && (
+              <span
+                className="ml-3 align-middle rounded bg-gray-100 px-2 py-1 text-sm font-normal text-gray-600"
+                title={`Rank ${data.frequency.rank} on the frequency list`}
+              >
+                Frequency band {data.frequency.band}/5
+              </span>
+            )}
+            {data.cefr && (
+              <span
+                className="ml-2 align-middle rounded bg-blue-100 px-2 py-1 text-sm font-normal text-blue-700"
+                title={data.cefr.basis === "list" ? "From a CEFR word list" : "Estimated from word frequency"}
+              >
+                {data.cefr.level}
+                {data.cefr.basis === "frequency" && "?"}
+              </span>
+            )}
+          </h2>
+          {data.language_detected && (
+            <p className="text-gray-600 -mt-3 mb-4">
+              Detected language: <span className="font-semibold">{data.language}</span>
+            </p>
+          )}
+          {data.query && (
+            <p className="text-gray-600 -mt-3 mb-4">
+              Showing <span className="font-semibold">{data.word}</span>, the dictionary form of “{data.query}”.
+            </p>
+          )}
+          {data.audio && data.audio.length > 0 && (
+            <div className="flex gap-2 mb-4">
+              {data.audio.map((clip, i) => (
+                <button
+                  key={i}
+                  type="button"
+                  onClick={() => playAudio(clip.url)}
+                  className="border rounded px-3 py-1 text-sm shadow-sm"
+                  title={clip.speaker ? `Recorded by ${clip.speaker} (${clip.source})` : clip.source}
+                >
+                  ▶ {clip.variant || `Recording ${i + 1}`}
+                </button>
+              ))}
+            </div>
+          )}
 
           {data.senses.length > 0 ? (
-            data.senses.map((sense) => <SenseCard key={sense.id} sense={sense} />)
+            data.senses.map((sense) => <SenseCard key={sense.id} sense={sense} onReference={handleReference} />)
           ) : (
             <p className="text-gray-500 mt-4">No senses found for this word.</p>
           )}
 
+          {data.source && (
+            <p className="text-sm text-gray-500 mt-4">
+              Source:{" "}
+              {data.source_url ? (
+                <a href={data.source_url} target="_blank" rel="noopener noreferrer" className="underline">
+                  {data.source}
+                </a>
+              ) : (
+                data.source
+              )}
+              {data.license && <span> • {data.license}</span>}
+              {data.scraped_at && <span> • fetched {new Date(data.scraped_at).toLocaleString()}</span>}
+            </p>
+          )}
+
           <div className="mt-6 flex gap-3">
             <button
               onClick={handleSave}
@@ -13,6 +13,10 @@ type WordForm = {
   gender?: string;
   degree?: string;
   tense?: string;
+  person?: string;
+  mood?: string;
+  voice?: string;
+  case?: string;
 };
 
 type Sense = {
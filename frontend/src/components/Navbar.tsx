@@ -9,6 +9,7 @@ const navItems = [
   { name: "Dashboard", path: "/dashboard" },
   { name: "Study Today", path: "/study" },
   { name: "Fetch New Cards", path: "/fetch" },
+  { name: "Analyze Text", path: "/analyze" },
 ];
 
 export default function Navbar() {
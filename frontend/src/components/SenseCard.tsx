@@ -1,4 +1,5 @@
 import WordFormTable from "@/components/WordFormTable";
+import { playAudio } from "@/lib/api";
 
 type Meaning = { description: string; examples?: string[] };
 type Expression = { phrase: string; explanation: string };
@@ -10,6 +11,10 @@ type WordForm = {
   gender?: string;
   degree?: string;
   tense?: string;
+  person?: string;
+  mood?: string;
+  voice?: string;
+  case?: string;
 };
 
 type FlatWordEntry = {
@@ -22,11 +27,27 @@ type FlatWordEntry = {
   expressions?: { phrase: string; explanation: string }[];
 };
 
+type Pronunciation = { text: string; notation?: string; audio?: string; variant?: string };
+type Reference = { word: string; relation: string; article_id?: string };
+
+const RELATION_LABELS: Record<string, string> = {
+  see: "See",
+  compare: "Compare",
+  origin: "From",
+  related: "Related",
+};
+
 type Sense = {
   id: string;
   category: string;
+  part_of_speech?: string;
   gender?: string;
   article?: string;
+  pronunciations?: Pronunciation[];
+  etymology?: string;
+  synonyms?: string[];
+  antonyms?: string[];
+  references?: Reference[];
   meanings: { description: string; examples?: string[] }[];
   word_forms?: any[];
   expressions?: { phrase: string; explanation: string }[];
@@ -35,16 +56,45 @@ type Sense = {
 type WordEntry = FlatWordEntry & { senses?: Sense[] };
 
 
-export default function SenseCard({ sense }: { sense: Sense }) {
+export default function SenseCard({
+  sense,
+  onReference,
+}: {
+  sense: Sense;
+  // Called with the target word when a reference is clicked
+  onReference?: (word: string) => void;
+}) {
   return (
     <div className="border rounded-lg shadow p-6 bg-white mt-6">
       {/* Sense Header */}
       <div className="mb-4">
         <h3 className="text-2xl font-semibold">{sense.category}</h3>
+        {sense.part_of_speech && sense.part_of_speech !== "other" && (
+          <p className="text-sm uppercase tracking-wide text-gray-500">{sense.part_of_speech.replace("_", " ")}</p>
+        )}
         <p className="text-gray-700 text-lg">
           {sense.gender && <span className="mr-2">• {sense.gender}</span>}
           {sense.article && <span className="mr-2">• Article: {sense.article}</span>}
         </p>
+        {sense.pronunciations && sense.pronunciations.length > 0 && (
+          <p className="text-gray-600 mt-1">
+            {sense.pronunciations.map((p, i) => (
+              <span key={i} className="mr-3">
+                {p.notation === "ipa" ? `/${p.text}/` : `[${p.text}]`}
+                {p.variant && <span className="text-sm ml-1">({p.variant.toUpperCase()})</span>}
+                {p.audio && (
+                  <button
+                    type="button"
+                    className="ml-1 text-blue-600 text-sm"
+                    onClick={() => playAudio(p.audio!)}
+                  >
+                    ▶
+                  </button>
+                )}
+              </span>
+            ))}
+          </p>
+        )}
       </div>
 
       {/* Meanings */}
@@ -69,6 +119,48 @@ export default function SenseCard({ sense }: { sense: Sense }) {
         ))}
       </ol>
 
+      {/* Related words */}
+      {((sense.synonyms && sense.synonyms.length > 0) || (sense.antonyms && sense.antonyms.length > 0)) && (
+        <div className="mt-6 text-gray-700">
+          {sense.synonyms && sense.synonyms.length > 0 && (
+            <p>
+              <span className="font-semibold">Synonyms:</span> {sense.synonyms.join(", ")}
+            </p>
+          )}
+          {sense.antonyms && sense.antonyms.length > 0 && (
+            <p>
+              <span className="font-semibold">Antonyms:</span> {sense.antonyms.join(", ")}
+            </p>
+          )}
+        </div>
+      )}
+
+      {/* References */}
+      {sense.references && sense.references.length > 0 && (
+        <div className="mt-6 text-gray-700">
+          {sense.references.map((ref, i) => (
+            <span key={i} className="mr-3">
+              <span className="font-semibold">{RELATION_LABELS[ref.relation] ?? "Related"}:</span>{" "}
+              {onReference ? (
+                <button type="button" onClick={() => onReference(ref.word)} className="text-blue-600 underline">
+                  {ref.word}
+                </button>
+              ) : (
+                ref.word
+              )}
+            </span>
+          ))}
+        </div>
+      )}
+
+      {/* Etymology */}
+      {sense.etymology && (
+        <div className="mt-6">
+          <h4 className="text-lg font-semibold mb-2">Origin (Opphav)</h4>
+          <p className="text-gray-700">{sense.etymology}</p>
+        </div>
+      )}
+
       {/* Word Forms */}
       {sense.word_forms && sense.word_forms.length > 0 && (
         <div className="mt-6">
@@ -6,6 +6,10 @@ type WordForm = {
   gender?: string;
   degree?: string;
   tense?: string;
+  person?: string;
+  mood?: string;
+  voice?: string;
+  case?: string;
 };
 
 export default function WordFormTable({ forms }: { forms: WordForm[] }) {
@@ -42,7 +46,11 @@ export default function WordFormTable({ forms }: { forms: WordForm[] }) {
                 form.gender && `Gender: ${form.gender}`,
                 form.definiteness && `Definiteness: ${form.definiteness}`,
                 form.degree && `Degree: ${form.degree}`,
-                form.tense && `Tense: ${form.tense}`]
+                form.tense && `Tense: ${form.tense}`,
+                form.person && `Person: ${form.person}`,
+                form.mood && `Mood: ${form.mood}`,
+                form.voice && `Voice: ${form.voice}`,
+                form.case && `Case: ${form.case}`]
                 .filter(Boolean)
                 .join(" • ")}
             </td>
@@ -28,6 +28,7 @@ async function fetchWithAuth(url: string, options: RequestInit = {}) {
 }
 
 // Fetch word data from scraper (via Next.js API route)
+// An empty language lets the service detect it
 export async function fetchWord(word: string, language: string = "no-bm") {
   const res = await fetch(`/api/scrape?word=${encodeURIComponent(word)}&language=${encodeURIComponent(language)}`);
   if (!res.ok) {
@@ -36,6 +37,16 @@ export async function fetchWord(word: string, language: string = "no-bm") {
   return res.json();
 }
 
+// Word suggestions for a prefix (empty when the source has no autocomplete)
+export async function suggestWords(prefix: string, language: string = "no-bm"): Promise<string[]> {
+  const res = await fetch(`/api/suggest?q=${encodeURIComponent(prefix)}&language=${encodeURIComponent(language)}`);
+  if (!res.ok) {
+    return [];
+  }
+  const data = await res.json();
+  return data.suggestions || [];
+}
+
 // Save word data to DB (via Python service proxy)
 export async function saveWord(data: any, language?: string) {
   const res = await fetch(`${API_BASE_URL}/words/add`, {
@@ -115,3 +126,66 @@ export async function addWordToLearning(wordId: number) {
   }
   return res.json();
 }
+
+export async function analyzeText(text: string, language: string) {
+  const res = await fetchWithAuth(`${API_BASE_URL}/review/analyze`, {
+    method: "POST",
+    body: JSON.stringify({ text, language }),
+  });
+  if (!res.ok) {
+    const error = await res.json().catch(() => ({}));
+    throw new Error(error.detail || "Failed to analyze text");
+  }
+  return res.json();
+}
+
+// How a text's words spread over the frequency bands (via Next.js API route)
+export async function getFrequencyProfile(text: string, language: string) {
+  const res = await fetch(`/api/analyze/profile`, {
+    method: "POST",
+    headers: { "Content-Type": "application/json" },
+    body: JSON.stringify({ text, language }),
+  });
+  if (!res.ok) {
+    throw new Error(`Failed to get frequency profile: ${res.statusText}`);
+  }
+  return res.json();
+}
+
+// Rank the new words of an e-book or subtitle file. Sent as a multipart
+// form, so the browser sets the Content-Type rather than fetchWithAuth.
+export async function analyzeFile(file: File, language: string, limit: number = 50) {
+  const form = new FormData();
+  form.append("file", file);
+  form.append("language", language);
+  form.append("limit", String(limit));
+  const token = getAuthToken();
+  const res = await fetch(`${API_BASE_URL}/review/analyze/upload`, {
+    method: "POST",
+    headers: token ? { Authorization: `Bearer ${token}` } : {},
+    body: form,
+  });
+  if (!res.ok) {
+    const error = await res.json().catch(() => ({}));
+    throw new Error(error.detail || "Failed to analyze file");
+  }
+  return res.json();
+}
+
+// Add a word by name, looking it up and storing it first if needed
+export async function learnWord(word: string, language: string) {
+  const res = await fetchWithAuth(`${API_BASE_URL}/review/learn-word`, {
+    method: "POST",
+    body: JSON.stringify({ word, language }),
+  });
+  if (!res.ok) {
+    const error = await res.json().catch(() => ({}));
+    throw new Error(error.detail || "Failed to add word to learning queue");
+  }
+  return res.json();
+}
+
+// Play a pronunciation recording through the audio proxy (via Next.js API route)
+export function playAudio(url: string) {
+  return new Audio(`/api/audio?url=${encodeURIComponent(url)}`).play();
+}